@@ -0,0 +1,4 @@
+# Retrieve the custom domain of a PropelAuth environment and the DNS records it needs.
+data "propelauth_custom_domain" "example" {
+  environment = "Prod"
+}
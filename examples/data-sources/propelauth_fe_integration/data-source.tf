@@ -0,0 +1,4 @@
+# Retrieve the details of a Front-end Integration to PropelAuth by environment.
+data "propelauth_fe_integration" "example" {
+  environment = "Prod"
+}
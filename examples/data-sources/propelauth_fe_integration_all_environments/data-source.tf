@@ -0,0 +1,6 @@
+# Retrieve the details of the Front-end Integrations to PropelAuth for every environment.
+data "propelauth_fe_integration_all_environments" "example" {}
+
+output "prod_auth_url" {
+  value = data.propelauth_fe_integration_all_environments.example.prod.auth_url
+}
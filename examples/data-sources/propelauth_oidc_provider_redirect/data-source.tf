@@ -0,0 +1,10 @@
+# Retrieve the redirect url to register with your identity provider
+# for your prod environment.
+data "propelauth_oidc_provider_redirect" "keycloak_prod_redirect" {
+  environment      = "Prod"
+  oidc_provider_id = propelauth_oidc_provider.keycloak.id
+}
+
+output "keycloak_prod_redirect_result" {
+  value = data.propelauth_oidc_provider_redirect.keycloak_prod_redirect.redirect_url
+}
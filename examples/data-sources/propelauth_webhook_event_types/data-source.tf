@@ -0,0 +1,9 @@
+# List the event types webhook endpoints can subscribe to.
+data "propelauth_webhook_event_types" "all" {}
+
+# Subscribe to every user event.
+resource "propelauth_webhook_endpoint" "user_events" {
+  environment = "Prod"
+  url         = "https://events.example.com/propelauth"
+  event_types = [for name in data.propelauth_webhook_event_types.all.names : name if startswith(name, "user.")]
+}
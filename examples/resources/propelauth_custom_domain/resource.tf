@@ -4,4 +4,16 @@ resource "propelauth_custom_domain" "my_custom_domain" {
   environment = "Prod"
   domain      = "example.com"
   subdomain   = "app"
-}
\ No newline at end of file
+}
+
+# The DNS records can be created in one go with `for_each`, e.g. in an AWS Route 53 hosted zone.
+# Set `dns_zone` if the records belong to a delegated zone such as `app.example.com`.
+resource "aws_route53_record" "propelauth" {
+  for_each = { for record in propelauth_custom_domain.my_custom_domain.dns_records : record.type => record }
+
+  zone_id = aws_route53_zone.primary.zone_id
+  name    = each.value.name
+  type    = each.value.type
+  ttl     = each.value.ttl
+  records = [each.value.value]
+}
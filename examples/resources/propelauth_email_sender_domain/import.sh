@@ -0,0 +1,4 @@
+# Email sender domains can be imported by which environment they are in
+terraform import propelauth_email_sender_domain.example Prod
+# or
+terraform import propelauth_email_sender_domain.example Staging
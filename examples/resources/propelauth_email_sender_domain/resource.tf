@@ -0,0 +1,22 @@
+# Send magic links, invitations, and other emails from mail.example.com
+resource "propelauth_email_sender_domain" "prod" {
+  environment = "Prod"
+  domain      = "mail.example.com"
+  sender_name = "Acme"
+  reply_to    = "support@example.com"
+}
+
+# AWS Route53 Example
+resource "aws_route53_zone" "primary" {
+  name = "example.com"
+}
+
+resource "aws_route53_record" "email_sender_records" {
+  for_each = { for record in propelauth_email_sender_domain.prod.dns_records : record.purpose => record }
+
+  zone_id = aws_route53_zone.primary.zone_id
+  name    = each.value.name
+  type    = each.value.type
+  ttl     = each.value.ttl
+  records = [each.value.value]
+}
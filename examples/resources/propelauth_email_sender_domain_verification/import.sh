@@ -0,0 +1,4 @@
+# Email sender domain verifications can be imported by which environment they are in
+terraform import propelauth_email_sender_domain_verification.example Prod
+# or
+terraform import propelauth_email_sender_domain_verification.example Staging
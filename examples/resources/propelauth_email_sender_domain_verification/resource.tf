@@ -0,0 +1,12 @@
+# This resource will verify the domain once its SPF, DKIM, and return-path records have been set up.
+# See the `propelauth_email_sender_domain` example for how to do that with AWS Route 53.
+resource "propelauth_email_sender_domain_verification" "prod" {
+  depends_on = [aws_route53_record.email_sender_records]
+
+  environment = propelauth_email_sender_domain.prod.environment
+  domain      = propelauth_email_sender_domain.prod.domain
+
+  timeouts = {
+    create = "15m"
+  }
+}
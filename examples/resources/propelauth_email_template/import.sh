@@ -0,0 +1,2 @@
+# Import a customized email template by its template type
+terraform import propelauth_email_template.invite Invite
@@ -0,0 +1,17 @@
+# Brand the invitation email. The templates are kept next to the configuration
+# so changes to them are reviewed like any other change.
+resource "propelauth_email_template" "invite" {
+  template_type = "Invite"
+  subject       = "{{inviter_email}} invited you to join {{org_name}} on {{project_name}}"
+  html_body     = file("${path.module}/email_templates/invite.html")
+  text_body     = file("${path.module}/email_templates/invite.txt")
+}
+
+resource "propelauth_email_template" "magic_link" {
+  template_type = "MagicLink"
+  subject       = "Your {{project_name}} login link"
+  html_body     = <<-EOT
+    <img src="{{logo_url}}" alt="{{project_name}}" />
+    <p>Click <a href="{{magic_link}}">here</a> to log in. The link expires in {{expires_in_minutes}} minutes.</p>
+  EOT
+}
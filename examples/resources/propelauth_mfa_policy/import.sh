@@ -0,0 +1,3 @@
+# As there is only one mfa_policy per project there's no need to specify the id,
+# but terraform import requires an id to be specified, so we can use an arbitrary string here.
+terraform import propelauth_mfa_policy.example arbitrary_string_here
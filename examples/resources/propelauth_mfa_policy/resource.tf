@@ -0,0 +1,9 @@
+# Require MFA for everyone, with a week to set it up after it's first required
+resource "propelauth_mfa_policy" "example" {
+  allowed_factors           = ["TOTP", "Passkey"]
+  require_mfa_for_all_users = true
+  orgs_can_require_mfa      = true
+  enrollment_grace_period   = "7d"
+  recovery_codes_enabled    = true
+  recovery_code_count       = 10
+}
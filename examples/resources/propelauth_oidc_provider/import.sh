@@ -0,0 +1,2 @@
+# Import an existing OIDC provider by its ID
+terraform import propelauth_oidc_provider.keycloak 0a1b2c3d-4e5f-6a7b-8c9d-0e1f2a3b4c5d
@@ -0,0 +1,25 @@
+variable "keycloak_client_secret" {
+  type      = string
+  sensitive = true
+}
+
+resource "propelauth_image" "keycloak_icon" {
+  source     = "${path.module}/keycloak-icon.png"
+  version    = "0.1.0"
+  image_type = "icon"
+}
+
+# Federate login with an internal Keycloak realm. Its endpoints are
+# discovered from the issuer.
+resource "propelauth_oidc_provider" "keycloak" {
+  issuer        = "https://keycloak.example.com/realms/employees"
+  client_id     = "propelauth"
+  client_secret = var.keycloak_client_secret
+  scopes        = ["openid", "email", "profile"]
+  claim_mappings = {
+    first_name = "given_name"
+    last_name  = "family_name"
+  }
+  button_label  = "Log in with Keycloak"
+  icon_image_id = propelauth_image.keycloak_icon.image_id
+}
@@ -0,0 +1,2 @@
+# Import an existing membership by its environment, organization ID and user ID
+terraform import propelauth_org_membership.e2e_admin Staging/1189c444-8a2d-4c41-8b4b-ae43ce79a492/31c41c16-c281-44ae-9602-8a047e3bf33d
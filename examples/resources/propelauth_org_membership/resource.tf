@@ -0,0 +1,23 @@
+variable "e2e_password" {
+  type      = string
+  sensitive = true
+}
+
+resource "propelauth_organization" "e2e" {
+  environment = "Staging"
+  name        = "E2E Org"
+}
+
+resource "propelauth_user" "e2e_admin" {
+  environment = "Staging"
+  email       = "e2e-admin@example.com"
+  password    = var.e2e_password
+}
+
+# Make the seeded user an admin of the seeded organization.
+resource "propelauth_org_membership" "e2e_admin" {
+  environment = "Staging"
+  org_id      = propelauth_organization.e2e.id
+  user_id     = propelauth_user.e2e_admin.id
+  role        = "Admin"
+}
@@ -0,0 +1,27 @@
+# Typed properties for organizations. Plan tier and region are in the
+# token so the backend can authorize without a lookup.
+resource "propelauth_org_property_settings" "example" {
+  properties = [
+    {
+      name         = "plan_tier"
+      display_name = "Plan Tier"
+      field_type   = "Enum"
+      required     = true
+      in_jwt       = true
+      enum_values  = ["free", "team", "enterprise"]
+    },
+    {
+      name         = "region"
+      display_name = "Region"
+      field_type   = "Enum"
+      in_jwt       = true
+      enum_values  = ["us", "eu"]
+    },
+    {
+      name          = "csm_owner"
+      display_name  = "CSM Owner"
+      field_type    = "Text"
+      user_writable = "Write"
+    },
+  ]
+}
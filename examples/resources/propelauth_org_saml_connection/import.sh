@@ -0,0 +1,2 @@
+# Import an existing SAML connection by the environment and ID of its organization
+terraform import propelauth_org_saml_connection.acme Prod/1189c444-8a2d-4c41-8b4b-ae43ce79a492
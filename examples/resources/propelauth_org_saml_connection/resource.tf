@@ -0,0 +1,24 @@
+# Set up the SAML connection of an organization from its Okta metadata URL.
+resource "propelauth_org_saml_connection" "acme" {
+  environment      = "Prod"
+  org_id           = "1189c444-8a2d-4c41-8b4b-ae43ce79a492"
+  idp_metadata_url = "https://acme.okta.com/app/exk1a2b3c4d5e6f7g8h9/sso/saml/metadata"
+  idp_provider     = "Okta"
+  attribute_mappings = {
+    first_name = "firstName"
+    last_name  = "lastName"
+  }
+  role_mappings = {
+    engineering = "Admin"
+    sales       = "Member"
+  }
+}
+
+# Hand these to the organization's admins to finish setting up Okta.
+output "acme_saml_acs_url" {
+  value = propelauth_org_saml_connection.acme.sp_acs_url
+}
+
+output "acme_saml_entity_id" {
+  value = propelauth_org_saml_connection.acme.sp_entity_id
+}
@@ -0,0 +1,2 @@
+# Import an existing organization by its environment and ID
+terraform import propelauth_organization.demo Prod/1189c444-8a2d-4c41-8b4b-ae43ce79a492
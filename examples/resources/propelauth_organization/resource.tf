@@ -0,0 +1,22 @@
+# Provision the organization for your own staff in each environment.
+resource "propelauth_organization" "staff" {
+  for_each = toset(["Test", "Staging", "Prod"])
+
+  environment        = each.key
+  name               = "Acme Staff"
+  domain             = "acme.com"
+  autojoin_by_domain = true
+  restrict_to_domain = true
+  can_setup_saml     = true
+  metadata = {
+    internal = "true"
+  }
+}
+
+# A demo tenant for the sales team, capped at a few seats.
+resource "propelauth_organization" "demo" {
+  environment              = "Prod"
+  name                     = "Demo Co"
+  max_users                = 5
+  custom_role_mapping_name = "Demo Roles"
+}
@@ -0,0 +1,2 @@
+# Import using the target environment as the ID: `Test`, `Staging`, or `Prod`. For example:
+terraform import propelauth_session_settings.prod Prod
@@ -0,0 +1,16 @@
+# Short-lived tokens and sessions in production, per our session management controls
+resource "propelauth_session_settings" "prod" {
+  environment               = "Prod"
+  access_token_ttl          = "15m"
+  refresh_token_lifetime    = "12h"
+  session_idle_timeout      = "30m"
+  session_absolute_lifetime = "12h"
+  max_concurrent_sessions   = 3
+}
+
+# Longer sessions in Test for ease of development
+resource "propelauth_session_settings" "test" {
+  environment               = "Test"
+  session_idle_timeout      = "7d"
+  session_absolute_lifetime = "30d"
+}
@@ -4,8 +4,35 @@ variable "github_client_secret" {
   sensitive = true
 }
 
+variable "microsoft_client_secret" {
+  type      = string
+  sensitive = true
+}
+
 resource "propelauth_social_login" "github_sso" {
   social_provider = "GitHub"
   client_id       = "my-client-id"
   client_secret   = var.github_client_secret
 }
+
+# The client secret for Sign in with Apple is generated from your key material
+# and renewed before it expires.
+resource "propelauth_social_login" "apple_sso" {
+  social_provider = "Apple"
+  client_id       = "com.example.signin"
+  apple = {
+    team_id     = "ABCDE12345"
+    key_id      = "FGHIJ67890"
+    private_key = file("AuthKey_FGHIJ67890.p8")
+  }
+}
+
+# Only allow work accounts from one Azure AD tenant
+resource "propelauth_social_login" "microsoft_sso" {
+  social_provider = "Microsoft"
+  client_id       = "my-client-id"
+  client_secret   = var.microsoft_client_secret
+  microsoft = {
+    tenant_id = "contoso.onmicrosoft.com"
+  }
+}
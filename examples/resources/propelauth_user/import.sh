@@ -0,0 +1,2 @@
+# Import an existing user by their environment and ID
+terraform import propelauth_user.e2e_owner Staging/31c41c16-c281-44ae-9602-8a047e3bf33d
@@ -0,0 +1,19 @@
+variable "e2e_password" {
+  type      = string
+  sensitive = true
+  ephemeral = true
+}
+
+# Deterministic accounts for the end-to-end tests of the Staging environment.
+resource "propelauth_user" "e2e_owner" {
+  environment = "Staging"
+  email       = "e2e-owner@example.com"
+  first_name  = "E2E"
+  last_name   = "Owner"
+  password    = var.e2e_password
+  # Bump when e2e_password changes, so the new password is set
+  password_version = 1
+  properties = {
+    tos_accepted = "true"
+  }
+}
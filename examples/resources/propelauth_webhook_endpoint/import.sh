@@ -0,0 +1,2 @@
+# Import an existing webhook endpoint by its environment and ID
+terraform import propelauth_webhook_endpoint.warehouse Prod/ep_2b4c6d8e0f
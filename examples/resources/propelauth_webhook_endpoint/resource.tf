@@ -0,0 +1,19 @@
+# Sync user and organization lifecycle events into the data warehouse.
+resource "propelauth_webhook_endpoint" "warehouse" {
+  for_each = toset(["Staging", "Prod"])
+
+  environment = each.key
+  url         = "https://warehouse.example.com/propelauth/${lower(each.key)}"
+  event_types = [
+    "user.created",
+    "user.deleted",
+    "org.created",
+    "org.deleted",
+  ]
+  description = "Data warehouse sync"
+}
+
+output "warehouse_signing_secrets" {
+  value     = { for env, endpoint in propelauth_webhook_endpoint.warehouse : env => endpoint.signing_secret }
+  sensitive = true
+}
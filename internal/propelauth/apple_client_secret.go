@@ -0,0 +1,84 @@
+package propelauth
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// AppleClientSecretMaxValidity is the longest Apple accepts a client secret for, six months.
+const AppleClientSecretMaxValidity = 15777000 * time.Second
+
+const appleAudience = "https://appleid.apple.com"
+
+// ParseApplePrivateKey parses the `.p8` private key Apple issues for Sign in with Apple, which must be
+// a PEM encoded PKCS #8 key on the P-256 curve so it can sign ES256 tokens.
+func ParseApplePrivateKey(privateKeyPem string) (*ecdsa.PrivateKey, error) {
+	block, _ := pem.Decode([]byte(privateKeyPem))
+	if block == nil {
+		return nil, errors.New("the private key is not PEM encoded, it must be the contents of the .p8 file downloaded from Apple")
+	}
+
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("the private key is not a PKCS #8 key: %s", err.Error())
+	}
+	ecdsaKey, ok := key.(*ecdsa.PrivateKey)
+	if !ok || ecdsaKey.Curve != elliptic.P256() {
+		return nil, errors.New("the private key is not an ES256 key, it must be an elliptic curve key on the P-256 curve")
+	}
+
+	return ecdsaKey, nil
+}
+
+// GenerateAppleClientSecret generates the client secret Apple expects for Sign in with Apple, a JWT signed with ES256
+// by the private key with the given key ID. The services ID is the client ID of the Sign in with Apple integration.
+func GenerateAppleClientSecret(teamId string, keyId string, servicesId string, privateKeyPem string, issuedAt time.Time, validity time.Duration) (string, error) {
+	if validity <= 0 || validity > AppleClientSecretMaxValidity {
+		return "", fmt.Errorf("the client secret must be valid for at most %s", AppleClientSecretMaxValidity)
+	}
+	privateKey, err := ParseApplePrivateKey(privateKeyPem)
+	if err != nil {
+		return "", err
+	}
+
+	header, err := json.Marshal(map[string]string{
+		"alg": "ES256",
+		"kid": keyId,
+	})
+	if err != nil {
+		return "", err
+	}
+	claims, err := json.Marshal(map[string]interface{}{
+		"iss": teamId,
+		"iat": issuedAt.Unix(),
+		"exp": issuedAt.Add(validity).Unix(),
+		"aud": appleAudience,
+		"sub": servicesId,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	signingInput := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(claims)
+	digest := sha256.Sum256([]byte(signingInput))
+	r, s, err := ecdsa.Sign(rand.Reader, privateKey, digest[:])
+	if err != nil {
+		return "", err
+	}
+
+	// JWS signatures are the fixed size big-endian R and S values rather than ASN.1
+	signature := make([]byte, 64)
+	r.FillBytes(signature[:32])
+	s.FillBytes(signature[32:])
+
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
@@ -0,0 +1,154 @@
+package propelauth
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"golang.org/x/net/idna"
+)
+
+// ApplicationUrlKind describes where an application URL is hosted, which decides how PropelAuth stores it
+// and which environments can use it.
+type ApplicationUrlKind string
+
+const (
+	// ApplicationUrlKindPublic is a URL on a public domain.
+	ApplicationUrlKindPublic ApplicationUrlKind = "Public"
+	// ApplicationUrlKindLoopback is a URL on the local machine, such as localhost, 127.0.0.1 or [::1].
+	ApplicationUrlKindLoopback ApplicationUrlKind = "Loopback"
+	// ApplicationUrlKindDevTunnel is a URL on a tunneling service that forwards to a local machine, such as ngrok.
+	ApplicationUrlKindDevTunnel ApplicationUrlKind = "DevTunnel"
+)
+
+// devTunnelDomains are the domains of popular tunneling services for exposing a local development server.
+var devTunnelDomains = []string{
+	"ngrok.io", "ngrok.app", "ngrok.dev", "ngrok-free.app", "ngrok-free.dev",
+	"trycloudflare.com", "loca.lt", "localtunnel.me", "devtunnels.ms", "app.github.dev",
+	"gitpod.io", "localhost.run", "lhr.life", "serveo.net",
+}
+
+// ApplicationUrl is a parsed application URL, made of only a scheme, host and port.
+type ApplicationUrl struct {
+	Kind     ApplicationUrlKind
+	Scheme   string
+	Hostname string
+	// Port is the scheme's default port if the URL doesn't have one.
+	Port int
+}
+
+// ParseApplicationUrl parses and classifies an application URL such as `http://localhost:3000` or
+// `https://app.example.com`. It returns an error describing what is wrong if the URL isn't one.
+func ParseApplicationUrl(rawUrl string) (*ApplicationUrl, error) {
+	parsedUrl, err := url.Parse(rawUrl)
+	if err != nil {
+		return nil, fmt.Errorf("%s is not a valid URL: %s", rawUrl, err.Error())
+	}
+
+	if parsedUrl.Scheme != "http" && parsedUrl.Scheme != "https" {
+		return nil, fmt.Errorf("%s must start with http:// or https://", rawUrl)
+	}
+	if parsedUrl.Hostname() == "" {
+		return nil, fmt.Errorf("%s must include a host", rawUrl)
+	}
+	if parsedUrl.User != nil {
+		return nil, fmt.Errorf("%s must not include a username or password", rawUrl)
+	}
+	if (parsedUrl.Path != "" && parsedUrl.Path != "/") || parsedUrl.RawQuery != "" || parsedUrl.Fragment != "" {
+		return nil, fmt.Errorf("%s must only include a scheme, host and port, without a path, query or fragment", rawUrl)
+	}
+
+	port := defaultPort(parsedUrl.Scheme)
+	if parsedUrl.Port() != "" {
+		port, err = strconv.Atoi(parsedUrl.Port())
+		if err != nil || port < 1 || port > 65535 {
+			return nil, fmt.Errorf("%s has an invalid port, it must be between 1 and 65535", rawUrl)
+		}
+	}
+
+	// internationalized domain names are compared and sent in their punycode form, e.g. `xn--bcher-kva.example`
+	hostname := strings.ToLower(parsedUrl.Hostname())
+	if net.ParseIP(hostname) == nil {
+		hostname, err = idna.Lookup.ToASCII(hostname)
+		if err != nil {
+			return nil, fmt.Errorf("%s does not have a valid domain name: %s", rawUrl, err.Error())
+		}
+	}
+
+	kind := ApplicationUrlKindPublic
+	if isLoopbackHost(hostname) {
+		kind = ApplicationUrlKindLoopback
+	} else if isDevTunnelHost(hostname) {
+		kind = ApplicationUrlKindDevTunnel
+	}
+
+	return &ApplicationUrl{
+		Kind:     kind,
+		Scheme:   parsedUrl.Scheme,
+		Hostname: hostname,
+		Port:     port,
+	}, nil
+}
+
+// IsLocalhost reports whether PropelAuth stores the URL as a localhost port, which it serves as
+// `http://localhost:<port>`. That's the case for any http URL on the local machine.
+func (u ApplicationUrl) IsLocalhost() bool {
+	return u.Kind == ApplicationUrlKindLoopback && u.Scheme == "http"
+}
+
+// String returns the URL as `scheme://host:port`, leaving out the port if it is the scheme's default.
+func (u ApplicationUrl) String() string {
+	host := u.Hostname
+	if strings.Contains(host, ":") {
+		host = "[" + host + "]"
+	}
+	if u.Port != defaultPort(u.Scheme) {
+		host = net.JoinHostPort(u.Hostname, strconv.Itoa(u.Port))
+	}
+	return u.Scheme + "://" + host
+}
+
+// IsSameApplicationUrl reports whether two application URLs are stored the same way by PropelAuth,
+// such as `https://example.com` and `https://example.com:443`, or `http://127.0.0.1:3000` and `http://localhost:3000`.
+func IsSameApplicationUrl(a string, b string) bool {
+	parsedA, err := ParseApplicationUrl(a)
+	if err != nil {
+		return a == b
+	}
+	parsedB, err := ParseApplicationUrl(b)
+	if err != nil {
+		return false
+	}
+
+	if parsedA.IsLocalhost() || parsedB.IsLocalhost() {
+		return parsedA.IsLocalhost() && parsedB.IsLocalhost() && parsedA.Port == parsedB.Port
+	}
+	return parsedA.String() == parsedB.String()
+}
+
+func isLoopbackHost(hostname string) bool {
+	if hostname == "localhost" || strings.HasSuffix(hostname, ".localhost") {
+		return true
+	}
+
+	ip := net.ParseIP(hostname)
+	return ip != nil && ip.IsLoopback()
+}
+
+func isDevTunnelHost(hostname string) bool {
+	for _, domain := range devTunnelDomains {
+		if strings.HasSuffix(hostname, "."+domain) {
+			return true
+		}
+	}
+	return false
+}
+
+func defaultPort(scheme string) int {
+	if scheme == "https" {
+		return 443
+	}
+	return 80
+}
@@ -0,0 +1,106 @@
+package propelauth
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// PropelAuthBackendClient - Client for the backend API of one of the project's environments, to manage the
+// environment's data such as organizations and users. It is authenticated with one of the environment's BE API keys.
+type PropelAuthBackendClient struct {
+	authUrl    string
+	httpClient *http.Client
+	apiKey     string
+}
+
+// SetBackendApiKey - Sets the BE API key used to manage the data of the requested environment.
+func (c *PropelAuthClient) SetBackendApiKey(environment string, apiKey string) {
+	c.backendClientsMux.Lock()
+	defer c.backendClientsMux.Unlock()
+
+	c.backendApiKeys[environment] = apiKey
+	delete(c.backendClients, environment)
+}
+
+// BackendClient - Returns the client for the backend API of the requested environment.
+func (c *PropelAuthClient) BackendClient(environment string) (*PropelAuthBackendClient, error) {
+	c.backendClientsMux.Lock()
+	defer c.backendClientsMux.Unlock()
+
+	if backendClient, ok := c.backendClients[environment]; ok {
+		return backendClient, nil
+	}
+
+	apiKey := c.backendApiKeys[environment]
+	if apiKey == "" {
+		return nil, fmt.Errorf("no BE API key is configured for the %s environment. Set `backend_api_keys.%s` in the provider "+
+			"configuration or the PROPELAUTH_%s_BE_API_KEY environment variable",
+			environment, strings.ToLower(environment), strings.ToUpper(environment))
+	}
+
+	// the backend API is served from the environment's auth URL
+	beIntegrationInfo, err := c.GetBeIntegrationInfo(environment)
+	if err != nil {
+		return nil, err
+	}
+	if beIntegrationInfo.AuthUrl == "" {
+		return nil, fmt.Errorf("the %s environment doesn't have an auth URL yet, verify its custom domain first", environment)
+	}
+
+	backendClient := &PropelAuthBackendClient{
+		authUrl:    strings.TrimSuffix(beIntegrationInfo.AuthUrl, "/"),
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+		apiKey:     apiKey,
+	}
+	c.backendClients[environment] = backendClient
+
+	return backendClient, nil
+}
+
+func (c *PropelAuthBackendClient) get(urlPostfix string) (*StandardResponse, error) {
+	return c.requestHelper("GET", c.assembleURL(urlPostfix), nil)
+}
+
+func (c *PropelAuthBackendClient) post(urlPostfix string, body []byte) (*StandardResponse, error) {
+	return c.requestHelper("POST", c.assembleURL(urlPostfix), body)
+}
+
+func (c *PropelAuthBackendClient) put(urlPostfix string, body []byte) (*StandardResponse, error) {
+	return c.requestHelper("PUT", c.assembleURL(urlPostfix), body)
+}
+
+func (c *PropelAuthBackendClient) delete(urlPostfix string) (*StandardResponse, error) {
+	return c.requestHelper("DELETE", c.assembleURL(urlPostfix), nil)
+}
+
+// requestHelper sends a request to the backend API. The backend API answers a missing organization, user or
+// connection with a bare 404, which is reported as a `not_found` error like the IaC API's.
+func (c *PropelAuthBackendClient) requestHelper(method string, url string, body []byte) (*StandardResponse, error) {
+	queryResponse, err := doRequest(c.httpClient, c.apiKey, method, url, body)
+	if err != nil {
+		return nil, err
+	}
+
+	if queryResponse.StatusCode == http.StatusNotFound {
+		if apiError, _ := convertStringErrorToPropelAuthError(queryResponse.BodyBytes); apiError == nil || apiError.ErrorCode == "" {
+			notFoundError, err := json.Marshal(PropelAuthApiError{ErrorCode: "not_found", UserFacingError: "Not found: " + url})
+			if err != nil {
+				return nil, err
+			}
+			return nil, errors.New(string(notFoundError))
+		}
+	}
+	if queryResponse.StatusCode >= 400 {
+		return nil, fmt.Errorf("%s", queryResponse.BodyText)
+	}
+
+	return queryResponse, nil
+}
+
+func (c *PropelAuthBackendClient) assembleURL(urlPostfix string) string {
+	return c.authUrl + "/api/backend/v1/" + urlPostfix
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"net/http"
 	"runtime"
+	"sync"
 	"time"
 )
 
@@ -16,6 +17,10 @@ type PropelAuthClient struct {
 	baseURL    string
 	httpClient *http.Client
 	apiKey     string
+
+	backendApiKeys    map[string]string
+	backendClients    map[string]*PropelAuthBackendClient
+	backendClientsMux sync.Mutex
 }
 
 type PropelAuthApiError struct {
@@ -61,6 +66,9 @@ func NewClient(tenant_id, project_id, api_key *string) (*PropelAuthClient, error
 		// Default Hashicups URL
 		baseURL: fmt.Sprintf(BaseURLTemplate, *tenant_id, *project_id),
 		apiKey:  *api_key,
+
+		backendApiKeys: map[string]string{},
+		backendClients: map[string]*PropelAuthBackendClient{},
 	}
 
 	return &c, nil
@@ -99,6 +107,26 @@ func (c *PropelAuthClient) delete(urlPostfix string, body []byte) (*StandardResp
 }
 
 func (c *PropelAuthClient) requestHelper(method string, url string, body []byte) (*StandardResponse, error) {
+	return sendRequest(c.httpClient, c.apiKey, method, url, body)
+}
+
+// sendRequest sends a JSON request authenticated with the API key, and returns the response body as an error
+// if the request failed.
+func sendRequest(httpClient *http.Client, apiKey string, method string, url string, body []byte) (*StandardResponse, error) {
+	queryResponse, err := doRequest(httpClient, apiKey, method, url, body)
+	if err != nil {
+		return nil, err
+	}
+
+	if queryResponse.StatusCode >= 400 {
+		return nil, fmt.Errorf("%s", queryResponse.BodyText)
+	}
+
+	return queryResponse, nil
+}
+
+// doRequest sends a JSON request authenticated with the API key, and returns the response whatever its status.
+func doRequest(httpClient *http.Client, apiKey string, method string, url string, body []byte) (*StandardResponse, error) {
 	requestBody := bytes.NewBuffer(body)
 
 	// create request
@@ -109,11 +137,11 @@ func (c *PropelAuthClient) requestHelper(method string, url string, body []byte)
 
 	// add headers
 	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Authorization", "Bearer "+c.apiKey)
+	req.Header.Set("Authorization", "Bearer "+apiKey)
 	req.Header.Set("User-Agent", "terraform-provider-propelauth/0.0 go/"+runtime.Version()+" "+runtime.GOOS+"/"+runtime.GOARCH)
 
 	// send request
-	resp, err := c.httpClient.Do(req)
+	resp, err := httpClient.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error making http request: %w", err)
 	}
@@ -128,10 +156,6 @@ func (c *PropelAuthClient) requestHelper(method string, url string, body []byte)
 
 	respBytes := buf.Bytes()
 
-	if resp.StatusCode >= 400 {
-		return nil, fmt.Errorf("%s", string(respBytes[:]))
-	}
-
 	// return the response
 	queryResponse := StandardResponse{
 		StatusCode:   resp.StatusCode,
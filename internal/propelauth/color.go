@@ -0,0 +1,373 @@
+package propelauth
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// ParseColor - Parses a CSS color into an RgbColor. Accepted formats are 3-, 6- and 8-digit hex codes
+// (`#fff`, `#ffffff`, `#ffffffff`), `rgb()`/`rgba()`, `hsl()`/`hsla()` and CSS named colors.
+// The hosted pages don't support transparency, so any alpha channel is validated and then dropped.
+func ParseColor(input string) (RgbColor, error) {
+	color := strings.ToLower(strings.TrimSpace(input))
+
+	if strings.HasPrefix(color, "#") {
+		return parseHexColor(color[1:])
+	}
+
+	if name, args, ok := splitColorFunction(color); ok {
+		switch name {
+		case "rgb", "rgba":
+			return parseRgbFunction(args)
+		case "hsl", "hsla":
+			return parseHslFunction(args)
+		default:
+			return RgbColor{}, fmt.Errorf("unsupported color function `%s()`", name)
+		}
+	}
+
+	if hex, ok := namedColors[color]; ok {
+		return parseHexColor(hex)
+	}
+
+	return RgbColor{}, fmt.Errorf("`%s` is not a hex code, rgb(), hsl() or CSS named color", input)
+}
+
+// RelativeLuminance - Returns the WCAG 2.x relative luminance of an sRGB color, from 0 (black) to 1 (white).
+func RelativeLuminance(color RgbColor) float64 {
+	linearize := func(channel uint8) float64 {
+		c := float64(channel) / 255
+		if c <= 0.03928 {
+			return c / 12.92
+		}
+		return math.Pow((c+0.055)/1.055, 2.4)
+	}
+
+	return 0.2126*linearize(color.Red) + 0.7152*linearize(color.Green) + 0.0722*linearize(color.Blue)
+}
+
+// ContrastRatio - Returns the WCAG 2.x contrast ratio between two colors, from 1 to 21.
+func ContrastRatio(foreground RgbColor, background RgbColor) float64 {
+	lighter := RelativeLuminance(foreground)
+	darker := RelativeLuminance(background)
+	if darker > lighter {
+		lighter, darker = darker, lighter
+	}
+
+	return (lighter + 0.05) / (darker + 0.05)
+}
+
+func parseHexColor(hex string) (RgbColor, error) {
+	switch len(hex) {
+	case 3:
+		hex = string([]byte{hex[0], hex[0], hex[1], hex[1], hex[2], hex[2]})
+	case 6, 8:
+	default:
+		return RgbColor{}, fmt.Errorf("hex color `#%s` must have 3, 6 or 8 digits", hex)
+	}
+
+	value, err := strconv.ParseUint(hex, 16, 32)
+	if err != nil {
+		return RgbColor{}, fmt.Errorf("hex color `#%s` contains non-hexadecimal characters", hex)
+	}
+	if len(hex) == 8 {
+		// the alpha channel isn't supported, so it's dropped
+		value >>= 8
+	}
+
+	return RgbColor{
+		Red:   uint8(value >> 16),
+		Green: uint8(value >> 8),
+		Blue:  uint8(value),
+	}, nil
+}
+
+// splitColorFunction splits `name(a, b, c)` or `name(a b c / d)` into its name and arguments.
+func splitColorFunction(color string) (string, []string, bool) {
+	open := strings.Index(color, "(")
+	if open <= 0 || !strings.HasSuffix(color, ")") {
+		return "", nil, false
+	}
+
+	name := strings.TrimSpace(color[:open])
+	inner := strings.ReplaceAll(color[open+1:len(color)-1], "/", " ")
+	args := strings.FieldsFunc(inner, func(r rune) bool {
+		return r == ',' || r == ' ' || r == '\t'
+	})
+
+	return name, args, true
+}
+
+func parseRgbFunction(args []string) (RgbColor, error) {
+	if len(args) != 3 && len(args) != 4 {
+		return RgbColor{}, fmt.Errorf("rgb() expects 3 channels and an optional alpha, got %d values", len(args))
+	}
+
+	var channels [3]uint8
+	for i, arg := range args[:3] {
+		var value float64
+		var err error
+		if strings.HasSuffix(arg, "%") {
+			value, err = parseFiniteFloat(strings.TrimSuffix(arg, "%"))
+			value = value * 255 / 100
+		} else {
+			value, err = parseFiniteFloat(arg)
+		}
+		if err != nil || value < 0 || value > 255 {
+			return RgbColor{}, fmt.Errorf("rgb() channel `%s` must be a number from 0 to 255 or a percentage", arg)
+		}
+		channels[i] = uint8(math.Round(value))
+	}
+
+	if len(args) == 4 {
+		if err := validateAlpha(args[3]); err != nil {
+			return RgbColor{}, err
+		}
+	}
+
+	return RgbColor{Red: channels[0], Green: channels[1], Blue: channels[2]}, nil
+}
+
+func parseHslFunction(args []string) (RgbColor, error) {
+	if len(args) != 3 && len(args) != 4 {
+		return RgbColor{}, fmt.Errorf("hsl() expects hue, saturation, lightness and an optional alpha, got %d values", len(args))
+	}
+
+	hue, err := parseFiniteFloat(strings.TrimSuffix(args[0], "deg"))
+	if err != nil {
+		return RgbColor{}, fmt.Errorf("hsl() hue `%s` must be a number of degrees", args[0])
+	}
+	saturation, err := parsePercentage(args[1])
+	if err != nil {
+		return RgbColor{}, fmt.Errorf("hsl() saturation %w", err)
+	}
+	lightness, err := parsePercentage(args[2])
+	if err != nil {
+		return RgbColor{}, fmt.Errorf("hsl() lightness %w", err)
+	}
+
+	if len(args) == 4 {
+		if err := validateAlpha(args[3]); err != nil {
+			return RgbColor{}, err
+		}
+	}
+
+	hue = math.Mod(math.Mod(hue, 360)+360, 360)
+	chroma := (1 - math.Abs(2*lightness-1)) * saturation
+	x := chroma * (1 - math.Abs(math.Mod(hue/60, 2)-1))
+	m := lightness - chroma/2
+
+	var r, g, b float64
+	switch {
+	case hue < 60:
+		r, g, b = chroma, x, 0
+	case hue < 120:
+		r, g, b = x, chroma, 0
+	case hue < 180:
+		r, g, b = 0, chroma, x
+	case hue < 240:
+		r, g, b = 0, x, chroma
+	case hue < 300:
+		r, g, b = x, 0, chroma
+	default:
+		r, g, b = chroma, 0, x
+	}
+
+	return RgbColor{
+		Red:   uint8(math.Round((r + m) * 255)),
+		Green: uint8(math.Round((g + m) * 255)),
+		Blue:  uint8(math.Round((b + m) * 255)),
+	}, nil
+}
+
+// parsePercentage parses `50%` into 0.5.
+func parsePercentage(arg string) (float64, error) {
+	if !strings.HasSuffix(arg, "%") {
+		return 0, fmt.Errorf("`%s` must be a percentage", arg)
+	}
+	value, err := parseFiniteFloat(strings.TrimSuffix(arg, "%"))
+	if err != nil || value < 0 || value > 100 {
+		return 0, fmt.Errorf("`%s` must be a percentage from 0%% to 100%%", arg)
+	}
+
+	return value / 100, nil
+}
+
+func validateAlpha(arg string) error {
+	value, err := parseFiniteFloat(strings.TrimSuffix(arg, "%"))
+	if strings.HasSuffix(arg, "%") {
+		value = value / 100
+	}
+	if err != nil || value < 0 || value > 1 {
+		return fmt.Errorf("alpha `%s` must be a number from 0 to 1 or a percentage", arg)
+	}
+
+	return nil
+}
+
+// parseFiniteFloat parses a number, rejecting the `nan` and `inf` spellings that strconv accepts but CSS doesn't.
+func parseFiniteFloat(arg string) (float64, error) {
+	value, err := strconv.ParseFloat(arg, 64)
+	if err != nil {
+		return 0, err
+	}
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		return 0, fmt.Errorf("`%s` is not a finite number", arg)
+	}
+
+	return value, nil
+}
+
+// namedColors maps the CSS named colors to their hex codes without the leading `#`.
+var namedColors = map[string]string{
+	"aliceblue":            "f0f8ff",
+	"antiquewhite":         "faebd7",
+	"aqua":                 "00ffff",
+	"aquamarine":           "7fffd4",
+	"azure":                "f0ffff",
+	"beige":                "f5f5dc",
+	"bisque":               "ffe4c4",
+	"black":                "000000",
+	"blanchedalmond":       "ffebcd",
+	"blue":                 "0000ff",
+	"blueviolet":           "8a2be2",
+	"brown":                "a52a2a",
+	"burlywood":            "deb887",
+	"cadetblue":            "5f9ea0",
+	"chartreuse":           "7fff00",
+	"chocolate":            "d2691e",
+	"coral":                "ff7f50",
+	"cornflowerblue":       "6495ed",
+	"cornsilk":             "fff8dc",
+	"crimson":              "dc143c",
+	"cyan":                 "00ffff",
+	"darkblue":             "00008b",
+	"darkcyan":             "008b8b",
+	"darkgoldenrod":        "b8860b",
+	"darkgray":             "a9a9a9",
+	"darkgreen":            "006400",
+	"darkgrey":             "a9a9a9",
+	"darkkhaki":            "bdb76b",
+	"darkmagenta":          "8b008b",
+	"darkolivegreen":       "556b2f",
+	"darkorange":           "ff8c00",
+	"darkorchid":           "9932cc",
+	"darkred":              "8b0000",
+	"darksalmon":           "e9967a",
+	"darkseagreen":         "8fbc8f",
+	"darkslateblue":        "483d8b",
+	"darkslategray":        "2f4f4f",
+	"darkslategrey":        "2f4f4f",
+	"darkturquoise":        "00ced1",
+	"darkviolet":           "9400d3",
+	"deeppink":             "ff1493",
+	"deepskyblue":          "00bfff",
+	"dimgray":              "696969",
+	"dimgrey":              "696969",
+	"dodgerblue":           "1e90ff",
+	"firebrick":            "b22222",
+	"floralwhite":          "fffaf0",
+	"forestgreen":          "228b22",
+	"fuchsia":              "ff00ff",
+	"gainsboro":            "dcdcdc",
+	"ghostwhite":           "f8f8ff",
+	"gold":                 "ffd700",
+	"goldenrod":            "daa520",
+	"gray":                 "808080",
+	"green":                "008000",
+	"greenyellow":          "adff2f",
+	"grey":                 "808080",
+	"honeydew":             "f0fff0",
+	"hotpink":              "ff69b4",
+	"indianred":            "cd5c5c",
+	"indigo":               "4b0082",
+	"ivory":                "fffff0",
+	"khaki":                "f0e68c",
+	"lavender":             "e6e6fa",
+	"lavenderblush":        "fff0f5",
+	"lawngreen":            "7cfc00",
+	"lemonchiffon":         "fffacd",
+	"lightblue":            "add8e6",
+	"lightcoral":           "f08080",
+	"lightcyan":            "e0ffff",
+	"lightgoldenrodyellow": "fafad2",
+	"lightgray":            "d3d3d3",
+	"lightgreen":           "90ee90",
+	"lightgrey":            "d3d3d3",
+	"lightpink":            "ffb6c1",
+	"lightsalmon":          "ffa07a",
+	"lightseagreen":        "20b2aa",
+	"lightskyblue":         "87cefa",
+	"lightslategray":       "778899",
+	"lightslategrey":       "778899",
+	"lightsteelblue":       "b0c4de",
+	"lightyellow":          "ffffe0",
+	"lime":                 "00ff00",
+	"limegreen":            "32cd32",
+	"linen":                "faf0e6",
+	"magenta":              "ff00ff",
+	"maroon":               "800000",
+	"mediumaquamarine":     "66cdaa",
+	"mediumblue":           "0000cd",
+	"mediumorchid":         "ba55d3",
+	"mediumpurple":         "9370db",
+	"mediumseagreen":       "3cb371",
+	"mediumslateblue":      "7b68ee",
+	"mediumspringgreen":    "00fa9a",
+	"mediumturquoise":      "48d1cc",
+	"mediumvioletred":      "c71585",
+	"midnightblue":         "191970",
+	"mintcream":            "f5fffa",
+	"mistyrose":            "ffe4e1",
+	"moccasin":             "ffe4b5",
+	"navajowhite":          "ffdead",
+	"navy":                 "000080",
+	"oldlace":              "fdf5e6",
+	"olive":                "808000",
+	"olivedrab":            "6b8e23",
+	"orange":               "ffa500",
+	"orangered":            "ff4500",
+	"orchid":               "da70d6",
+	"palegoldenrod":        "eee8aa",
+	"palegreen":            "98fb98",
+	"paleturquoise":        "afeeee",
+	"palevioletred":        "db7093",
+	"papayawhip":           "ffefd5",
+	"peachpuff":            "ffdab9",
+	"peru":                 "cd853f",
+	"pink":                 "ffc0cb",
+	"plum":                 "dda0dd",
+	"powderblue":           "b0e0e6",
+	"purple":               "800080",
+	"rebeccapurple":        "663399",
+	"red":                  "ff0000",
+	"rosybrown":            "bc8f8f",
+	"royalblue":            "4169e1",
+	"saddlebrown":          "8b4513",
+	"salmon":               "fa8072",
+	"sandybrown":           "f4a460",
+	"seagreen":             "2e8b57",
+	"seashell":             "fff5ee",
+	"sienna":               "a0522d",
+	"silver":               "c0c0c0",
+	"skyblue":              "87ceeb",
+	"slateblue":            "6a5acd",
+	"slategray":            "708090",
+	"slategrey":            "708090",
+	"snow":                 "fffafa",
+	"springgreen":          "00ff7f",
+	"steelblue":            "4682b4",
+	"tan":                  "d2b48c",
+	"teal":                 "008080",
+	"thistle":              "d8bfd8",
+	"tomato":               "ff6347",
+	"turquoise":            "40e0d0",
+	"violet":               "ee82ee",
+	"wheat":                "f5deb3",
+	"white":                "ffffff",
+	"whitesmoke":           "f5f5f5",
+	"yellow":               "ffff00",
+	"yellowgreen":          "9acd32",
+}
@@ -0,0 +1,102 @@
+package propelauth
+
+import (
+	"math"
+	"testing"
+)
+
+func TestParseColor(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    RgbColor
+		wantErr bool
+	}{
+		{name: "Test 6-digit hex", input: "#50c878", want: RgbColor{Red: 80, Green: 200, Blue: 120}},
+		{name: "Test uppercase hex", input: "#FFFFFF", want: RgbColor{Red: 255, Green: 255, Blue: 255}},
+		{name: "Test 3-digit hex", input: "#fFf", want: RgbColor{Red: 255, Green: 255, Blue: 255}},
+		{name: "Test 8-digit hex drops alpha", input: "#0f0f0f80", want: RgbColor{Red: 15, Green: 15, Blue: 15}},
+		{name: "Test rgb with commas", input: "rgb(80, 200, 120)", want: RgbColor{Red: 80, Green: 200, Blue: 120}},
+		{name: "Test rgb with spaces and alpha", input: "rgb(80 200 120 / 0.5)", want: RgbColor{Red: 80, Green: 200, Blue: 120}},
+		{name: "Test rgb with percentages", input: "rgb(100%, 0%, 50%)", want: RgbColor{Red: 255, Green: 0, Blue: 128}},
+		{name: "Test hsl", input: "hsl(120, 100%, 25%)", want: RgbColor{Red: 0, Green: 128, Blue: 0}},
+		{name: "Test hsl with negative hue", input: "hsl(-120deg 100% 50%)", want: RgbColor{Red: 0, Green: 0, Blue: 255}},
+		{name: "Test named color", input: "RebeccaPurple", want: RgbColor{Red: 102, Green: 51, Blue: 153}},
+		{name: "Test 5-digit hex", input: "#fffff", wantErr: true},
+		{name: "Test non-hex characters", input: "#ggg", wantErr: true},
+		{name: "Test non-hex alpha", input: "#ffffffzz", wantErr: true},
+		{name: "Test rgb out of range", input: "rgb(256, 0, 0)", wantErr: true},
+		{name: "Test hsl without percentages", input: "hsl(120, 100, 25)", wantErr: true},
+		{name: "Test rgb with NaN", input: "rgb(nan, 0, 0)", wantErr: true},
+		{name: "Test rgb with infinite percentage", input: "rgb(0%, -inf%, 0%)", wantErr: true},
+		{name: "Test hsl with infinite hue", input: "hsl(inf, 50%, 50%)", wantErr: true},
+		{name: "Test hsl with NaN saturation", input: "hsl(120, nan%, 50%)", wantErr: true},
+		{name: "Test NaN alpha", input: "rgb(80 200 120 / nan)", wantErr: true},
+		{name: "Test unknown name", input: "blurple", wantErr: true},
+		{name: "Test unknown function", input: "lab(50 20 20)", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseColor(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseColor() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && got != tt.want {
+				t.Errorf("ParseColor() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestContrastRatio(t *testing.T) {
+	type args struct {
+		foreground RgbColor
+		background RgbColor
+	}
+	tests := []struct {
+		name string
+		args args
+		want float64
+	}{
+		{
+			name: "Test black on white",
+			args: args{
+				foreground: RgbColor{Red: 0, Green: 0, Blue: 0},
+				background: RgbColor{Red: 255, Green: 255, Blue: 255},
+			},
+			want: 21,
+		},
+		{
+			name: "Test order does not matter",
+			args: args{
+				foreground: RgbColor{Red: 255, Green: 255, Blue: 255},
+				background: RgbColor{Red: 0, Green: 0, Blue: 0},
+			},
+			want: 21,
+		},
+		{
+			name: "Test same color",
+			args: args{
+				foreground: RgbColor{Red: 80, Green: 200, Blue: 120},
+				background: RgbColor{Red: 80, Green: 200, Blue: 120},
+			},
+			want: 1,
+		},
+		{
+			name: "Test mid grey on white",
+			args: args{
+				foreground: RgbColor{Red: 118, Green: 118, Blue: 118},
+				background: RgbColor{Red: 255, Green: 255, Blue: 255},
+			},
+			want: 4.54,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ContrastRatio(tt.args.foreground, tt.args.background)
+			if math.Abs(got-tt.want) > 0.01 {
+				t.Errorf("ContrastRatio() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
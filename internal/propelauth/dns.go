@@ -0,0 +1,189 @@
+package propelauth
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"math/rand"
+	"net"
+	"os"
+	"strings"
+	"time"
+
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+// dnsQueryTimeout bounds a single query so an unresponsive resolver doesn't use up the whole verification timeout.
+const dnsQueryTimeout = 5 * time.Second
+
+// DnsRecordCheck is the outcome of looking up one of the DNS records a custom domain needs.
+type DnsRecordCheck struct {
+	Type     string
+	Name     string
+	Expected string
+	Resolver string
+	Observed []string
+	// Err is set when the resolver couldn't be queried, in which case the check is inconclusive.
+	Err error
+}
+
+// IsMatch reports whether the resolver returned the expected value for the record.
+func (c DnsRecordCheck) IsMatch() bool {
+	for _, observed := range c.Observed {
+		if normalizeDnsRecordValue(c.Type, observed) == normalizeDnsRecordValue(c.Type, c.Expected) {
+			return true
+		}
+	}
+	return false
+}
+
+func (c DnsRecordCheck) String() string {
+	switch {
+	case c.Err != nil:
+		return fmt.Sprintf("The %s record %s could not be looked up with %s: %s", c.Type, c.Name, c.Resolver, c.Err.Error())
+	case c.IsMatch():
+		return fmt.Sprintf("The %s record %s is set to %q.", c.Type, c.Name, c.Expected)
+	case len(c.Observed) == 0:
+		return fmt.Sprintf("The %s record %s is missing according to %s, expected %q.", c.Type, c.Name, c.Resolver, c.Expected)
+	default:
+		return fmt.Sprintf("The %s record %s is set to %q according to %s, expected %q.",
+			c.Type, c.Name, strings.Join(c.Observed, `", "`), c.Resolver, c.Expected)
+	}
+}
+
+// CheckDnsRecord looks up a TXT or CNAME record through each of the resolvers. It returns the check from the
+// first resolver that doesn't return the expected value, or a matching check if all of them do.
+func CheckDnsRecord(ctx context.Context, resolvers []string, recordType string, name string, expected string) DnsRecordCheck {
+	check := DnsRecordCheck{Type: recordType, Name: name, Expected: expected}
+	for _, resolver := range resolvers {
+		check.Resolver = resolver
+		check.Observed, check.Err = lookupDnsRecord(ctx, resolver, recordType, name)
+		if !check.IsMatch() {
+			return check
+		}
+	}
+	return check
+}
+
+// SystemDnsResolvers returns the nameservers configured in /etc/resolv.conf, or nil if there are none.
+func SystemDnsResolvers() []string {
+	file, err := os.Open("/etc/resolv.conf")
+	if err != nil {
+		return nil
+	}
+	defer file.Close()
+
+	resolvers := []string{}
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) >= 2 && fields[0] == "nameserver" {
+			resolvers = append(resolvers, fields[1])
+		}
+	}
+	return resolvers
+}
+
+// lookupDnsRecord queries the resolver directly rather than going through net.Resolver, which follows CNAME
+// chains to the final canonical name and so can't tell which target a CNAME record points at.
+func lookupDnsRecord(ctx context.Context, resolver string, recordType string, name string) ([]string, error) {
+	var queryType dnsmessage.Type
+	switch recordType {
+	case "TXT":
+		queryType = dnsmessage.TypeTXT
+	case "CNAME":
+		queryType = dnsmessage.TypeCNAME
+	default:
+		return nil, fmt.Errorf("unsupported record type %s", recordType)
+	}
+
+	fqdn := strings.TrimSuffix(name, ".") + "."
+	questionName, err := dnsmessage.NewName(fqdn)
+	if err != nil {
+		return nil, err
+	}
+
+	query := dnsmessage.Message{
+		Header: dnsmessage.Header{ID: uint16(rand.Uint32()), RecursionDesired: true},
+		Questions: []dnsmessage.Question{
+			{Name: questionName, Type: queryType, Class: dnsmessage.ClassINET},
+		},
+	}
+	packedQuery, err := query.Pack()
+	if err != nil {
+		return nil, err
+	}
+
+	queryCtx, cancel := context.WithTimeout(ctx, dnsQueryTimeout)
+	defer cancel()
+
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(queryCtx, "udp", resolverAddress(resolver))
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	if deadline, ok := queryCtx.Deadline(); ok {
+		err = conn.SetDeadline(deadline)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	_, err = conn.Write(packedQuery)
+	if err != nil {
+		return nil, err
+	}
+
+	buffer := make([]byte, 4096)
+	var response dnsmessage.Message
+	for {
+		n, err := conn.Read(buffer)
+		if err != nil {
+			return nil, err
+		}
+		err = response.Unpack(buffer[:n])
+		// ignore stray packets that aren't the response to this query
+		if err == nil && response.Response && response.ID == query.ID {
+			break
+		}
+	}
+
+	switch response.RCode {
+	case dnsmessage.RCodeSuccess:
+	case dnsmessage.RCodeNameError:
+		return []string{}, nil
+	default:
+		return nil, fmt.Errorf("the resolver responded with %s", response.RCode.String())
+	}
+
+	observed := []string{}
+	for _, answer := range response.Answers {
+		switch body := answer.Body.(type) {
+		case *dnsmessage.TXTResource:
+			observed = append(observed, strings.Join(body.TXT, ""))
+		case *dnsmessage.CNAMEResource:
+			// only the record itself, not the rest of the chain a recursive resolver may include
+			if queryType == dnsmessage.TypeCNAME && strings.EqualFold(answer.Header.Name.String(), fqdn) {
+				observed = append(observed, body.CNAME.String())
+			}
+		}
+	}
+	return observed, nil
+}
+
+// resolverAddress adds the default DNS port to resolvers given without one.
+func resolverAddress(resolver string) string {
+	if _, _, err := net.SplitHostPort(resolver); err == nil {
+		return resolver
+	}
+	return net.JoinHostPort(strings.Trim(resolver, "[]"), "53")
+}
+
+func normalizeDnsRecordValue(recordType string, value string) string {
+	if recordType == "CNAME" {
+		return strings.ToLower(strings.TrimSuffix(value, "."))
+	}
+	return strings.Trim(value, `"`)
+}
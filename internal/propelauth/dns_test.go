@@ -0,0 +1,152 @@
+package propelauth
+
+import (
+	"context"
+	"net"
+	"strings"
+	"testing"
+
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+// startTestDnsServer serves the given TXT and CNAME records over UDP on localhost and returns its address.
+// Names without records get an NXDOMAIN response.
+func startTestDnsServer(t *testing.T, txtRecords map[string]string, cnameRecords map[string]string) string {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	go func() {
+		buffer := make([]byte, 512)
+		for {
+			n, addr, err := conn.ReadFrom(buffer)
+			if err != nil {
+				return
+			}
+
+			var query dnsmessage.Message
+			if err := query.Unpack(buffer[:n]); err != nil || len(query.Questions) != 1 {
+				continue
+			}
+			question := query.Questions[0]
+			name := strings.TrimSuffix(question.Name.String(), ".")
+
+			response := dnsmessage.Message{
+				Header:    dnsmessage.Header{ID: query.ID, Response: true, RCode: dnsmessage.RCodeNameError},
+				Questions: query.Questions,
+			}
+			answerHeader := dnsmessage.ResourceHeader{Name: question.Name, Type: question.Type, Class: dnsmessage.ClassINET, TTL: 300}
+			if value, ok := txtRecords[name]; ok && question.Type == dnsmessage.TypeTXT {
+				response.RCode = dnsmessage.RCodeSuccess
+				response.Answers = []dnsmessage.Resource{{Header: answerHeader, Body: &dnsmessage.TXTResource{TXT: []string{value}}}}
+			}
+			if value, ok := cnameRecords[name]; ok && question.Type == dnsmessage.TypeCNAME {
+				response.RCode = dnsmessage.RCodeSuccess
+				response.Answers = []dnsmessage.Resource{{Header: answerHeader, Body: &dnsmessage.CNAMEResource{CNAME: dnsmessage.MustNewName(value)}}}
+			}
+
+			packed, err := response.Pack()
+			if err != nil {
+				continue
+			}
+			_, _ = conn.WriteTo(packed, addr)
+		}
+	}()
+
+	return conn.LocalAddr().String()
+}
+
+func TestCheckDnsRecord(t *testing.T) {
+	resolver := startTestDnsServer(t,
+		map[string]string{"_propelauth.auth.example.com": "verification-token"},
+		map[string]string{"auth.example.com": "Example.PropelAuthDomain.com."},
+	)
+	otherResolver := startTestDnsServer(t, map[string]string{}, map[string]string{"auth.example.com": "stale.example.net."})
+
+	tests := []struct {
+		name         string
+		resolvers    []string
+		recordType   string
+		recordName   string
+		expected     string
+		wantMatch    bool
+		wantObserved []string
+	}{
+		{
+			name:         "Test matching TXT record",
+			resolvers:    []string{resolver},
+			recordType:   "TXT",
+			recordName:   "_propelauth.auth.example.com",
+			expected:     "verification-token",
+			wantMatch:    true,
+			wantObserved: []string{"verification-token"},
+		},
+		{
+			name:         "Test mismatched TXT record",
+			resolvers:    []string{resolver},
+			recordType:   "TXT",
+			recordName:   "_propelauth.auth.example.com",
+			expected:     "other-token",
+			wantMatch:    false,
+			wantObserved: []string{"verification-token"},
+		},
+		{
+			name:         "Test missing TXT record",
+			resolvers:    []string{resolver},
+			recordType:   "TXT",
+			recordName:   "_propelauth.auth.other.com",
+			expected:     "verification-token",
+			wantMatch:    false,
+			wantObserved: []string{},
+		},
+		{
+			name:         "Test CNAME record ignores case and trailing dot",
+			resolvers:    []string{resolver},
+			recordType:   "CNAME",
+			recordName:   "auth.example.com",
+			expected:     "example.propelauthdomain.com",
+			wantMatch:    true,
+			wantObserved: []string{"Example.PropelAuthDomain.com."},
+		},
+		{
+			name:         "Test every resolver must match",
+			resolvers:    []string{resolver, otherResolver},
+			recordType:   "CNAME",
+			recordName:   "auth.example.com",
+			expected:     "example.propelauthdomain.com",
+			wantMatch:    false,
+			wantObserved: []string{"stale.example.net."},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			check := CheckDnsRecord(context.Background(), tt.resolvers, tt.recordType, tt.recordName, tt.expected)
+			if check.Err != nil {
+				t.Fatalf("CheckDnsRecord() error = %v", check.Err)
+			}
+			if check.IsMatch() != tt.wantMatch {
+				t.Errorf("CheckDnsRecord().IsMatch() = %v, want %v", check.IsMatch(), tt.wantMatch)
+			}
+			if strings.Join(check.Observed, ",") != strings.Join(tt.wantObserved, ",") {
+				t.Errorf("CheckDnsRecord().Observed = %v, want %v", check.Observed, tt.wantObserved)
+			}
+		})
+	}
+}
+
+func TestResolverAddress(t *testing.T) {
+	tests := map[string]string{
+		"1.1.1.1":        "1.1.1.1:53",
+		"127.0.0.1:5353": "127.0.0.1:5353",
+		"2606:4700::1":   "[2606:4700::1]:53",
+		"[::1]:5353":     "[::1]:5353",
+		"dns.google":     "dns.google:53",
+	}
+	for resolver, want := range tests {
+		if got := resolverAddress(resolver); got != want {
+			t.Errorf("resolverAddress(%q) = %v, want %v", resolver, got, want)
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package propelauth
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// durationUnits are the units accepted by ParseDuration, largest first.
+var durationUnits = []struct {
+	suffix string
+	length time.Duration
+}{
+	{"w", 7 * 24 * time.Hour},
+	{"d", 24 * time.Hour},
+	{"h", time.Hour},
+	{"m", time.Minute},
+	{"s", time.Second},
+}
+
+var durationPartRegex = regexp.MustCompile(`(\d+)([a-z]+)`)
+
+// ParseDuration - Parses a human-readable duration such as `15m`, `30d` or `1h30m`. Unlike time.ParseDuration,
+// it accepts days (`d`) and weeks (`w`), and only whole seconds.
+func ParseDuration(input string) (time.Duration, error) {
+	duration := strings.ToLower(strings.TrimSpace(input))
+	if duration == "" {
+		return 0, fmt.Errorf("the duration is empty")
+	}
+
+	if durationPartRegex.ReplaceAllString(duration, "") != "" {
+		return 0, fmt.Errorf("`%s` is not a duration such as `15m`, `30d` or `1h30m`", input)
+	}
+
+	var total time.Duration
+	for _, part := range durationPartRegex.FindAllStringSubmatch(duration, -1) {
+		amount, err := strconv.ParseInt(part[1], 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("`%s` is too large a number", part[1])
+		}
+
+		unitLength := time.Duration(0)
+		for _, unit := range durationUnits {
+			if unit.suffix == part[2] {
+				unitLength = unit.length
+			}
+		}
+		if unitLength == 0 {
+			return 0, fmt.Errorf("`%s` is not a unit, the units are `w`, `d`, `h`, `m` and `s`", part[2])
+		}
+
+		if amount > int64((100*365*24*time.Hour-total)/unitLength) {
+			return 0, fmt.Errorf("`%s` is longer than 100 years", input)
+		}
+		total += time.Duration(amount) * unitLength
+	}
+
+	return total, nil
+}
+
+// FormatDuration - Formats a duration the way ParseDuration reads it, e.g. `30d` or `1h30m`. Weeks aren't used
+// so lifetimes read naturally, e.g. `14d` rather than `2w`.
+func FormatDuration(duration time.Duration) string {
+	duration = duration.Truncate(time.Second)
+	if duration <= 0 {
+		return "0s"
+	}
+
+	formatted := ""
+	for _, unit := range durationUnits[1:] {
+		if amount := duration / unit.length; amount > 0 {
+			formatted += fmt.Sprintf("%d%s", amount, unit.suffix)
+			duration -= amount * unit.length
+		}
+	}
+	return formatted
+}
@@ -0,0 +1,65 @@
+package propelauth
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseDuration(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    time.Duration
+		wantErr bool
+	}{
+		{name: "Test minutes", input: "15m", want: 15 * time.Minute},
+		{name: "Test days", input: "30d", want: 30 * 24 * time.Hour},
+		{name: "Test weeks", input: "2w", want: 14 * 24 * time.Hour},
+		{name: "Test combined units", input: "1h30m", want: 90 * time.Minute},
+		{name: "Test uppercase and spaces", input: " 1D12H ", want: 36 * time.Hour},
+		{name: "Test seconds", input: "3600s", want: time.Hour},
+		{name: "Test empty", input: "", wantErr: true},
+		{name: "Test number without unit", input: "900", wantErr: true},
+		{name: "Test fractions", input: "1.5h", wantErr: true},
+		{name: "Test negative", input: "-15m", wantErr: true},
+		{name: "Test unknown unit", input: "1y", wantErr: true},
+		{name: "Test milliseconds", input: "500ms", wantErr: true},
+		{name: "Test too long", input: "99999999999w", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseDuration(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseDuration() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && got != tt.want {
+				t.Errorf("ParseDuration() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatDuration(t *testing.T) {
+	tests := []struct {
+		name  string
+		input time.Duration
+		want  string
+	}{
+		{name: "Test minutes", input: 15 * time.Minute, want: "15m"},
+		{name: "Test days", input: 30 * 24 * time.Hour, want: "30d"},
+		{name: "Test weeks are days", input: 14 * 24 * time.Hour, want: "14d"},
+		{name: "Test combined units", input: 90*time.Minute + 5*time.Second, want: "1h30m5s"},
+		{name: "Test zero", input: 0, want: "0s"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := FormatDuration(tt.input)
+			if got != tt.want {
+				t.Errorf("FormatDuration() = %v, want %v", got, tt.want)
+			}
+			if parsed, err := ParseDuration(got); tt.input > 0 && (err != nil || parsed != tt.input) {
+				t.Errorf("ParseDuration(FormatDuration()) = %v, %v, want %v", parsed, err, tt.input)
+			}
+		})
+	}
+}
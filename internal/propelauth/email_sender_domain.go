@@ -0,0 +1,79 @@
+package propelauth
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// GetEmailSenderDomain - Returns the email sender domain for the requested environment.
+func (c *PropelAuthClient) GetEmailSenderDomain(environment string) (*EmailSenderDomainInfo, error) {
+	res, err := c.get(fmt.Sprintf("email_sender_domain?environment=%v", environment))
+	if err != nil {
+		return nil, err
+	}
+
+	emailSenderDomainInfo := EmailSenderDomainInfo{}
+	err = json.Unmarshal(res.BodyBytes, &emailSenderDomainInfo)
+	if err != nil {
+		return nil, err
+	}
+
+	return &emailSenderDomainInfo, nil
+}
+
+// UpdateEmailSenderDomain - Sets the domain, sender name, and reply-to address emails are sent with for the
+// requested environment.
+func (c *PropelAuthClient) UpdateEmailSenderDomain(environment string, domain string, senderName *string, replyTo *string) (*EmailSenderDomainInfo, error) {
+	request := emailSenderDomainUpdateRequest{
+		Environment: environment,
+		Domain:      domain,
+		SenderName:  senderName,
+		ReplyTo:     replyTo,
+	}
+
+	body, err := json.Marshal(request)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := c.put("email_sender_domain", body)
+	if err != nil {
+		return nil, err
+	}
+
+	emailSenderDomainInfo := EmailSenderDomainInfo{}
+	err = json.Unmarshal(res.BodyBytes, &emailSenderDomainInfo)
+	if err != nil {
+		return nil, err
+	}
+
+	return &emailSenderDomainInfo, nil
+}
+
+// VerifyEmailSenderDomain - Verifies the email sender domain for the requested environment.
+func (c *PropelAuthClient) VerifyEmailSenderDomain(environment string) error {
+	request := emailSenderDomainVerifyRequest{
+		Environment: environment,
+	}
+	body, err := json.Marshal(request)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.post("email_sender_domain/verify", body)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// DeleteEmailSenderDomain - Removes the email sender domain for the requested environment, so emails are sent
+// from PropelAuth's default sender again.
+func (c *PropelAuthClient) DeleteEmailSenderDomain(environment string) error {
+	_, err := c.delete(fmt.Sprintf("email_sender_domain?environment=%v", environment), nil)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
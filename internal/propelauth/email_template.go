@@ -0,0 +1,182 @@
+package propelauth
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// EmailTemplateType describes one of the transactional emails PropelAuth sends.
+type EmailTemplateType struct {
+	// Name is how the template is referred to in configuration, e.g. `ConfirmEmail`.
+	Name string
+	// Key is how the template is keyed in its API path, e.g. `confirm_email`.
+	Key string
+	// Variables are the placeholders that can be used in the template.
+	Variables []string
+	// RequiredVariables are the placeholders that every body must use, such as the link the email is for.
+	RequiredVariables []string
+}
+
+// emailTemplateCommonVariables can be used in every email template.
+var emailTemplateCommonVariables = []string{"project_name", "logo_url", "support_email", "user_email"}
+
+var emailTemplateTypes = []EmailTemplateType{
+	{
+		Name:              "ConfirmEmail",
+		Key:               "confirm_email",
+		Variables:         []string{"confirmation_link"},
+		RequiredVariables: []string{"confirmation_link"},
+	},
+	{
+		Name:              "MagicLink",
+		Key:               "magic_link",
+		Variables:         []string{"magic_link", "expires_in_minutes"},
+		RequiredVariables: []string{"magic_link"},
+	},
+	{
+		Name:              "Invite",
+		Key:               "invite",
+		Variables:         []string{"invite_link", "org_name", "inviter_email", "role"},
+		RequiredVariables: []string{"invite_link"},
+	},
+	{
+		Name:              "ResetPassword",
+		Key:               "reset_password",
+		Variables:         []string{"reset_password_link", "expires_in_minutes"},
+		RequiredVariables: []string{"reset_password_link"},
+	},
+}
+
+var (
+	emailTemplatePlaceholderRegex = regexp.MustCompile(`\{\{\s*([^{}]*?)\s*\}\}`)
+	emailTemplateVariableRegex    = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+)
+
+// EmailTemplateTypeNames returns the names of the email templates that can be customized.
+func EmailTemplateTypeNames() []string {
+	names := make([]string, len(emailTemplateTypes))
+	for i, templateType := range emailTemplateTypes {
+		names[i] = templateType.Name
+	}
+	return names
+}
+
+// LookupEmailTemplateType returns the email template type with the given name.
+func LookupEmailTemplateType(name string) (*EmailTemplateType, bool) {
+	for i := range emailTemplateTypes {
+		if emailTemplateTypes[i].Name == name {
+			return &emailTemplateTypes[i], true
+		}
+	}
+	return nil, false
+}
+
+// SupportsVariable returns true if the placeholder can be used in the template.
+func (t *EmailTemplateType) SupportsVariable(variable string) bool {
+	return slices.Contains(emailTemplateCommonVariables, variable) || slices.Contains(t.Variables, variable)
+}
+
+// SupportedVariables returns every placeholder that can be used in the template.
+func (t *EmailTemplateType) SupportedVariables() []string {
+	return append(slices.Clone(emailTemplateCommonVariables), t.Variables...)
+}
+
+// EmailTemplateVariables returns the placeholders used in part of an email template, in order of first use.
+// Placeholders are written as `{{variable}}`, and an unbalanced `{{` or `}}` is an error.
+func EmailTemplateVariables(template string) ([]string, error) {
+	variables := []string{}
+	for _, match := range emailTemplatePlaceholderRegex.FindAllStringSubmatch(template, -1) {
+		if !emailTemplateVariableRegex.MatchString(match[1]) {
+			return nil, fmt.Errorf("%s is not a valid placeholder, placeholders are written as {{variable_name}}", match[0])
+		}
+		if !slices.Contains(variables, match[1]) {
+			variables = append(variables, match[1])
+		}
+	}
+
+	remainder := emailTemplatePlaceholderRegex.ReplaceAllString(template, "")
+	if strings.Contains(remainder, "{{") || strings.Contains(remainder, "}}") {
+		return nil, fmt.Errorf("the template has an unclosed placeholder, placeholders are written as {{variable_name}}")
+	}
+
+	return variables, nil
+}
+
+// CheckBody checks that a subject or body of the template only uses supported placeholders and, for a body,
+// that it uses the required ones.
+func (t *EmailTemplateType) CheckBody(template string, isBody bool) error {
+	variables, err := EmailTemplateVariables(template)
+	if err != nil {
+		return err
+	}
+
+	for _, variable := range variables {
+		if !t.SupportsVariable(variable) {
+			message := fmt.Sprintf("{{%s}} is not supported in the %s template", variable, t.Name)
+			if suggestion, ok := ClosestMatch(t.SupportedVariables(), variable, 3); ok {
+				return fmt.Errorf("%s, did you mean {{%s}}?", message, suggestion)
+			}
+			return fmt.Errorf("%s. The supported placeholders are: %s", message, strings.Join(t.SupportedVariables(), ", "))
+		}
+	}
+	if isBody {
+		for _, variable := range t.RequiredVariables {
+			if !slices.Contains(variables, variable) {
+				return fmt.Errorf("the %s template must use {{%s}}, or the email is of no use to its recipient", t.Name, variable)
+			}
+		}
+	}
+
+	return nil
+}
+
+// GetEmailTemplate - Returns the requested email template.
+func (c *PropelAuthClient) GetEmailTemplate(templateType string) (*EmailTemplate, error) {
+	res, err := c.get(fmt.Sprintf("email_template/%s", emailTemplateKey(templateType)))
+	if err != nil {
+		return nil, err
+	}
+
+	emailTemplate := EmailTemplate{}
+	err = json.Unmarshal(res.BodyBytes, &emailTemplate)
+	if err != nil {
+		return nil, err
+	}
+
+	return &emailTemplate, nil
+}
+
+// UpdateEmailTemplate - Customizes the requested email template.
+func (c *PropelAuthClient) UpdateEmailTemplate(templateType string, request EmailTemplateUpdateRequest) error {
+	body, err := json.Marshal(request)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.put(fmt.Sprintf("email_template/%s", emailTemplateKey(templateType)), body)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ResetEmailTemplate - Resets the requested email template to PropelAuth's default.
+func (c *PropelAuthClient) ResetEmailTemplate(templateType string) error {
+	_, err := c.delete(fmt.Sprintf("email_template/%s", emailTemplateKey(templateType)), nil)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func emailTemplateKey(templateType string) string {
+	if emailTemplateType, ok := LookupEmailTemplateType(templateType); ok {
+		return emailTemplateType.Key
+	}
+	return strings.ToLower(templateType)
+}
@@ -0,0 +1,59 @@
+package propelauth
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestEmailTemplateVariables(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+		want     string
+		wantErr  bool
+	}{
+		{"no placeholders", "Welcome!", "", false},
+		{"placeholders", "Hi {{user_email}}, <a href=\"{{ confirmation_link }}\">confirm</a> {{user_email}}", "user_email,confirmation_link", false},
+		{"invalid placeholder", "Hi {{User Email}}", "", true},
+		{"unclosed placeholder", "Hi {{user_email", "", true},
+		{"stray closing braces", "Hi user_email}}", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := EmailTemplateVariables(tt.template)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("EmailTemplateVariables() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && strings.Join(got, ",") != tt.want {
+				t.Errorf("EmailTemplateVariables() = %v, want %v", strings.Join(got, ","), tt.want)
+			}
+		})
+	}
+}
+
+func TestEmailTemplateTypeCheckBody(t *testing.T) {
+	invite, _ := LookupEmailTemplateType("Invite")
+
+	tests := []struct {
+		name     string
+		template string
+		isBody   bool
+		wantErr  string
+	}{
+		{"supported placeholders", "{{inviter_email}} invited you to {{org_name}}: {{invite_link}}", true, ""},
+		{"subject without the link", "Join {{org_name}} on {{project_name}}", false, ""},
+		{"body without the link", "Join {{org_name}}", true, "must use {{invite_link}}"},
+		{"misspelled placeholder", "{{invite_lnk}} {{invite_link}}", true, "did you mean {{invite_link}}"},
+		{"placeholder of another template", "{{magic_link}} {{invite_link}}", true, "not supported in the Invite template"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := invite.CheckBody(tt.template, tt.isBody)
+			if tt.wantErr == "" && err != nil {
+				t.Errorf("CheckBody() error = %v, want none", err)
+			} else if tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) {
+				t.Errorf("CheckBody() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
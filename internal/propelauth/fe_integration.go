@@ -6,8 +6,8 @@ import (
 	"strings"
 )
 
-// GetTestFeIntegrationInfo - Returns the FE integration info for the test environment.
-func (c *PropelAuthClient) GetTestFeIntegrationInfo() (*TestFeIntegrationInfo, error) {
+// GetFeIntegrationInfo - Returns the FE integration info for all environments.
+func (c *PropelAuthClient) GetFeIntegrationInfo() (*FeIntegrationInfoResponse, error) {
 	res, err := c.get("fe_integration")
 	if err != nil {
 		return nil, err
@@ -19,6 +19,16 @@ func (c *PropelAuthClient) GetTestFeIntegrationInfo() (*TestFeIntegrationInfo, e
 		return nil, err
 	}
 
+	return &feIntegration, nil
+}
+
+// GetTestFeIntegrationInfo - Returns the FE integration info for the test environment.
+func (c *PropelAuthClient) GetTestFeIntegrationInfo() (*TestFeIntegrationInfo, error) {
+	feIntegration, err := c.GetFeIntegrationInfo()
+	if err != nil {
+		return nil, err
+	}
+
 	return &feIntegration.Test, nil
 }
 
@@ -31,17 +41,20 @@ type FeIntegrationUpdate struct {
 
 // UpdateTestFeIntegration - Updates the FE integration info for the test environment.
 func (c *PropelAuthClient) UpdateTestFeIntegration(update FeIntegrationUpdate) (*TestFeIntegrationInfo, error) {
-	var applicationUrl testEnvFeIntegrationApplicationUrl
-	isLocalhost, port := GetPortFromLocalhost(update.ApplicationUrl)
+	parsedApplicationUrl, err := ParseApplicationUrl(update.ApplicationUrl)
+	if err != nil {
+		return nil, err
+	}
 
-	if isLocalhost {
+	var applicationUrl testEnvFeIntegrationApplicationUrl
+	if parsedApplicationUrl.IsLocalhost() {
 		applicationUrl = testEnvFeIntegrationApplicationUrl{
-			Port: port,
+			Port: parsedApplicationUrl.Port,
 			Type: "Localhost",
 		}
 	} else {
 		applicationUrl = testEnvFeIntegrationApplicationUrl{
-			ApplicationUrl: update.ApplicationUrl,
+			ApplicationUrl: parsedApplicationUrl.String(),
 			Type:           "SchemeAndDomain",
 		}
 	}
@@ -94,13 +107,7 @@ func (c *PropelAuthClient) UpdateLiveFeIntegration(environment string, update Fe
 
 // GetLiveFeIntegrationInfo - Returns the FE integration info for a live staging or prod environment.
 func (c *PropelAuthClient) GetLiveFeIntegrationInfo(environment string) (*FeIntegrationInfoForEnv, error) {
-	res, err := c.get("fe_integration")
-	if err != nil {
-		return nil, err
-	}
-
-	feIntegration := FeIntegrationInfoResponse{}
-	err = json.Unmarshal(res.BodyBytes, &feIntegration)
+	feIntegration, err := c.GetFeIntegrationInfo()
 	if err != nil {
 		return nil, err
 	}
@@ -62,6 +62,11 @@ type EnvironmentConfigUpdate struct {
 	OrgAuditLogIncludesEmployees        *bool            `json:"org_audit_log_includes_employees,omitempty"`
 	HasPhoneMfa                         *bool            `json:"has_phone_mfa,omitempty"`
 	AllUsersMustSetup2fa                *bool            `json:"all_users_must_setup_2fa,omitempty"`
+	HasTotpMfa                          *bool            `json:"has_totp_mfa,omitempty"`
+	HasPasskeyMfa                       *bool            `json:"has_passkey_mfa,omitempty"`
+	MfaGracePeriodSeconds               *int64           `json:"mfa_grace_period_seconds,omitempty"`
+	MfaRecoveryCodesEnabled             *bool            `json:"mfa_recovery_codes_enabled,omitempty"`
+	MfaRecoveryCodeCount                *int64           `json:"mfa_recovery_code_count,omitempty"`
 	AllowAutojoinByDomain               *bool            `json:"allow_autojoin_by_domain,omitempty"`
 }
 
@@ -111,6 +116,11 @@ type EnvironmentConfigResponse struct {
 	OrgAuditLogIncludesEmployees        bool            `json:"org_audit_log_includes_employees"`
 	HasPhoneMfa                         bool            `json:"has_phone_mfa"`
 	AllUsersMustSetup2fa                bool            `json:"all_users_must_setup_2fa"`
+	HasTotpMfa                          bool            `json:"has_totp_mfa"`
+	HasPasskeyMfa                       bool            `json:"has_passkey_mfa"`
+	MfaGracePeriodSeconds               int64           `json:"mfa_grace_period_seconds"`
+	MfaRecoveryCodesEnabled             bool            `json:"mfa_recovery_codes_enabled"`
+	MfaRecoveryCodeCount                int64           `json:"mfa_recovery_code_count"`
 	AllowAutojoinByDomain               bool            `json:"allow_autojoin_by_domain"`
 }
 
@@ -191,11 +201,16 @@ type RateLimitConfig struct {
 }
 
 type RealmConfigUpdate struct {
-	AutoConfirmEmails                     *bool `json:"auto_confirm_emails,omitempty"`
-	AllowPublicSignups                    *bool `json:"allow_public_signups,omitempty"`
-	WaitlistUsersRequireEmailConfirmation *bool `json:"waitlist_users_require_email_confirmation,omitempty"`
-	MagicLinkRequiresInterstitial         *bool `json:"magic_link_requires_interstitial,omitempty"`
-	MagicLinkExpireAfterFirstUse          *bool `json:"magic_link_expire_after_first_use,omitempty"`
+	AutoConfirmEmails                     *bool  `json:"auto_confirm_emails,omitempty"`
+	AllowPublicSignups                    *bool  `json:"allow_public_signups,omitempty"`
+	WaitlistUsersRequireEmailConfirmation *bool  `json:"waitlist_users_require_email_confirmation,omitempty"`
+	MagicLinkRequiresInterstitial         *bool  `json:"magic_link_requires_interstitial,omitempty"`
+	MagicLinkExpireAfterFirstUse          *bool  `json:"magic_link_expire_after_first_use,omitempty"`
+	AccessTokenTtlSeconds                 *int64 `json:"access_token_ttl_seconds,omitempty"`
+	RefreshTokenLifetimeSeconds           *int64 `json:"refresh_token_lifetime_seconds,omitempty"`
+	SessionIdleTimeoutSeconds             *int64 `json:"session_idle_timeout_seconds,omitempty"`
+	SessionAbsoluteLifetimeSeconds        *int64 `json:"session_absolute_lifetime_seconds,omitempty"`
+	MaxConcurrentSessions                 *int64 `json:"max_concurrent_sessions,omitempty"`
 }
 
 type RealmConfigsResponse struct {
@@ -211,6 +226,11 @@ type RealmConfigResponse struct {
 	MagicLinkRequiresInterstitial         bool   `json:"magic_link_requires_interstitial"`
 	MagicLinkExpireAfterFirstUse          bool   `json:"magic_link_expire_after_first_use"`
 	AuthHostname                          string `json:"auth_hostname"`
+	AccessTokenTtlSeconds                 int64  `json:"access_token_ttl_seconds"`
+	RefreshTokenLifetimeSeconds           int64  `json:"refresh_token_lifetime_seconds"`
+	SessionIdleTimeoutSeconds             int64  `json:"session_idle_timeout_seconds"`
+	SessionAbsoluteLifetimeSeconds        int64  `json:"session_absolute_lifetime_seconds"`
+	MaxConcurrentSessions                 int64  `json:"max_concurrent_sessions"` // 0 is unlimited
 }
 
 type UserProperties struct {
@@ -243,6 +263,25 @@ type TosLink struct {
 	Name string `json:"name"`
 }
 
+type OrgProperties struct {
+	Fields []OrgProperty `json:"fields"`
+}
+
+type OrgProperty struct {
+	Name         string              `json:"name"`
+	DisplayName  string              `json:"display_name"`
+	FieldType    string              `json:"field_type"`
+	Required     bool                `json:"required"`
+	InJwt        bool                `json:"in_jwt"`
+	IsEnabled    bool                `json:"is_enabled"`
+	UserWritable string              `json:"user_writable"`
+	Metadata     orgPropertyMetadata `json:"metadata"`
+}
+
+type orgPropertyMetadata struct {
+	EnumValues []string `json:"enum_values,omitempty"`
+}
+
 type FeIntegrationInfoResponse struct {
 	Test    TestFeIntegrationInfo   `json:"test"`
 	Staging FeIntegrationInfoForEnv `json:"stage"`
@@ -340,6 +379,30 @@ type customDomainVerifyRequest struct {
 	IsSwitching bool   `json:"is_switching"`
 }
 
+type EmailSenderDomainInfo struct {
+	Domain                string  `json:"domain"`
+	SenderName            *string `json:"sender_name"`
+	ReplyTo               *string `json:"reply_to"`
+	IsVerified            bool    `json:"is_verified"`
+	SpfRecordKey          *string `json:"spf_record_key"`
+	SpfRecordValue        *string `json:"spf_record_value"`
+	DkimRecordKey         *string `json:"dkim_record_key"`
+	DkimRecordValue       *string `json:"dkim_record_value"`
+	ReturnPathRecordKey   *string `json:"return_path_record_key"`
+	ReturnPathRecordValue *string `json:"return_path_record_value"`
+}
+
+type emailSenderDomainUpdateRequest struct {
+	Environment string  `json:"environment"`
+	Domain      string  `json:"domain"`
+	SenderName  *string `json:"sender_name"`
+	ReplyTo     *string `json:"reply_to"`
+}
+
+type emailSenderDomainVerifyRequest struct {
+	Environment string `json:"environment"`
+}
+
 type RolesAndPermissions struct {
 	Roles            []RoleDefinition `json:"roles"`
 	Permissions      []Permission     `json:"available_external_permissions"`
@@ -381,20 +444,8 @@ type RoleMigrationMap struct {
 	OldToNewRoleMapping map[string]*string `json:"role_map"`
 }
 
-type AllSocialLoginInfoResponse struct {
-	Google     SocialLoginInfo `json:"google"`
-	GitHub     SocialLoginInfo `json:"github"`
-	Slack      SocialLoginInfo `json:"slack"`
-	Microsoft  SocialLoginInfo `json:"microsoft"`
-	LinkedIn   SocialLoginInfo `json:"linkedin"`
-	Salesforce SocialLoginInfo `json:"salesforce"`
-	Outreach   SocialLoginInfo `json:"outreach"`
-	QuickBooks SocialLoginInfo `json:"quickbooks"`
-	Xero       SocialLoginInfo `json:"xero"`
-	Salesloft  SocialLoginInfo `json:"salesloft"`
-	Atlassian  SocialLoginInfo `json:"atlassian"`
-	Apple      SocialLoginInfo `json:"apple"`
-}
+// AllSocialLoginInfoResponse is the `social` payload, keyed by each social login provider's key, e.g. `github`.
+type AllSocialLoginInfoResponse map[string]SocialLoginInfo
 
 type SocialLoginInfo struct {
 	ClientId           string `json:"client_id"`
@@ -407,6 +458,60 @@ type SocialLoginUpdateRequest struct {
 	ClientId     string `json:"client_id"`
 	ClientSecret string `json:"client_secret"`
 	Enabled      bool   `json:"enabled"`
+	SocialLoginOptions
+}
+
+// SocialLoginOptions are the optional, provider-specific restrictions of a social login. Unset options are left out
+// of the request so PropelAuth keeps its defaults, while empty ones clear a restriction.
+type SocialLoginOptions struct {
+	Microsoft   *MicrosoftSocialLoginOptions `json:"microsoft,omitempty"`
+	Google      *GoogleSocialLoginOptions    `json:"google,omitempty"`
+	GitHub      *GitHubSocialLoginOptions    `json:"github,omitempty"`
+	ExtraScopes *[]string                    `json:"extra_scopes,omitempty"`
+}
+
+type MicrosoftSocialLoginOptions struct {
+	TenantId          string `json:"tenant_id"`
+	OrganizationsOnly bool   `json:"organizations_only"`
+}
+
+type GoogleSocialLoginOptions struct {
+	HostedDomains []string `json:"hosted_domains"`
+}
+
+type GitHubSocialLoginOptions struct {
+	AllowedOrganizations []string `json:"allowed_organizations"`
+}
+
+type OidcProviderInfo struct {
+	OidcProviderId        string            `json:"oidc_provider_id"`
+	Issuer                string            `json:"issuer"`
+	AuthorizationEndpoint string            `json:"authorization_endpoint"`
+	TokenEndpoint         string            `json:"token_endpoint"`
+	UserinfoEndpoint      string            `json:"userinfo_endpoint"`
+	JwksUri               string            `json:"jwks_uri"`
+	ClientId              string            `json:"client_id"`
+	Scopes                []string          `json:"scopes"`
+	ClaimMappings         map[string]string `json:"claim_mappings"`
+	ButtonLabel           string            `json:"button_label"`
+	IconImageId           *string           `json:"icon_image_id"`
+	TestRedirectUrl       string            `json:"test_redirect_url"`
+	StagingRedirectUrl    string            `json:"stage_redirect_url"`
+	ProdRedirectUrl       string            `json:"prod_redirect_url"`
+}
+
+type OidcProviderRequest struct {
+	Issuer                string            `json:"issuer"`
+	AuthorizationEndpoint string            `json:"authorization_endpoint"`
+	TokenEndpoint         string            `json:"token_endpoint"`
+	UserinfoEndpoint      string            `json:"userinfo_endpoint,omitempty"`
+	JwksUri               string            `json:"jwks_uri"`
+	ClientId              string            `json:"client_id"`
+	ClientSecret          string            `json:"client_secret"`
+	Scopes                []string          `json:"scopes"`
+	ClaimMappings         map[string]string `json:"claim_mappings"`
+	ButtonLabel           string            `json:"button_label"`
+	IconImageId           *string           `json:"icon_image_id"`
 }
 
 type OauthClientRequest struct {
@@ -427,3 +532,151 @@ type ApiKeyAlert struct {
 	Enabled           bool  `json:"enabled"`
 	AdvanceNoticeDays int32 `json:"advance_notice_days"`
 }
+
+type SamlSpMetadata struct {
+	EntityId  string `json:"entity_id"`
+	AcsUrl    string `json:"acs_url"`
+	LogoutUrl string `json:"logout_url"`
+}
+
+type SamlIdpMetadataRequest struct {
+	OrgId             string            `json:"org_id"`
+	IdpEntityId       string            `json:"idp_entity_id"`
+	IdpSsoUrl         string            `json:"idp_sso_url"`
+	IdpCertificate    string            `json:"idp_certificate"`
+	Provider          string            `json:"provider"`
+	AttributeMappings map[string]string `json:"attribute_mappings,omitempty"`
+	RoleMappings      map[string]string `json:"role_mappings,omitempty"`
+}
+
+type OrganizationInfo struct {
+	OrgId                 string                 `json:"org_id"`
+	Name                  string                 `json:"name"`
+	UrlSafeOrgSlug        string                 `json:"url_safe_org_slug"`
+	CanSetupSaml          bool                   `json:"can_setup_saml"`
+	IsSamlConfigured      bool                   `json:"is_saml_configured"`
+	IsSamlInTestMode      bool                   `json:"is_saml_in_test_mode"`
+	MaxUsers              *int64                 `json:"max_users"`
+	Metadata              map[string]interface{} `json:"metadata"`
+	CustomRoleMappingName *string                `json:"custom_role_mapping_name"`
+	Domain                *string                `json:"domain"`
+	DomainAutojoin        bool                   `json:"domain_autojoin"`
+	DomainRestrict        bool                   `json:"domain_restrict"`
+}
+
+type OrganizationCreateRequest struct {
+	Name                          string  `json:"name"`
+	Domain                        *string `json:"domain,omitempty"`
+	EnableAutoJoiningByDomain     bool    `json:"enable_auto_joining_by_domain"`
+	MembersMustHaveMatchingDomain bool    `json:"members_must_have_matching_domain"`
+	MaxUsers                      *int64  `json:"max_users,omitempty"`
+	CustomRoleMappingName         *string `json:"custom_role_mapping_name,omitempty"`
+}
+
+type OrganizationUpdateRequest struct {
+	Name                  string                 `json:"name"`
+	Domain                *string                `json:"domain"`
+	AutojoinByDomain      bool                   `json:"autojoin_by_domain"`
+	RestrictToDomain      bool                   `json:"restrict_to_domain"`
+	CanSetupSaml          bool                   `json:"can_setup_saml"`
+	MaxUsers              *int64                 `json:"max_users"`
+	Metadata              map[string]interface{} `json:"metadata"`
+	CustomRoleMappingName *string                `json:"custom_role_mapping_name,omitempty"`
+}
+
+type UserInfo struct {
+	UserId         string                           `json:"user_id"`
+	Email          string                           `json:"email"`
+	EmailConfirmed bool                             `json:"email_confirmed"`
+	Username       *string                          `json:"username"`
+	FirstName      *string                          `json:"first_name"`
+	LastName       *string                          `json:"last_name"`
+	Properties     map[string]interface{}           `json:"properties"`
+	OrgIdToOrgInfo map[string]UserOrgMembershipInfo `json:"org_id_to_org_info"`
+}
+
+type UserOrgMembershipInfo struct {
+	OrgId           string   `json:"org_id"`
+	UserRole        string   `json:"user_role"`
+	AdditionalRoles []string `json:"additional_roles"`
+}
+
+type UserCreateRequest struct {
+	Email                          string                 `json:"email"`
+	EmailConfirmed                 bool                   `json:"email_confirmed"`
+	SendEmailToConfirmEmailAddress bool                   `json:"send_email_to_confirm_email_address"`
+	Password                       *string                `json:"password,omitempty"`
+	Username                       *string                `json:"username,omitempty"`
+	FirstName                      *string                `json:"first_name,omitempty"`
+	LastName                       *string                `json:"last_name,omitempty"`
+	Properties                     map[string]interface{} `json:"properties,omitempty"`
+}
+
+type UserUpdateRequest struct {
+	Username   *string                `json:"username,omitempty"`
+	FirstName  *string                `json:"first_name,omitempty"`
+	LastName   *string                `json:"last_name,omitempty"`
+	Properties map[string]interface{} `json:"properties"`
+}
+
+type UserEmailUpdateRequest struct {
+	NewEmail                 string `json:"new_email"`
+	RequireEmailConfirmation bool   `json:"require_email_confirmation"`
+}
+
+type UserPasswordUpdateRequest struct {
+	Password string `json:"password"`
+}
+
+type OrgMembershipRequest struct {
+	UserId          string   `json:"user_id"`
+	OrgId           string   `json:"org_id"`
+	Role            string   `json:"role,omitempty"`
+	AdditionalRoles []string `json:"additional_roles,omitempty"`
+}
+
+type WebhookEventTypesResponse struct {
+	EventTypes []WebhookEventType `json:"event_types"`
+}
+
+type WebhookEventType struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+type WebhookEndpointInfo struct {
+	Id          string   `json:"id"`
+	Url         string   `json:"url"`
+	EventTypes  []string `json:"event_types"`
+	Enabled     bool     `json:"enabled"`
+	Description string   `json:"description"`
+}
+
+type WebhookEndpointCreationResponse struct {
+	Id            string `json:"id"`
+	SigningSecret string `json:"signing_secret"`
+}
+
+type WebhookEndpointSecretResponse struct {
+	SigningSecret string `json:"signing_secret"`
+}
+
+type WebhookEndpointRequest struct {
+	Url         string   `json:"url"`
+	EventTypes  []string `json:"event_types"`
+	Enabled     bool     `json:"enabled"`
+	Description string   `json:"description"`
+}
+
+type EmailTemplate struct {
+	Subject      string  `json:"subject"`
+	HtmlBody     string  `json:"html_body"`
+	TextBody     *string `json:"text_body"`
+	IsCustomized bool    `json:"is_customized"`
+}
+
+type EmailTemplateUpdateRequest struct {
+	Subject  string  `json:"subject"`
+	HtmlBody string  `json:"html_body"`
+	TextBody *string `json:"text_body"`
+}
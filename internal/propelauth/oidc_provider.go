@@ -0,0 +1,166 @@
+package propelauth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// OidcDiscoveryDocument is the subset of an OpenID provider's discovery document PropelAuth needs to federate login with it.
+type OidcDiscoveryDocument struct {
+	Issuer                string   `json:"issuer"`
+	AuthorizationEndpoint string   `json:"authorization_endpoint"`
+	TokenEndpoint         string   `json:"token_endpoint"`
+	UserinfoEndpoint      string   `json:"userinfo_endpoint"`
+	JwksUri               string   `json:"jwks_uri"`
+	ScopesSupported       []string `json:"scopes_supported"`
+}
+
+// ValidateOidcIssuerUrl checks that an issuer URL is one OpenID Connect allows, an https URL without a query or fragment,
+// such as `https://keycloak.example.com/realms/employees` or `https://example.okta.com/oauth2/default`.
+func ValidateOidcIssuerUrl(issuer string) error {
+	parsedIssuer, err := url.Parse(issuer)
+	if err != nil {
+		return fmt.Errorf("%s is not a valid URL: %s", issuer, err.Error())
+	}
+	if parsedIssuer.Scheme != "https" {
+		return fmt.Errorf("%s must start with https://", issuer)
+	}
+	if parsedIssuer.Hostname() == "" {
+		return fmt.Errorf("%s must include a host", issuer)
+	}
+	if parsedIssuer.User != nil {
+		return fmt.Errorf("%s must not include a username or password", issuer)
+	}
+	if parsedIssuer.RawQuery != "" || parsedIssuer.Fragment != "" || strings.HasSuffix(issuer, "?") || strings.HasSuffix(issuer, "#") {
+		return fmt.Errorf("%s must not include a query or fragment", issuer)
+	}
+	if strings.HasSuffix(parsedIssuer.Path, "/.well-known/openid-configuration") {
+		return fmt.Errorf("%s is a discovery document, use the issuer it belongs to instead", issuer)
+	}
+	return nil
+}
+
+// DiscoverOidcIssuer fetches and checks the discovery document of an OpenID provider.
+func DiscoverOidcIssuer(ctx context.Context, httpClient *http.Client, issuer string) (*OidcDiscoveryDocument, error) {
+	if err := ValidateOidcIssuerUrl(issuer); err != nil {
+		return nil, err
+	}
+
+	discoveryUrl := strings.TrimSuffix(issuer, "/") + "/.well-known/openid-configuration"
+	req, err := http.NewRequestWithContext(ctx, "GET", discoveryUrl, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error on creating discovery request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching %s: %w", discoveryUrl, err)
+	}
+	defer resp.Body.Close() //nolint:errcheck
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("error fetching %s: unexpected status %s", discoveryUrl, resp.Status)
+	}
+
+	document := OidcDiscoveryDocument{}
+	err = json.NewDecoder(resp.Body).Decode(&document)
+	if err != nil {
+		return nil, fmt.Errorf("%s is not a valid discovery document: %w", discoveryUrl, err)
+	}
+
+	// the issuer must match exactly, or tokens from the provider won't validate
+	if document.Issuer != issuer {
+		return nil, fmt.Errorf("the discovery document of %s is for the issuer %s, the issuer must match exactly", issuer, document.Issuer)
+	}
+	if document.AuthorizationEndpoint == "" || document.TokenEndpoint == "" || document.JwksUri == "" {
+		return nil, fmt.Errorf("the discovery document of %s is missing the authorization endpoint, token endpoint or JWKS URI", issuer)
+	}
+
+	return &document, nil
+}
+
+// GetOidcProvider - Returns the OIDC identity provider with the given ID.
+func (c *PropelAuthClient) GetOidcProvider(oidcProviderId string) (*OidcProviderInfo, error) {
+	res, err := c.get(fmt.Sprintf("oidc_provider/%s", oidcProviderId))
+	if err != nil {
+		return nil, err
+	}
+
+	oidcProvider := OidcProviderInfo{}
+	err = json.Unmarshal(res.BodyBytes, &oidcProvider)
+	if err != nil {
+		return nil, err
+	}
+
+	return &oidcProvider, nil
+}
+
+// CreateOidcProvider - Creates a new OIDC identity provider and returns it.
+func (c *PropelAuthClient) CreateOidcProvider(request OidcProviderRequest) (*OidcProviderInfo, error) {
+	body, err := json.Marshal(request)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := c.post("oidc_provider", body)
+	if err != nil {
+		return nil, err
+	}
+
+	oidcProvider := OidcProviderInfo{}
+	err = json.Unmarshal(res.BodyBytes, &oidcProvider)
+	if err != nil {
+		return nil, err
+	}
+
+	return &oidcProvider, nil
+}
+
+// UpdateOidcProvider - Updates an existing OIDC identity provider.
+func (c *PropelAuthClient) UpdateOidcProvider(oidcProviderId string, request OidcProviderRequest) error {
+	body, err := json.Marshal(request)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.put(fmt.Sprintf("oidc_provider/%s", oidcProviderId), body)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// DeleteOidcProvider - Deletes an existing OIDC identity provider.
+func (c *PropelAuthClient) DeleteOidcProvider(oidcProviderId string) error {
+	_, err := c.delete(fmt.Sprintf("oidc_provider/%s", oidcProviderId), nil)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// GetOidcProviderRedirectUrl - Returns the authorized redirect for the requested environment and OIDC identity provider.
+func (c *PropelAuthClient) GetOidcProviderRedirectUrl(environment string, oidcProviderId string) (*string, error) {
+	oidcProvider, err := c.GetOidcProvider(oidcProviderId)
+	if err != nil {
+		return nil, err
+	}
+
+	switch environment {
+	case "Test":
+		return &oidcProvider.TestRedirectUrl, nil
+	case "Staging":
+		return &oidcProvider.StagingRedirectUrl, nil
+	case "Prod":
+		return &oidcProvider.ProdRedirectUrl, nil
+	default:
+		return nil, fmt.Errorf("invalid environment when fetching OIDC provider redirect URL: %s", environment)
+	}
+}
@@ -0,0 +1,68 @@
+package propelauth
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestValidateOidcIssuerUrl(t *testing.T) {
+	tests := []struct {
+		name    string
+		issuer  string
+		wantErr bool
+	}{
+		{name: "Test Keycloak realm", issuer: "https://keycloak.example.com/realms/employees", wantErr: false},
+		{name: "Test Okta authorization server", issuer: "https://example.okta.com/oauth2/default", wantErr: false},
+		{name: "Test http issuer", issuer: "http://keycloak.example.com/realms/employees", wantErr: true},
+		{name: "Test issuer with query", issuer: "https://example.okta.com/oauth2/default?tenant=1", wantErr: true},
+		{name: "Test discovery document", issuer: "https://example.okta.com/.well-known/openid-configuration", wantErr: true},
+		{name: "Test missing host", issuer: "https:///realms/employees", wantErr: true},
+		{name: "Test not a URL", issuer: "keycloak.example.com", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidateOidcIssuerUrl(tt.issuer); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateOidcIssuerUrl() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestDiscoverOidcIssuer(t *testing.T) {
+	var advertisedIssuer string
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/realms/employees/.well-known/openid-configuration" {
+			http.NotFound(w, r)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(OidcDiscoveryDocument{
+			Issuer:                advertisedIssuer,
+			AuthorizationEndpoint: advertisedIssuer + "/protocol/openid-connect/auth",
+			TokenEndpoint:         advertisedIssuer + "/protocol/openid-connect/token",
+			JwksUri:               advertisedIssuer + "/protocol/openid-connect/certs",
+		})
+	}))
+	defer server.Close()
+	issuer := server.URL + "/realms/employees"
+
+	advertisedIssuer = issuer
+	document, err := DiscoverOidcIssuer(context.Background(), server.Client(), issuer)
+	if err != nil {
+		t.Fatalf("DiscoverOidcIssuer() error = %v", err)
+	}
+	if document.TokenEndpoint != issuer+"/protocol/openid-connect/token" {
+		t.Errorf("DiscoverOidcIssuer().TokenEndpoint = %v", document.TokenEndpoint)
+	}
+
+	advertisedIssuer = server.URL + "/realms/other"
+	if _, err := DiscoverOidcIssuer(context.Background(), server.Client(), issuer); err == nil {
+		t.Errorf("DiscoverOidcIssuer() accepted a discovery document for another issuer")
+	}
+
+	if _, err := DiscoverOidcIssuer(context.Background(), server.Client(), server.URL+"/realms/missing"); err == nil {
+		t.Errorf("DiscoverOidcIssuer() accepted a missing discovery document")
+	}
+}
@@ -0,0 +1,131 @@
+package propelauth
+
+import (
+	"encoding/json"
+	"slices"
+)
+
+// GetOrgProperties - Returns current org properties settings.
+func (c *PropelAuthClient) GetOrgProperties() (*OrgProperties, error) {
+	res, err := c.get("org_property_settings")
+	if err != nil {
+		return nil, err
+	}
+
+	orgProperties := OrgProperties{}
+	err = json.Unmarshal(res.BodyBytes, &orgProperties)
+	if err != nil {
+		return nil, err
+	}
+
+	return &orgProperties, nil
+}
+
+// UpdateOrgProperties - Updates the org properties settings.
+func (c *PropelAuthClient) UpdateOrgProperties(orgProperties *OrgProperties) (*OrgProperties, error) {
+	body, err := json.Marshal(orgProperties)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = c.put("org_property_settings", body)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.GetOrgProperties()
+}
+
+type OrgPropertySettings struct {
+	Name         string
+	DisplayName  string
+	FieldType    string
+	Required     bool
+	InJwt        bool
+	UserWritable string
+	EnumValues   []string
+}
+
+func (o *OrgPropertySettings) IsEqual(other OrgPropertySettings) bool {
+	return o.Name == other.Name &&
+		o.DisplayName == other.DisplayName &&
+		o.FieldType == other.FieldType &&
+		o.Required == other.Required &&
+		o.InJwt == other.InJwt &&
+		o.UserWritable == other.UserWritable &&
+		slices.Equal(o.EnumValues, other.EnumValues)
+}
+
+// UpsertProperty - Upserts an org property and sets it to enabled.
+func (op *OrgProperties) UpsertProperty(orgProperty OrgPropertySettings) {
+	for i := range op.Fields {
+		if op.Fields[i].Name == orgProperty.Name {
+			op.Fields[i].DisplayName = orgProperty.DisplayName
+			op.Fields[i].FieldType = orgProperty.FieldType
+			op.Fields[i].Required = orgProperty.Required
+			op.Fields[i].InJwt = orgProperty.InJwt
+			op.Fields[i].UserWritable = orgProperty.UserWritable
+			op.Fields[i].Metadata = orgPropertyMetadata{
+				EnumValues: orgProperty.EnumValues,
+			}
+			op.Fields[i].IsEnabled = true
+			return
+		}
+	}
+	op.Fields = append(op.Fields, OrgProperty{
+		Name:         orgProperty.Name,
+		DisplayName:  orgProperty.DisplayName,
+		FieldType:    orgProperty.FieldType,
+		Required:     orgProperty.Required,
+		InJwt:        orgProperty.InJwt,
+		UserWritable: orgProperty.UserWritable,
+		Metadata: orgPropertyMetadata{
+			EnumValues: orgProperty.EnumValues,
+		},
+		IsEnabled: true,
+	})
+}
+
+// DisableDroppedProperties - Disables org properties that are not in the provided list.
+func (op *OrgProperties) DisableDroppedProperties(orgProperties []OrgPropertySettings) {
+	for i := range op.Fields {
+		if !slices.ContainsFunc(orgProperties, func(orgProperty OrgPropertySettings) bool {
+			return orgProperty.Name == op.Fields[i].Name
+		}) {
+			op.Fields[i].IsEnabled = false
+		}
+	}
+}
+
+// GetEnabledProperty - Returns the settings for an enabled org property.
+func (op *OrgProperties) GetEnabledProperty(propertyName string) (OrgPropertySettings, bool) {
+	for i := range op.Fields {
+		if op.Fields[i].Name == propertyName && op.Fields[i].IsEnabled {
+			return op.Fields[i].settings(), true
+		}
+	}
+	return OrgPropertySettings{}, false
+}
+
+// GetHangingProperties - Returns a list of org properties that are enabled but not in the provided list.
+func (op *OrgProperties) GetHangingProperties(propertiesInState []string) []OrgPropertySettings {
+	var hangingProperties []OrgPropertySettings
+	for i := range op.Fields {
+		if op.Fields[i].IsEnabled && !Contains(propertiesInState, op.Fields[i].Name) {
+			hangingProperties = append(hangingProperties, op.Fields[i].settings())
+		}
+	}
+	return hangingProperties
+}
+
+func (o *OrgProperty) settings() OrgPropertySettings {
+	return OrgPropertySettings{
+		Name:         o.Name,
+		DisplayName:  o.DisplayName,
+		FieldType:    o.FieldType,
+		Required:     o.Required,
+		InJwt:        o.InJwt,
+		UserWritable: o.UserWritable,
+		EnumValues:   o.Metadata.EnumValues,
+	}
+}
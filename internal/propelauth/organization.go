@@ -0,0 +1,68 @@
+package propelauth
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// GetOrganization - Returns an organization of the environment.
+func (c *PropelAuthBackendClient) GetOrganization(orgId string) (*OrganizationInfo, error) {
+	res, err := c.get(fmt.Sprintf("org/%s", orgId))
+	if err != nil {
+		return nil, err
+	}
+
+	organization := OrganizationInfo{}
+	err = json.Unmarshal(res.BodyBytes, &organization)
+	if err != nil {
+		return nil, err
+	}
+
+	return &organization, nil
+}
+
+// CreateOrganization - Creates an organization in the environment and returns its ID.
+func (c *PropelAuthBackendClient) CreateOrganization(request OrganizationCreateRequest) (string, error) {
+	body, err := json.Marshal(request)
+	if err != nil {
+		return "", err
+	}
+
+	res, err := c.post("org/", body)
+	if err != nil {
+		return "", err
+	}
+
+	organization := OrganizationInfo{}
+	err = json.Unmarshal(res.BodyBytes, &organization)
+	if err != nil {
+		return "", err
+	}
+
+	return organization.OrgId, nil
+}
+
+// UpdateOrganization - Updates an existing organization of the environment.
+func (c *PropelAuthBackendClient) UpdateOrganization(orgId string, request OrganizationUpdateRequest) error {
+	body, err := json.Marshal(request)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.put(fmt.Sprintf("org/%s", orgId), body)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// DeleteOrganization - Deletes an existing organization of the environment, along with its memberships.
+func (c *PropelAuthBackendClient) DeleteOrganization(orgId string) error {
+	_, err := c.delete(fmt.Sprintf("org/%s", orgId))
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -2,6 +2,10 @@ package propelauth
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
 )
 
 // ValidateRolesAndPermissions - Validates an update to roles and permissions without applying it.
@@ -189,3 +193,25 @@ func (r *RolesAndPermissions) GetHierarchy() []string {
 func (r *RolesAndPermissions) IsMultiRole() bool {
 	return r.OrgRoleStructure == "multi_role"
 }
+
+// ValidateOrgRoles - Checks that a member of an organization can be given the role and additional roles.
+func (r *RolesAndPermissions) ValidateOrgRoles(role string, additionalRoles []string) error {
+	enabledRoles := []string{}
+	for _, roleDefinition := range r.Roles {
+		if !roleDefinition.Disabled {
+			enabledRoles = append(enabledRoles, roleDefinition.Name)
+		}
+	}
+
+	for _, roleName := range append([]string{role}, additionalRoles...) {
+		if !slices.Contains(enabledRoles, roleName) {
+			return fmt.Errorf("the role %q isn't one of the roles in propelauth_roles_and_permissions: %s",
+				roleName, strings.Join(enabledRoles, ", "))
+		}
+	}
+	if len(additionalRoles) > 0 && !r.IsMultiRole() {
+		return errors.New("additional roles can only be given when `multiple_roles_per_user` is enabled in propelauth_roles_and_permissions")
+	}
+
+	return nil
+}
@@ -0,0 +1,42 @@
+package propelauth
+
+import (
+	"testing"
+)
+
+func TestValidateOrgRoles(t *testing.T) {
+	hierarchy := RolesAndPermissions{
+		Roles: []RoleDefinition{
+			{Name: "Owner"},
+			{Name: "Admin"},
+			{Name: "Member"},
+			{Name: "Legacy", Disabled: true},
+		},
+		OrgRoleStructure: "single_role_in_hierarchy",
+	}
+	multiRole := hierarchy
+	multiRole.OrgRoleStructure = "multi_role"
+
+	tests := []struct {
+		name                string
+		rolesAndPermissions RolesAndPermissions
+		role                string
+		additionalRoles     []string
+		wantErr             bool
+	}{
+		{"known role", hierarchy, "Admin", nil, false},
+		{"unknown role", hierarchy, "Viewer", nil, true},
+		{"disabled role", hierarchy, "Legacy", nil, true},
+		{"additional roles in a hierarchy", hierarchy, "Admin", []string{"Member"}, true},
+		{"additional roles with multiple roles", multiRole, "Admin", []string{"Member"}, false},
+		{"unknown additional role", multiRole, "Admin", []string{"Viewer"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.rolesAndPermissions.ValidateOrgRoles(tt.role, tt.additionalRoles)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateOrgRoles() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
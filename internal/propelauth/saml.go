@@ -0,0 +1,186 @@
+package propelauth
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+const (
+	samlHttpRedirectBinding = "urn:oasis:names:tc:SAML:2.0:bindings:HTTP-Redirect"
+	samlHttpPostBinding     = "urn:oasis:names:tc:SAML:2.0:bindings:HTTP-POST"
+)
+
+// SamlIdpMetadata is what PropelAuth needs to know about an organization's SAML identity provider.
+type SamlIdpMetadata struct {
+	EntityId string
+	SsoUrl   string
+	// Certificate is the PEM encoded certificate the identity provider signs its assertions with.
+	Certificate string
+}
+
+type samlEntityDescriptor struct {
+	XMLName          xml.Name `xml:"EntityDescriptor"`
+	EntityId         string   `xml:"entityID,attr"`
+	IdpSsoDescriptor *struct {
+		KeyDescriptors []struct {
+			Use             string `xml:"use,attr"`
+			X509Certificate string `xml:"KeyInfo>X509Data>X509Certificate"`
+		} `xml:"KeyDescriptor"`
+		SingleSignOnServices []struct {
+			Binding  string `xml:"Binding,attr"`
+			Location string `xml:"Location,attr"`
+		} `xml:"SingleSignOnService"`
+	} `xml:"IDPSSODescriptor"`
+}
+
+// ParseSamlIdpMetadata parses the metadata XML of a SAML identity provider, which must describe a single entity.
+func ParseSamlIdpMetadata(metadataXml string) (*SamlIdpMetadata, error) {
+	descriptor := samlEntityDescriptor{}
+	err := xml.Unmarshal([]byte(metadataXml), &descriptor)
+	if err != nil {
+		return nil, fmt.Errorf("the IdP metadata is not a SAML EntityDescriptor: %s", err.Error())
+	}
+	if descriptor.EntityId == "" {
+		return nil, errors.New("the IdP metadata doesn't have an entityID")
+	}
+	if descriptor.IdpSsoDescriptor == nil {
+		return nil, errors.New("the IdP metadata doesn't have an IDPSSODescriptor, it may be the metadata of a service provider")
+	}
+
+	// PropelAuth redirects to the identity provider, so prefer the redirect binding
+	ssoUrl := ""
+	for _, binding := range []string{samlHttpRedirectBinding, samlHttpPostBinding} {
+		for _, service := range descriptor.IdpSsoDescriptor.SingleSignOnServices {
+			if ssoUrl == "" && service.Binding == binding {
+				ssoUrl = service.Location
+			}
+		}
+	}
+	if ssoUrl == "" {
+		return nil, errors.New("the IdP metadata doesn't have a SingleSignOnService with the HTTP-Redirect or HTTP-POST binding")
+	}
+
+	certificate := ""
+	for _, keyDescriptor := range descriptor.IdpSsoDescriptor.KeyDescriptors {
+		if certificate == "" && keyDescriptor.Use != "encryption" && strings.TrimSpace(keyDescriptor.X509Certificate) != "" {
+			certificate = keyDescriptor.X509Certificate
+		}
+	}
+	if certificate == "" {
+		return nil, errors.New("the IdP metadata doesn't have a signing certificate")
+	}
+	parsedCertificate, err := ParseSamlCertificate(certificate)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SamlIdpMetadata{
+		EntityId:    descriptor.EntityId,
+		SsoUrl:      ssoUrl,
+		Certificate: string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: parsedCertificate.Raw})),
+	}, nil
+}
+
+// FetchSamlIdpMetadata fetches and parses the metadata XML of a SAML identity provider from its metadata URL.
+func FetchSamlIdpMetadata(ctx context.Context, httpClient *http.Client, metadataUrl string) (*SamlIdpMetadata, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", metadataUrl, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error on creating metadata request: %w", err)
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching %s: %w", metadataUrl, err)
+	}
+	defer resp.Body.Close() //nolint:errcheck
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("error fetching %s: unexpected status %s", metadataUrl, resp.Status)
+	}
+	metadataXml, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return nil, fmt.Errorf("error on reading %s: %w", metadataUrl, err)
+	}
+
+	return ParseSamlIdpMetadata(string(metadataXml))
+}
+
+// ParseSamlCertificate parses an X.509 certificate, either PEM encoded or as the bare base64 found in SAML metadata.
+func ParseSamlCertificate(certificate string) (*x509.Certificate, error) {
+	der := []byte{}
+	if block, _ := pem.Decode([]byte(certificate)); block != nil {
+		der = block.Bytes
+	} else {
+		decoded, err := base64.StdEncoding.DecodeString(strings.Join(strings.Fields(certificate), ""))
+		if err != nil {
+			return nil, errors.New("the certificate is neither PEM encoded nor base64 encoded")
+		}
+		der = decoded
+	}
+
+	parsedCertificate, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, fmt.Errorf("the certificate is not a valid X.509 certificate: %s", err.Error())
+	}
+	return parsedCertificate, nil
+}
+
+// GetSamlSpMetadata - Returns what an organization's SAML identity provider needs to know about PropelAuth.
+func (c *PropelAuthBackendClient) GetSamlSpMetadata(orgId string) (*SamlSpMetadata, error) {
+	res, err := c.get(fmt.Sprintf("saml_sp_metadata/%s", orgId))
+	if err != nil {
+		return nil, err
+	}
+
+	spMetadata := SamlSpMetadata{}
+	err = json.Unmarshal(res.BodyBytes, &spMetadata)
+	if err != nil {
+		return nil, err
+	}
+
+	return &spMetadata, nil
+}
+
+// UpsertSamlIdpMetadata - Sets up or replaces an organization's SAML connection.
+func (c *PropelAuthBackendClient) UpsertSamlIdpMetadata(request SamlIdpMetadataRequest) error {
+	body, err := json.Marshal(request)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.post("saml_idp_metadata", body)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// SetSamlConnectionLive - Lets an organization's users log in with its SAML connection.
+func (c *PropelAuthBackendClient) SetSamlConnectionLive(orgId string) error {
+	_, err := c.post(fmt.Sprintf("saml_idp_metadata/go_live/%s", orgId), nil)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// DeleteSamlConnection - Removes an organization's SAML connection.
+func (c *PropelAuthBackendClient) DeleteSamlConnection(orgId string) error {
+	_, err := c.delete(fmt.Sprintf("saml_idp_metadata/%s", orgId))
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
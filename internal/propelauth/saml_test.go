@@ -0,0 +1,120 @@
+package propelauth
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"fmt"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+)
+
+func generateTestCertificate(t *testing.T, notAfter time.Time) []byte {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "idp.example.com"},
+		NotBefore:    notAfter.Add(-365 * 24 * time.Hour),
+		NotAfter:     notAfter,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, &template, &template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return der
+}
+
+func testIdpMetadataXml(certificate string, ssoServices string) string {
+	return fmt.Sprintf(`<?xml version="1.0" encoding="UTF-8"?>
+<md:EntityDescriptor xmlns:md="urn:oasis:names:tc:SAML:2.0:metadata" xmlns:ds="http://www.w3.org/2000/09/xmldsig#" entityID="http://www.okta.com/exk1234567890">
+  <md:IDPSSODescriptor protocolSupportEnumeration="urn:oasis:names:tc:SAML:2.0:protocol">
+    <md:KeyDescriptor use="signing">
+      <ds:KeyInfo><ds:X509Data><ds:X509Certificate>%s</ds:X509Certificate></ds:X509Data></ds:KeyInfo>
+    </md:KeyDescriptor>
+    %s
+  </md:IDPSSODescriptor>
+</md:EntityDescriptor>`, certificate, ssoServices)
+}
+
+func TestParseSamlIdpMetadata(t *testing.T) {
+	certificate := base64.StdEncoding.EncodeToString(generateTestCertificate(t, time.Now().Add(365*24*time.Hour)))
+	redirectService := `<md:SingleSignOnService Binding="urn:oasis:names:tc:SAML:2.0:bindings:HTTP-Redirect" Location="https://example.okta.com/app/sso/saml"/>`
+	postService := `<md:SingleSignOnService Binding="urn:oasis:names:tc:SAML:2.0:bindings:HTTP-POST" Location="https://example.okta.com/app/sso/saml/post"/>`
+
+	tests := []struct {
+		name       string
+		xml        string
+		wantSsoUrl string
+		wantErr    bool
+	}{
+		{
+			name:       "Test redirect binding is preferred",
+			xml:        testIdpMetadataXml(certificate, postService+redirectService),
+			wantSsoUrl: "https://example.okta.com/app/sso/saml",
+		},
+		{
+			name:       "Test post binding",
+			xml:        testIdpMetadataXml(certificate, postService),
+			wantSsoUrl: "https://example.okta.com/app/sso/saml/post",
+		},
+		{
+			name:    "Test missing sso service",
+			xml:     testIdpMetadataXml(certificate, ""),
+			wantErr: true,
+		},
+		{
+			name:    "Test invalid certificate",
+			xml:     testIdpMetadataXml("bm90LWEtY2VydGlmaWNhdGU=", redirectService),
+			wantErr: true,
+		},
+		{
+			name:    "Test not XML",
+			xml:     "https://example.okta.com/app/exk1234567890/sso/saml/metadata",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			metadata, err := ParseSamlIdpMetadata(tt.xml)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseSamlIdpMetadata() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if metadata.EntityId != "http://www.okta.com/exk1234567890" {
+				t.Errorf("ParseSamlIdpMetadata().EntityId = %v", metadata.EntityId)
+			}
+			if metadata.SsoUrl != tt.wantSsoUrl {
+				t.Errorf("ParseSamlIdpMetadata().SsoUrl = %v, want %v", metadata.SsoUrl, tt.wantSsoUrl)
+			}
+			if !strings.HasPrefix(metadata.Certificate, "-----BEGIN CERTIFICATE-----") {
+				t.Errorf("ParseSamlIdpMetadata().Certificate = %v, want a PEM encoded certificate", metadata.Certificate)
+			}
+		})
+	}
+}
+
+func TestParseSamlCertificate(t *testing.T) {
+	der := generateTestCertificate(t, time.Now().Add(365*24*time.Hour))
+	wrapped := base64.StdEncoding.EncodeToString(der)
+	wrapped = wrapped[:40] + "\n  " + wrapped[40:]
+
+	if _, err := ParseSamlCertificate(wrapped); err != nil {
+		t.Errorf("ParseSamlCertificate() of wrapped base64 error = %v", err)
+	}
+	if _, err := ParseSamlCertificate("-----BEGIN CERTIFICATE-----\n" + base64.StdEncoding.EncodeToString(der) + "\n-----END CERTIFICATE-----\n"); err != nil {
+		t.Errorf("ParseSamlCertificate() of PEM error = %v", err)
+	}
+	if _, err := ParseSamlCertificate("not a certificate"); err == nil {
+		t.Errorf("ParseSamlCertificate() accepted an invalid certificate")
+	}
+}
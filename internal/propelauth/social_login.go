@@ -13,12 +13,28 @@ func (c *PropelAuthClient) GetAllSocialLoginInfo() (*AllSocialLoginInfoResponse,
 		return nil, err
 	}
 
-	allSocialLoginInfo := AllSocialLoginInfoResponse{}
-	err = json.Unmarshal(res.BodyBytes, &allSocialLoginInfo)
+	return parseAllSocialLoginInfo(res.BodyBytes)
+}
+
+// parseAllSocialLoginInfo decodes the `social` payload. Every provider is keyed by its name, so ones this version
+// of the provider doesn't know are kept as well.
+func parseAllSocialLoginInfo(body []byte) (*AllSocialLoginInfoResponse, error) {
+	payload := map[string]json.RawMessage{}
+	err := json.Unmarshal(body, &payload)
 	if err != nil {
 		return nil, err
 	}
 
+	allSocialLoginInfo := AllSocialLoginInfoResponse{}
+	for key, rawInfo := range payload {
+		socialLoginInfo := SocialLoginInfo{}
+		if err := json.Unmarshal(rawInfo, &socialLoginInfo); err != nil {
+			// skip anything in the payload that isn't a provider
+			continue
+		}
+		allSocialLoginInfo[key] = socialLoginInfo
+	}
+
 	return &allSocialLoginInfo, nil
 }
 
@@ -29,34 +45,12 @@ func (c *PropelAuthClient) GetSocialLoginInfo(sso_provider string) (*SocialLogin
 		return nil, err
 	}
 
-	switch sso_provider {
-	case "Google":
-		return &allSocialLoginInfo.Google, nil
-	case "Microsoft":
-		return &allSocialLoginInfo.Microsoft, nil
-	case "GitHub":
-		return &allSocialLoginInfo.GitHub, nil
-	case "Slack":
-		return &allSocialLoginInfo.Slack, nil
-	case "LinkedIn":
-		return &allSocialLoginInfo.LinkedIn, nil
-	case "Atlassian":
-		return &allSocialLoginInfo.Atlassian, nil
-	case "Apple":
-		return &allSocialLoginInfo.Apple, nil
-	case "Salesforce":
-		return &allSocialLoginInfo.Salesforce, nil
-	case "QuickBooks":
-		return &allSocialLoginInfo.QuickBooks, nil
-	case "Xero":
-		return &allSocialLoginInfo.Xero, nil
-	case "Salesloft":
-		return &allSocialLoginInfo.Salesloft, nil
-	case "Outreach":
-		return &allSocialLoginInfo.Outreach, nil
-	default:
-		return nil, fmt.Errorf("invalid social login sso_provider: %s", sso_provider)
+	socialLoginInfo, ok := (*allSocialLoginInfo)[SocialProviderKey(sso_provider)]
+	if !ok {
+		return nil, fmt.Errorf("invalid social login sso_provider: %s, PropelAuth supports: %s", sso_provider, strings.Join(allSocialLoginInfo.Keys(), ", "))
 	}
+
+	return &socialLoginInfo, nil
 }
 
 // GetSocialLoginRedirectUrl - Returns the authorized redirect for the requested environment and sso provider.
@@ -79,11 +73,12 @@ func (c *PropelAuthClient) GetSocialLoginRedirectUrl(environment string, sso_pro
 }
 
 // UpsertSocialLoginInfo - Upserts the social login info for the requested social sso provider.
-func (c *PropelAuthClient) UpsertSocialLoginInfo(sso_provider string, clientId string, clientSecret string) error {
+func (c *PropelAuthClient) UpsertSocialLoginInfo(sso_provider string, clientId string, clientSecret string, options SocialLoginOptions) error {
 	request := SocialLoginUpdateRequest{
-		ClientId:     clientId,
-		ClientSecret: clientSecret,
-		Enabled:      true,
+		ClientId:           clientId,
+		ClientSecret:       clientSecret,
+		Enabled:            true,
+		SocialLoginOptions: options,
 	}
 
 	body, err := json.Marshal(request)
@@ -91,7 +86,7 @@ func (c *PropelAuthClient) UpsertSocialLoginInfo(sso_provider string, clientId s
 		return err
 	}
 
-	_, err = c.put(fmt.Sprintf("social/%s", strings.ToLower(sso_provider)), body)
+	_, err = c.put(fmt.Sprintf("social/%s", SocialProviderKey(sso_provider)), body)
 	if err != nil {
 		return err
 	}
@@ -112,7 +107,7 @@ func (c *PropelAuthClient) DeleteSocialLogin(sso_provider string) error {
 		return err
 	}
 
-	_, err = c.put(fmt.Sprintf("social/%s", strings.ToLower(sso_provider)), body)
+	_, err = c.put(fmt.Sprintf("social/%s", SocialProviderKey(sso_provider)), body)
 	if err != nil {
 		return err
 	}
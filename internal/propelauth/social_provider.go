@@ -0,0 +1,105 @@
+package propelauth
+
+import (
+	"sort"
+	"strings"
+)
+
+// SocialProvider describes a social login provider that PropelAuth supports.
+type SocialProvider struct {
+	// Name is how the provider is referred to in configuration, e.g. `GitHub`.
+	Name string
+	// Key is how the provider is keyed in the `social` payload and in its API path, e.g. `github`.
+	Key string
+}
+
+// socialProviders are the social login providers known to this version of the provider. PropelAuth may support
+// more, which are still readable from the `social` payload by their key.
+var socialProviders = []SocialProvider{
+	{
+		Name: "Google",
+		Key:  "google",
+	},
+	{
+		Name: "Microsoft",
+		Key:  "microsoft",
+	},
+	{
+		Name: "GitHub",
+		Key:  "github",
+	},
+	{
+		Name: "Slack",
+		Key:  "slack",
+	},
+	{
+		Name: "LinkedIn",
+		Key:  "linkedin",
+	},
+	{
+		Name: "Atlassian",
+		Key:  "atlassian",
+	},
+	{
+		Name: "Apple",
+		Key:  "apple",
+	},
+	{
+		Name: "Salesforce",
+		Key:  "salesforce",
+	},
+	{
+		Name: "QuickBooks",
+		Key:  "quickbooks",
+	},
+	{
+		Name: "Xero",
+		Key:  "xero",
+	},
+	{
+		Name: "Salesloft",
+		Key:  "salesloft",
+	},
+	{
+		Name: "Outreach",
+		Key:  "outreach",
+	},
+}
+
+// SocialProviderNames returns the names of the social login providers known to this version of the provider.
+func SocialProviderNames() []string {
+	names := make([]string, len(socialProviders))
+	for i, provider := range socialProviders {
+		names[i] = provider.Name
+	}
+	return names
+}
+
+// LookupSocialProvider returns the known social login provider with the given name or key, ignoring case.
+func LookupSocialProvider(name string) (*SocialProvider, bool) {
+	for i := range socialProviders {
+		if strings.EqualFold(socialProviders[i].Name, name) || strings.EqualFold(socialProviders[i].Key, name) {
+			return &socialProviders[i], true
+		}
+	}
+	return nil, false
+}
+
+// SocialProviderKey returns how a social login provider is keyed in the `social` payload and its API path.
+// Providers unknown to this version of the provider are keyed by their lowercased name.
+func SocialProviderKey(name string) string {
+	if provider, ok := LookupSocialProvider(name); ok {
+		return provider.Key
+	}
+	return strings.ToLower(name)
+}
+
+// Keys returns the keys of every social login provider in the payload, sorted.
+func (r AllSocialLoginInfoResponse) Keys() []string {
+	keys := make([]string, 0, len(r))
+	for key := range r {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,43 @@
+package propelauth
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSocialProviderKey(t *testing.T) {
+	tests := map[string]string{
+		"GitHub":     "github",
+		"github":     "github",
+		"LinkedIn":   "linkedin",
+		"Apple":      "apple",
+		"NewOidcIdp": "newoidcidp",
+	}
+	for name, want := range tests {
+		if got := SocialProviderKey(name); got != want {
+			t.Errorf("SocialProviderKey(%q) = %v, want %v", name, got, want)
+		}
+	}
+}
+
+func TestParseAllSocialLoginInfo(t *testing.T) {
+	body := []byte(`{
+		"github": {"client_id": "github-client", "test_redirect_url": "https://test.example.com/github/callback"},
+		"gitlab": {"client_id": "gitlab-client", "prod_redirect_url": "https://auth.example.com/gitlab/callback"},
+		"enabled_count": 2
+	}`)
+
+	allSocialLoginInfo, err := parseAllSocialLoginInfo(body)
+	if err != nil {
+		t.Fatalf("parseAllSocialLoginInfo() error = %v", err)
+	}
+	if got := strings.Join(allSocialLoginInfo.Keys(), ","); got != "github,gitlab" {
+		t.Errorf("parseAllSocialLoginInfo().Keys() = %v, want github,gitlab", got)
+	}
+	if got := (*allSocialLoginInfo)["github"].TestRedirectUrl; got != "https://test.example.com/github/callback" {
+		t.Errorf("github TestRedirectUrl = %v, want https://test.example.com/github/callback", got)
+	}
+	if got := (*allSocialLoginInfo)["gitlab"].ClientId; got != "gitlab-client" {
+		t.Errorf("gitlab ClientId = %v, want gitlab-client", got)
+	}
+}
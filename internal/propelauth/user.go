@@ -0,0 +1,143 @@
+package propelauth
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// GetUser - Returns a user of the environment, along with their organization memberships.
+func (c *PropelAuthBackendClient) GetUser(userId string) (*UserInfo, error) {
+	res, err := c.get(fmt.Sprintf("user/%s?include_orgs=true", userId))
+	if err != nil {
+		return nil, err
+	}
+
+	user := UserInfo{}
+	err = json.Unmarshal(res.BodyBytes, &user)
+	if err != nil {
+		return nil, err
+	}
+
+	return &user, nil
+}
+
+// CreateUser - Creates a user in the environment and returns their ID.
+func (c *PropelAuthBackendClient) CreateUser(request UserCreateRequest) (string, error) {
+	body, err := json.Marshal(request)
+	if err != nil {
+		return "", err
+	}
+
+	res, err := c.post("user/", body)
+	if err != nil {
+		return "", err
+	}
+
+	user := UserInfo{}
+	err = json.Unmarshal(res.BodyBytes, &user)
+	if err != nil {
+		return "", err
+	}
+
+	return user.UserId, nil
+}
+
+// UpdateUser - Updates the profile and properties of an existing user of the environment.
+func (c *PropelAuthBackendClient) UpdateUser(userId string, request UserUpdateRequest) error {
+	body, err := json.Marshal(request)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.put(fmt.Sprintf("user/%s", userId), body)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// UpdateUserEmail - Changes the email address of an existing user of the environment.
+func (c *PropelAuthBackendClient) UpdateUserEmail(userId string, request UserEmailUpdateRequest) error {
+	body, err := json.Marshal(request)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.put(fmt.Sprintf("user/%s/email", userId), body)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// UpdateUserPassword - Sets the password of an existing user of the environment.
+func (c *PropelAuthBackendClient) UpdateUserPassword(userId string, request UserPasswordUpdateRequest) error {
+	body, err := json.Marshal(request)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.put(fmt.Sprintf("user/%s/password", userId), body)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// DeleteUser - Deletes an existing user of the environment.
+func (c *PropelAuthBackendClient) DeleteUser(userId string) error {
+	_, err := c.delete(fmt.Sprintf("user/%s", userId))
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// AddUserToOrg - Makes a user a member of an organization with the requested roles.
+func (c *PropelAuthBackendClient) AddUserToOrg(request OrgMembershipRequest) error {
+	body, err := json.Marshal(request)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.post("org/add_user", body)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ChangeUserRoleInOrg - Changes the roles of a member of an organization.
+func (c *PropelAuthBackendClient) ChangeUserRoleInOrg(request OrgMembershipRequest) error {
+	body, err := json.Marshal(request)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.post("org/change_role", body)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// RemoveUserFromOrg - Removes a member from an organization.
+func (c *PropelAuthBackendClient) RemoveUserFromOrg(userId string, orgId string) error {
+	body, err := json.Marshal(OrgMembershipRequest{UserId: userId, OrgId: orgId})
+	if err != nil {
+		return err
+	}
+
+	_, err = c.post("org/remove_user", body)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
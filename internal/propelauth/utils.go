@@ -2,8 +2,9 @@ package propelauth
 
 import (
 	"fmt"
-	"net/url"
-	"strconv"
+	"strings"
+
+	"golang.org/x/net/publicsuffix"
 )
 
 func Contains(slice []string, target string) bool {
@@ -15,6 +16,47 @@ func Contains(slice []string, target string) bool {
 	return false
 }
 
+// ClosestMatch returns the option closest to target, ignoring case, spaces, dashes and underscores.
+// Options more than maxDistance edits away are not considered a match.
+func ClosestMatch(options []string, target string, maxDistance int) (string, bool) {
+	normalize := strings.NewReplacer(" ", "", "-", "", "_", "")
+	normalizedTarget := strings.ToLower(normalize.Replace(target))
+
+	closest := ""
+	closestDistance := maxDistance + 1
+	for _, option := range options {
+		distance := levenshteinDistance(strings.ToLower(normalize.Replace(option)), normalizedTarget)
+		if distance < closestDistance {
+			closest = option
+			closestDistance = distance
+		}
+	}
+
+	return closest, closest != ""
+}
+
+func levenshteinDistance(a string, b string) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+
+	return previous[len(b)]
+}
+
 func FlipBoolRef(b *bool) *bool {
 	if b == nil {
 		return nil
@@ -27,25 +69,36 @@ func FlipBoolRef(b *bool) *bool {
 	}
 }
 
+// GetPortFromLocalhost returns the port of an http URL on the local machine, such as `http://localhost:3000`
+// or `http://127.0.0.1:3000`, which PropelAuth stores as a localhost port.
 func GetPortFromLocalhost(inputUrl string) (bool, int) {
-	// Parse the inputUrl string into a URL
-	parsedURL, err := url.Parse(inputUrl)
-	if err != nil {
+	applicationUrl, err := ParseApplicationUrl(inputUrl)
+	if err != nil || !applicationUrl.IsLocalhost() {
 		return false, 0
 	}
 
-	fmt.Println(parsedURL.Hostname())
+	return true, applicationUrl.Port
+}
 
-	// Check if the URL is localhost
-	if parsedURL.Hostname() == "localhost" && parsedURL.Scheme == "http" {
-		fmt.Println(parsedURL.Port())
-		port, err := strconv.Atoi(parsedURL.Port())
-		if err == nil {
-			return true, port
-		} else {
-			return false, 0
+// RelativeRecordName returns the name of a DNS record relative to the DNS zone it is created in. If zone is empty,
+// the zone is the registrable domain of the record according to the public suffix list, so the record
+// `auth.example.co.uk` is `auth` rather than `auth.example`. A record at the apex of the zone is named `@`.
+func RelativeRecordName(recordName string, zone string) (string, error) {
+	recordName = strings.ToLower(strings.TrimSuffix(recordName, "."))
+	if zone == "" {
+		registrableDomain, err := publicsuffix.EffectiveTLDPlusOne(recordName)
+		if err != nil {
+			return "", err
 		}
+		zone = registrableDomain
 	}
+	zone = strings.ToLower(strings.TrimSuffix(zone, "."))
 
-	return false, 0
+	if recordName == zone {
+		return "@", nil
+	}
+	if !strings.HasSuffix(recordName, "."+zone) {
+		return "", fmt.Errorf("the record %s is not within the DNS zone %s", recordName, zone)
+	}
+	return strings.TrimSuffix(recordName, "."+zone), nil
 }
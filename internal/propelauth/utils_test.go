@@ -40,6 +40,49 @@ func TestContains(t *testing.T) {
 	}
 }
 
+func TestClosestMatch(t *testing.T) {
+	options := []string{"Roboto", "Inter", "OpenSans", "PlusJakartaSans"}
+	tests := []struct {
+		name      string
+		target    string
+		want      string
+		wantFound bool
+	}{
+		{
+			name:      "Test exact match",
+			target:    "Inter",
+			want:      "Inter",
+			wantFound: true,
+		},
+		{
+			name:      "Test spaces and case are ignored",
+			target:    "plus jakarta sans",
+			want:      "PlusJakartaSans",
+			wantFound: true,
+		},
+		{
+			name:      "Test typo",
+			target:    "Robotto",
+			want:      "Roboto",
+			wantFound: true,
+		},
+		{
+			name:      "Test too far away",
+			target:    "Comic Sans",
+			want:      "",
+			wantFound: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, found := ClosestMatch(options, tt.target, 3)
+			if got != tt.want || found != tt.wantFound {
+				t.Errorf("ClosestMatch() = %v, %v, want %v, %v", got, found, tt.want, tt.wantFound)
+			}
+		})
+	}
+}
+
 func TestGetPortFromLocalhost(t *testing.T) {
 	type args struct {
 		inputUrl string
@@ -87,3 +130,35 @@ func TestGetPortFromLocalhost(t *testing.T) {
 		})
 	}
 }
+
+func TestRelativeRecordName(t *testing.T) {
+	tests := []struct {
+		name       string
+		recordName string
+		zone       string
+		want       string
+		wantErr    bool
+	}{
+		{name: "Test registrable domain", recordName: "auth.example.com", zone: "", want: "auth"},
+		{name: "Test multi-label public suffix", recordName: "auth.example.co.uk", zone: "", want: "auth"},
+		{name: "Test subdomain", recordName: "_propelauth.auth.app.example.com.au", zone: "", want: "_propelauth.auth.app"},
+		{name: "Test delegated zone", recordName: "auth.prod.example.com", zone: "prod.example.com", want: "auth"},
+		{name: "Test fully qualified names", recordName: "Auth.Example.com.", zone: "example.com.", want: "auth"},
+		{name: "Test zone apex", recordName: "example.com", zone: "example.com", want: "@"},
+		{name: "Test registrable domain apex", recordName: "example.co.uk", zone: "", want: "@"},
+		{name: "Test record outside zone", recordName: "auth.example.com", zone: "other.com", wantErr: true},
+		{name: "Test zone sharing a suffix", recordName: "auth.myexample.com", zone: "example.com", wantErr: true},
+		{name: "Test public suffix only", recordName: "co.uk", zone: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := RelativeRecordName(tt.recordName, tt.zone)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("RelativeRecordName() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("RelativeRecordName() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,114 @@
+package propelauth
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// GetWebhookEventTypes - Returns the event types that webhook endpoints can subscribe to.
+func (c *PropelAuthClient) GetWebhookEventTypes() ([]WebhookEventType, error) {
+	res, err := c.get("webhook_event_types")
+	if err != nil {
+		return nil, err
+	}
+
+	eventTypes := WebhookEventTypesResponse{}
+	err = json.Unmarshal(res.BodyBytes, &eventTypes)
+	if err != nil {
+		return nil, err
+	}
+
+	return eventTypes.EventTypes, nil
+}
+
+// GetWebhookEndpoint - Returns a webhook endpoint of the requested environment.
+func (c *PropelAuthClient) GetWebhookEndpoint(environment string, webhookEndpointId string) (*WebhookEndpointInfo, error) {
+	res, err := c.get(
+		fmt.Sprintf("%v/webhook_endpoint/%v", strings.ToLower(environment), webhookEndpointId),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	webhookEndpoint := WebhookEndpointInfo{}
+	err = json.Unmarshal(res.BodyBytes, &webhookEndpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	return &webhookEndpoint, nil
+}
+
+// GetWebhookEndpointSecret - Returns the secret the requested webhook endpoint's events are signed with.
+func (c *PropelAuthClient) GetWebhookEndpointSecret(environment string, webhookEndpointId string) (string, error) {
+	res, err := c.get(
+		fmt.Sprintf("%v/webhook_endpoint/%v/secret", strings.ToLower(environment), webhookEndpointId),
+	)
+	if err != nil {
+		return "", err
+	}
+
+	secret := WebhookEndpointSecretResponse{}
+	err = json.Unmarshal(res.BodyBytes, &secret)
+	if err != nil {
+		return "", err
+	}
+
+	return secret.SigningSecret, nil
+}
+
+// CreateWebhookEndpoint - Creates a new webhook endpoint and returns its id and signing secret.
+func (c *PropelAuthClient) CreateWebhookEndpoint(environment string, request WebhookEndpointRequest) (*WebhookEndpointCreationResponse, error) {
+	body, err := json.Marshal(request)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := c.post(
+		fmt.Sprintf("%v/webhook_endpoint", strings.ToLower(environment)),
+		body,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	webhookEndpoint := WebhookEndpointCreationResponse{}
+	err = json.Unmarshal(res.BodyBytes, &webhookEndpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	return &webhookEndpoint, nil
+}
+
+// UpdateWebhookEndpoint - Updates an existing webhook endpoint.
+func (c *PropelAuthClient) UpdateWebhookEndpoint(environment string, webhookEndpointId string, request WebhookEndpointRequest) error {
+	body, err := json.Marshal(request)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.put(
+		fmt.Sprintf("%v/webhook_endpoint/%v", strings.ToLower(environment), webhookEndpointId),
+		body,
+	)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// DeleteWebhookEndpoint - Deletes an existing webhook endpoint.
+func (c *PropelAuthClient) DeleteWebhookEndpoint(environment string, webhookEndpointId string) error {
+	_, err := c.delete(
+		fmt.Sprintf("%v/webhook_endpoint/%v", strings.ToLower(environment), webhookEndpointId),
+		nil,
+	)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -85,12 +85,14 @@ func (r *basicAuthConfigurationResource) Schema(ctx context.Context, req resourc
 				Description: "If true, you will be able to use the APIs to collect emails before launching. The default setting is false.",
 			},
 			"user_autologout_seconds": schema.Int64Attribute{
-				Optional: true,
+				Optional:           true,
+				DeprecationMessage: "Use `session_idle_timeout` or `session_absolute_lifetime` of `propelauth_session_settings` instead.",
 				Description: "The number of seconds before a user is automatically logged out. The default setting is 1209600 (14 days)." +
 					"See also \"user_autologout_type\" for more information.",
 			},
 			"user_autologout_type": schema.StringAttribute{
-				Optional: true,
+				Optional:           true,
+				DeprecationMessage: "Use `session_idle_timeout` or `session_absolute_lifetime` of `propelauth_session_settings` instead.",
 				Validators: []validator.String{
 					stringvalidator.OneOf("AfterInactivity", "AfterLogin"),
 				},
@@ -111,12 +113,14 @@ func (r *basicAuthConfigurationResource) Schema(ctx context.Context, req resourc
 					"See `https://docs.propelauth.com/overview/user-management/user-properties#login-method-property` for more information.",
 			},
 			"has_phone_mfa": schema.BoolAttribute{
-				Optional: true,
+				Optional:           true,
+				DeprecationMessage: "Use `allowed_factors` of `propelauth_mfa_policy` instead.",
 				Description: "If true, users will be able to set up SMS as a 2FA method." +
 					"See `https://docs.propelauth.com/overview/authentication/mfa#sms-mfa` for more information on setting up SMS MFA.",
 			},
 			"all_users_must_setup_2fa": schema.BoolAttribute{
-				Optional: true,
+				Optional:           true,
+				DeprecationMessage: "Use `require_mfa_for_all_users` of `propelauth_mfa_policy` instead.",
 				Description: "If true, all users will be required to use multifactor authentication when logging in. " +
 					"Note: This feature is only available on some pricing plans.",
 			},
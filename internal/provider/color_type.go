@@ -0,0 +1,135 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"terraform-provider-propelauth/internal/propelauth"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/attr/xattr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// Ensure the color type and value fully satisfy framework interfaces.
+var _ basetypes.StringTypable = colorType{}
+var _ basetypes.StringValuableWithSemanticEquals = colorValue{}
+var _ xattr.ValidateableAttribute = colorValue{}
+
+// colorType is a string attribute type for theme colors. It accepts any spelling supported by
+// propelauth.ParseColor and considers two spellings equal when they describe the same RGB color,
+// so a configured `white` is kept in state when the API returns `#ffffff`. Changing the configured
+// spelling, e.g. from `white` to `#FFF`, still plans an update.
+type colorType struct {
+	basetypes.StringType
+}
+
+func (t colorType) Equal(o attr.Type) bool {
+	other, ok := o.(colorType)
+	if !ok {
+		return false
+	}
+
+	return t.StringType.Equal(other.StringType)
+}
+
+func (t colorType) String() string {
+	return "colorType"
+}
+
+func (t colorType) ValueFromString(ctx context.Context, in basetypes.StringValue) (basetypes.StringValuable, diag.Diagnostics) {
+	return colorValue{StringValue: in}, nil
+}
+
+func (t colorType) ValueFromTerraform(ctx context.Context, in tftypes.Value) (attr.Value, error) {
+	attrValue, err := t.StringType.ValueFromTerraform(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+
+	stringValue, ok := attrValue.(basetypes.StringValue)
+	if !ok {
+		return nil, fmt.Errorf("unexpected value type of %T", attrValue)
+	}
+
+	stringValuable, diags := t.ValueFromString(ctx, stringValue)
+	if diags.HasError() {
+		return nil, fmt.Errorf("unexpected error converting StringValue to StringValuable: %v", diags)
+	}
+
+	return stringValuable, nil
+}
+
+func (t colorType) ValueType(ctx context.Context) attr.Value {
+	return colorValue{}
+}
+
+// colorValue is the value of a colorType attribute.
+type colorValue struct {
+	basetypes.StringValue
+}
+
+func newColorValue(rgb propelauth.RgbColor) colorValue {
+	return colorValue{StringValue: types.StringValue(convertRgbToHexColor(rgb))}
+}
+
+func (v colorValue) Equal(o attr.Value) bool {
+	other, ok := o.(colorValue)
+	if !ok {
+		return false
+	}
+
+	return v.StringValue.Equal(other.StringValue)
+}
+
+func (v colorValue) Type(ctx context.Context) attr.Type {
+	return colorType{}
+}
+
+func (v colorValue) StringSemanticEquals(ctx context.Context, newValuable basetypes.StringValuable) (bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	newValue, ok := newValuable.(colorValue)
+	if !ok {
+		diags.AddError(
+			"Semantic Equality Check Error",
+			fmt.Sprintf("Expected value type %T but got value type %T. Please report this issue to the provider developers.", v, newValuable),
+		)
+		return false, diags
+	}
+
+	priorColor, err := propelauth.ParseColor(v.ValueString())
+	if err != nil {
+		return false, diags
+	}
+	newColor, err := propelauth.ParseColor(newValue.ValueString())
+	if err != nil {
+		return false, diags
+	}
+
+	return priorColor == newColor, diags
+}
+
+func (v colorValue) ValidateAttribute(ctx context.Context, req xattr.ValidateAttributeRequest, resp *xattr.ValidateAttributeResponse) {
+	if v.IsNull() || v.IsUnknown() {
+		return
+	}
+
+	if _, err := propelauth.ParseColor(v.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid color",
+			fmt.Sprintf("%s must be a hex color code, rgb(), hsl() or CSS named color: %s", req.Path, err.Error()),
+		)
+	}
+}
+
+// RgbColor returns the parsed color. Values are validated before they reach the plan,
+// so an unparsable value here falls back to black.
+func (v colorValue) RgbColor() propelauth.RgbColor {
+	rgb, _ := propelauth.ParseColor(v.ValueString())
+	return rgb
+}
@@ -0,0 +1,42 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"terraform-provider-propelauth/internal/propelauth"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestColorValueStringSemanticEquals(t *testing.T) {
+	ctx := context.Background()
+
+	// The prior value is the configured spelling, and the new one is the canonical hex color read from the API.
+	// When they're semantically equal, the framework keeps the configured spelling in state.
+	tests := []struct {
+		name  string
+		prior string
+		read  propelauth.RgbColor
+		want  bool
+	}{
+		{name: "Test canonical hex", prior: "#000000", read: propelauth.RgbColor{}, want: true},
+		{name: "Test named color", prior: "black", read: propelauth.RgbColor{}, want: true},
+		{name: "Test 3-digit uppercase hex", prior: "#FFF", read: propelauth.RgbColor{Red: 255, Green: 255, Blue: 255}, want: true},
+		{name: "Test rgb", prior: "rgb(80, 200, 120)", read: propelauth.RgbColor{Red: 80, Green: 200, Blue: 120}, want: true},
+		{name: "Test different color", prior: "white", read: propelauth.RgbColor{}, want: false},
+		{name: "Test invalid color", prior: "blurple", read: propelauth.RgbColor{}, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prior := colorValue{StringValue: types.StringValue(tt.prior)}
+			got, diags := prior.StringSemanticEquals(ctx, newColorValue(tt.read))
+			if diags.HasError() {
+				t.Fatalf("StringSemanticEquals() diagnostics = %v", diags)
+			}
+			if got != tt.want {
+				t.Errorf("StringSemanticEquals() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
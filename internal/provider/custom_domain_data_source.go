@@ -0,0 +1,212 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"terraform-provider-propelauth/internal/propelauth"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource = &customDomainDataSource{}
+)
+
+// NewCustomDomainDataSource is a helper function to simplify the provider implementation.
+func NewCustomDomainDataSource() datasource.DataSource {
+	return &customDomainDataSource{}
+}
+
+type customDomainDataSource struct {
+	client *propelauth.PropelAuthClient
+}
+
+type customDomainDataSourceModel struct {
+	Environment      types.String                    `tfsdk:"environment"`
+	Domain           types.String                    `tfsdk:"domain"`
+	Subdomain        types.String                    `tfsdk:"subdomain"`
+	IsVerified       types.Bool                      `tfsdk:"is_verified"`
+	IsPending        types.Bool                      `tfsdk:"is_pending"`
+	TxtRecordKey     types.String                    `tfsdk:"txt_record_key"`
+	TxtRecordValue   types.String                    `tfsdk:"txt_record_value"`
+	CnameRecordKey   types.String                    `tfsdk:"cname_record_key"`
+	CnameRecordValue types.String                    `tfsdk:"cname_record_value"`
+	PendingSwitch    *customDomainPendingSwitchModel `tfsdk:"pending_switch"`
+}
+
+type customDomainPendingSwitchModel struct {
+	Domain           types.String `tfsdk:"domain"`
+	Subdomain        types.String `tfsdk:"subdomain"`
+	IsVerified       types.Bool   `tfsdk:"is_verified"`
+	TxtRecordKey     types.String `tfsdk:"txt_record_key"`
+	TxtRecordValue   types.String `tfsdk:"txt_record_value"`
+	CnameRecordKey   types.String `tfsdk:"cname_record_key"`
+	CnameRecordValue types.String `tfsdk:"cname_record_value"`
+}
+
+// Metadata returns the data source type name.
+func (d *customDomainDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_custom_domain"
+}
+
+// Schema defines the schema for the data source.
+func (d *customDomainDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Retrieve the custom domain of one of your PropelAuth environments, along with the DNS records it needs. " +
+			"This lets configurations that don't manage the `propelauth_custom_domain` resource, such as the one for your DNS zone, " +
+			"look up the records without sharing remote state.",
+		Attributes: map[string]schema.Attribute{
+			"environment": schema.StringAttribute{
+				Required: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("Staging", "Prod"),
+				},
+				Description: "The environment of the custom domain. Accepted values are `Staging` and `Prod`.",
+			},
+			"domain": schema.StringAttribute{
+				Computed:    true,
+				Description: "The domain name for the custom domain.",
+			},
+			"subdomain": schema.StringAttribute{
+				Computed:    true,
+				Description: "The subdomain for the custom domain, if any.",
+			},
+			"is_verified": schema.BoolAttribute{
+				Computed:    true,
+				Description: "True if the custom domain has been verified.",
+			},
+			"is_pending": schema.BoolAttribute{
+				Computed:    true,
+				Description: "True if a change to the custom domain is pending.",
+			},
+			"txt_record_key": schema.StringAttribute{
+				Computed:    true,
+				Description: "The TXT record key for the custom domain. This is only returned until the domain is verified.",
+			},
+			"txt_record_value": schema.StringAttribute{
+				Computed:    true,
+				Description: "The TXT record value for the custom domain. This is only returned until the domain is verified.",
+			},
+			"cname_record_key": schema.StringAttribute{
+				Computed:    true,
+				Description: "The CNAME record key for the custom domain. This is only returned until the domain is verified.",
+			},
+			"cname_record_value": schema.StringAttribute{
+				Computed:    true,
+				Description: "The CNAME record value for the custom domain. This is only returned until the domain is verified.",
+			},
+			"pending_switch": schema.SingleNestedAttribute{
+				Computed: true,
+				Attributes: map[string]schema.Attribute{
+					"domain": schema.StringAttribute{
+						Computed:    true,
+						Description: "The domain name being switched to.",
+					},
+					"subdomain": schema.StringAttribute{
+						Computed:    true,
+						Description: "The subdomain being switched to, if any.",
+					},
+					"is_verified": schema.BoolAttribute{
+						Computed:    true,
+						Description: "True if the domain being switched to has been verified.",
+					},
+					"txt_record_key": schema.StringAttribute{
+						Computed:    true,
+						Description: "The TXT record key for the domain being switched to.",
+					},
+					"txt_record_value": schema.StringAttribute{
+						Computed:    true,
+						Description: "The TXT record value for the domain being switched to.",
+					},
+					"cname_record_key": schema.StringAttribute{
+						Computed:    true,
+						Description: "The CNAME record key for the domain being switched to.",
+					},
+					"cname_record_value": schema.StringAttribute{
+						Computed:    true,
+						Description: "The CNAME record value for the domain being switched to.",
+					},
+				},
+				Description: "The domain the environment is switching to while its verified custom domain keeps serving. " +
+					"This is null if no switch is in progress.",
+			},
+		},
+	}
+}
+
+func (d *customDomainDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*propelauth.PropelAuthClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data source Configure Type",
+			fmt.Sprintf("Expected *propelauth.PropelAuthClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *customDomainDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state customDomainDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Fetch the data from the PropelAuth API
+	environment := state.Environment.ValueString()
+	customDomainInfo, err := d.client.GetCustomDomainInfo(environment, false)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to fetch data from PropelAuth API", err.Error())
+		return
+	}
+	state.Domain = types.StringValue(customDomainInfo.Domain)
+	state.Subdomain = types.StringPointerValue(customDomainInfo.Subdomain)
+	state.IsVerified = types.BoolValue(customDomainInfo.IsVerified)
+	state.IsPending = types.BoolValue(customDomainInfo.IsPending)
+	state.TxtRecordKey = types.StringPointerValue(customDomainInfo.TxtRecordKey)
+	state.TxtRecordValue = types.StringPointerValue(customDomainInfo.TxtRecordValue)
+	state.CnameRecordKey = types.StringPointerValue(customDomainInfo.CnameRecordKey)
+	state.CnameRecordValue = types.StringPointerValue(customDomainInfo.CnameRecordValue)
+
+	// Only a verified custom domain can be switched away from
+	state.PendingSwitch = nil
+	if customDomainInfo.IsVerified {
+		pendingDomainInfo, err := d.client.GetCustomDomainInfo(environment, true)
+		if err != nil && !propelauth.IsPropelAuthNotFoundError(err) {
+			resp.Diagnostics.AddError("Failed to fetch data from PropelAuth API", err.Error())
+			return
+		}
+
+		if err == nil && pendingDomainInfo.Domain != "" && !customDomainMatches(customDomainInfo, pendingDomainInfo.Domain, pendingDomainInfo.Subdomain) {
+			state.PendingSwitch = &customDomainPendingSwitchModel{
+				Domain:           types.StringValue(pendingDomainInfo.Domain),
+				Subdomain:        types.StringPointerValue(pendingDomainInfo.Subdomain),
+				IsVerified:       types.BoolValue(pendingDomainInfo.IsVerified),
+				TxtRecordKey:     types.StringPointerValue(pendingDomainInfo.TxtRecordKey),
+				TxtRecordValue:   types.StringPointerValue(pendingDomainInfo.TxtRecordValue),
+				CnameRecordKey:   types.StringPointerValue(pendingDomainInfo.CnameRecordKey),
+				CnameRecordValue: types.StringPointerValue(pendingDomainInfo.CnameRecordValue),
+			}
+		}
+	}
+
+	// Write the data to the Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
@@ -8,11 +8,15 @@ import (
 	"terraform-provider-propelauth/internal/propelauth"
 
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
@@ -21,6 +25,7 @@ import (
 var _ resource.Resource = &customDomainResource{}
 var _ resource.ResourceWithConfigure = &customDomainResource{}
 var _ resource.ResourceWithImportState = &customDomainResource{}
+var _ resource.ResourceWithValidateConfig = &customDomainResource{}
 
 func NewCustomDomainResource() resource.Resource {
 	return &customDomainResource{}
@@ -42,6 +47,31 @@ type customDomainResourceModel struct {
 	CnameRecordKey              types.String `tfsdk:"cname_record_key"`
 	CnameRecordKeyWithoutDomain types.String `tfsdk:"cname_record_key_without_domain"`
 	CnameRecordValue            types.String `tfsdk:"cname_record_value"`
+	IsSwitching                 types.Bool   `tfsdk:"is_switching"`
+	DnsZone                     types.String `tfsdk:"dns_zone"`
+	DnsRecords                  types.List   `tfsdk:"dns_records"`
+}
+
+// customDomainDnsRecordTtl is the TTL, in seconds, suggested for the custom domain's DNS records.
+const customDomainDnsRecordTtl = 300
+
+var customDomainDnsRecordAttrTypes = map[string]attr.Type{
+	"type":          types.StringType,
+	"name":          types.StringType,
+	"relative_name": types.StringType,
+	"value":         types.StringType,
+	"ttl":           types.Int64Type,
+}
+
+// plannedCustomDomainDnsRecord is a custom domain DNS record of the given type before it's created.
+func plannedCustomDomainDnsRecord(recordType string) map[string]attr.Value {
+	return map[string]attr.Value{
+		"type":          types.StringValue(recordType),
+		"name":          types.StringUnknown(),
+		"relative_name": types.StringUnknown(),
+		"value":         types.StringUnknown(),
+		"ttl":           types.Int64Value(customDomainDnsRecordTtl),
+	}
 }
 
 func (r *customDomainResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -61,7 +91,10 @@ func (r *customDomainResource) Schema(ctx context.Context, req resource.SchemaRe
 		Description: "This resource just sets up the process of verifying the domain. " +
 			"It will return the TXT and CNAME records that you need to add to your DNS settings. " +
 			"You will need to add these records to your DNS settings manually or using Terraform. " +
-			"Then, the `propelauth_custom_domain_verification` resource will verify the domain.",
+			"Then, the `propelauth_custom_domain_verification` resource will verify the domain. " +
+			"If the environment already has a verified custom domain, changing `domain` or `subdomain` stages the new domain " +
+			"as a pending switch. The records returned are for the new domain, and the current domain keeps serving your " +
+			"hosted pages until `propelauth_custom_domain_verification` verifies the new one and cuts over.",
 		Attributes: map[string]schema.Attribute{
 			"environment": schema.StringAttribute{
 				Required: true,
@@ -102,13 +135,26 @@ func (r *customDomainResource) Schema(ctx context.Context, req resource.SchemaRe
 					"Your resulting auth domain will be `auth.<subdomain>.<domain>`. " +
 					"The value must not begin or end with a period.",
 			},
+			"dns_zone": schema.StringAttribute{
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(
+						domainNameRegex,
+						"`dns_zone` must be a valid domain name with lowercase characters such as 'your.site.com'.",
+					),
+				},
+				Description: "The DNS zone the records will be created in, such as `example.com` or a delegated `prod.example.com`. " +
+					"This is used to derive the record names without the domain. " +
+					"If not set, the zone is the registrable domain of `domain` according to the public suffix list, " +
+					"e.g. `example.co.uk` for `app.example.co.uk`.",
+			},
 			"txt_record_key": schema.StringAttribute{
 				Computed:    true,
 				Description: "The TXT record key for the custom domain.",
 			},
 			"txt_record_key_without_domain": schema.StringAttribute{
 				Computed:    true,
-				Description: "The TXT record key for the custom domain without the domain (e.g. just auth instead of auth.example.com) .",
+				Description: "The TXT record key for the custom domain relative to `dns_zone` (e.g. just auth instead of auth.example.com) .",
 			},
 			"txt_record_value": schema.StringAttribute{
 				Computed:    true,
@@ -120,16 +166,90 @@ func (r *customDomainResource) Schema(ctx context.Context, req resource.SchemaRe
 			},
 			"cname_record_key_without_domain": schema.StringAttribute{
 				Computed:    true,
-				Description: "The CNAME record key for the custom domain without the domain (e.g. just auth instead of auth.example.com) .",
+				Description: "The CNAME record key for the custom domain relative to `dns_zone` (e.g. just auth instead of auth.example.com) .",
 			},
 			"cname_record_value": schema.StringAttribute{
 				Computed:    true,
 				Description: "The CNAME record value for the custom domain.",
 			},
+			"dns_records": schema.ListNestedAttribute{
+				Computed: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"type": schema.StringAttribute{
+							Computed:    true,
+							Description: "The type of the DNS record, `TXT` or `CNAME`.",
+						},
+						"name": schema.StringAttribute{
+							Computed:    true,
+							Description: "The full name of the DNS record, e.g. `auth.example.com`.",
+						},
+						"relative_name": schema.StringAttribute{
+							Computed:    true,
+							Description: "The name of the DNS record relative to `dns_zone`, e.g. `auth`.",
+						},
+						"value": schema.StringAttribute{
+							Computed:    true,
+							Description: "The value of the DNS record.",
+						},
+						"ttl": schema.Int64Attribute{
+							Computed:    true,
+							Description: "A suggested TTL for the DNS record, in seconds.",
+						},
+					},
+				},
+				PlanModifiers: []planmodifier.List{
+					dnsRecordsPlanModifier{
+						attrTypes: customDomainDnsRecordAttrTypes,
+						plannedRecords: []map[string]attr.Value{
+							plannedCustomDomainDnsRecord("TXT"),
+							plannedCustomDomainDnsRecord("CNAME"),
+						},
+						domainPaths: []path.Path{path.Root("domain"), path.Root("subdomain")},
+					},
+				},
+				Description: "The TXT and CNAME records for the custom domain, in a form that can be passed to " +
+					"your DNS provider's record resources with `for_each`.",
+			},
+			"is_switching": schema.BoolAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.Bool{
+					useStateForUnknownUnlessChangedModifier{paths: []path.Path{path.Root("domain"), path.Root("subdomain")}},
+				},
+				Description: "True if the custom domain is staged as a switch away from the environment's currently verified " +
+					"custom domain, which keeps serving until this one is verified.",
+			},
 		},
 	}
 }
 
+func (r *customDomainResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config customDomainResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if config.DnsZone.IsNull() || config.DnsZone.IsUnknown() || config.Domain.IsUnknown() || config.Subdomain.IsUnknown() {
+		return
+	}
+
+	fullDomain := config.Domain.ValueString()
+	if !config.Subdomain.IsNull() {
+		fullDomain = config.Subdomain.ValueString() + "." + fullDomain
+	}
+
+	dnsZone := config.DnsZone.ValueString()
+	if fullDomain != dnsZone && !strings.HasSuffix(fullDomain, "."+dnsZone) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("dns_zone"),
+			"Invalid DNS zone",
+			fmt.Sprintf("The DNS records for %s can't be created in the DNS zone %s. "+
+				"`dns_zone` must be the domain itself or one of its parent domains.", fullDomain, dnsZone),
+		)
+	}
+}
+
 func (r *customDomainResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	// Prevent panic if the provider has not been configured.
 	if req.ProviderData == nil {
@@ -160,11 +280,22 @@ func (r *customDomainResource) Create(ctx context.Context, req resource.CreateRe
 		return
 	}
 
-	// Update the custom domain info
+	// Check for a verified custom domain that needs to keep serving until this one is verified
 	environment := plan.Environment.ValueString()
+	customDomainInfo, err := r.client.GetCustomDomainInfo(environment, false)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error getting custom domain info",
+			"Could not get custom domain info, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	// Update the custom domain info
 	domain := plan.Domain.ValueString()
 	subdomain := plan.Subdomain.ValueStringPointer()
-	customDomainInfo, err := r.client.UpdateCustomDomainInfo(environment, domain, subdomain, false)
+	isSwitching := isCustomDomainSwitch(customDomainInfo, domain, subdomain)
+	customDomainInfo, err = r.client.UpdateCustomDomainInfo(environment, domain, subdomain, isSwitching)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error setting custom domain info",
@@ -177,20 +308,12 @@ func (r *customDomainResource) Create(ctx context.Context, req resource.CreateRe
 	// Documentation: https://terraform.io/plugin/log
 	tflog.Trace(ctx, "created a propelauth_custom_domain resource")
 
-	// Derive fields so they can be used either with or without the full domain
-	cnameRecordKeyParts := strings.Split(*customDomainInfo.CnameRecordKey, ".")
-	cnameRecordKeyWithoutDomain := strings.Join(cnameRecordKeyParts[:len(cnameRecordKeyParts)-2], ".")
-
-	txtRecordKeyParts := strings.Split(*customDomainInfo.TxtRecordKey, ".")
-	txtRecordKeyWithoutDomain := strings.Join(txtRecordKeyParts[:len(txtRecordKeyParts)-2], ".")
-
 	// Save data into Terraform state
-	plan.CnameRecordKey = types.StringPointerValue(customDomainInfo.CnameRecordKey)
-	plan.CnameRecordValue = types.StringPointerValue(customDomainInfo.CnameRecordValue)
-	plan.CnameRecordKeyWithoutDomain = types.StringValue(cnameRecordKeyWithoutDomain)
-	plan.TxtRecordKey = types.StringPointerValue(customDomainInfo.TxtRecordKey)
-	plan.TxtRecordKeyWithoutDomain = types.StringValue(txtRecordKeyWithoutDomain)
-	plan.TxtRecordValue = types.StringPointerValue(customDomainInfo.TxtRecordValue)
+	resp.Diagnostics.Append(updateCustomDomainRecords(&plan, customDomainInfo)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	plan.IsSwitching = types.BoolValue(isSwitching)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
 }
 
@@ -205,7 +328,6 @@ func (r *customDomainResource) Read(ctx context.Context, req resource.ReadReques
 	// Get the main env's custom domain info
 	environment := state.Environment.ValueString()
 
-	// isSwitching := state.IsPending.ValueBool()
 	customDomainInfo, err := r.client.GetCustomDomainInfo(environment, false)
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -215,12 +337,16 @@ func (r *customDomainResource) Read(ctx context.Context, req resource.ReadReques
 		return
 	}
 
-	isDomainOrSubdomainChanged := state.Domain.ValueString() != customDomainInfo.Domain || state.Subdomain.ValueStringPointer() != customDomainInfo.Subdomain
-
-	isSwitching := isDomainOrSubdomainChanged && customDomainInfo.IsVerified
+	isSwitching := isCustomDomainSwitch(customDomainInfo, state.Domain.ValueString(), state.Subdomain.ValueStringPointer())
 	if isSwitching {
 		// If the domain is switching, fetch the pending state instead.
 		customDomainInfo, err = r.client.GetCustomDomainInfo(environment, true)
+		if propelauth.IsPropelAuthNotFoundError(err) {
+			// The switch was cancelled outside of Terraform, so it's staged again
+			tflog.Trace(ctx, "deleting a propelauth_custom_domain resource because its pending switch was not found in PropelAuth")
+			resp.State.RemoveResource(ctx)
+			return
+		}
 		if err != nil {
 			resp.Diagnostics.AddError(
 				"Error getting custom domain info",
@@ -237,29 +363,14 @@ func (r *customDomainResource) Read(ctx context.Context, req resource.ReadReques
 	// Set the data from the state into the response
 	state.Domain = types.StringValue(customDomainInfo.Domain)
 	state.Subdomain = types.StringPointerValue(customDomainInfo.Subdomain)
+	state.IsSwitching = types.BoolValue(isSwitching)
 
 	// So as not to need an update after verification of a domain,
 	// these fields are only updated if the domain is not verified, which is when they are
 	// returned.
-	if customDomainInfo.TxtRecordKey != nil {
-		state.TxtRecordKey = types.StringPointerValue(customDomainInfo.TxtRecordKey)
-
-		txtRecordKeyParts := strings.Split(*customDomainInfo.TxtRecordKey, ".")
-		txtRecordKeyWithoutDomain := strings.Join(txtRecordKeyParts[:len(txtRecordKeyParts)-2], ".")
-		state.TxtRecordKeyWithoutDomain = types.StringValue(txtRecordKeyWithoutDomain)
-	}
-	if customDomainInfo.TxtRecordValue != nil {
-		state.TxtRecordValue = types.StringPointerValue(customDomainInfo.TxtRecordValue)
-	}
-	if customDomainInfo.CnameRecordKey != nil {
-		state.CnameRecordKey = types.StringPointerValue(customDomainInfo.CnameRecordKey)
-
-		cnameRecordKeyParts := strings.Split(*customDomainInfo.CnameRecordKey, ".")
-		cnameRecordKeyWithoutDomain := strings.Join(cnameRecordKeyParts[:len(cnameRecordKeyParts)-2], ".")
-		state.CnameRecordKeyWithoutDomain = types.StringValue(cnameRecordKeyWithoutDomain)
-	}
-	if customDomainInfo.CnameRecordValue != nil {
-		state.CnameRecordValue = types.StringPointerValue(customDomainInfo.CnameRecordValue)
+	resp.Diagnostics.Append(updateCustomDomainRecords(&state, customDomainInfo)...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
@@ -281,6 +392,25 @@ func (r *customDomainResource) Update(ctx context.Context, req resource.UpdateRe
 		return
 	}
 
+	// Only `dns_zone` changed, so the records stay the same and just their relative names are derived again.
+	// The API doesn't return the records of a verified domain, so they come from the state.
+	if plan.Domain.Equal(state.Domain) && plan.Subdomain.Equal(state.Subdomain) {
+		tflog.Trace(ctx, "updated a propelauth_custom_domain resource")
+
+		resp.Diagnostics.Append(updateCustomDomainRecords(&plan, &propelauth.CustomDomainInfoResponse{
+			TxtRecordKey:     state.TxtRecordKey.ValueStringPointer(),
+			TxtRecordValue:   state.TxtRecordValue.ValueStringPointer(),
+			CnameRecordKey:   state.CnameRecordKey.ValueStringPointer(),
+			CnameRecordValue: state.CnameRecordValue.ValueStringPointer(),
+		})...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		plan.IsSwitching = state.IsSwitching
+		resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+		return
+	}
+
 	// Re-fetch the main env's custom domain info to check
 	// if its verification status has changed.
 	customDomainInfo, err := r.client.GetCustomDomainInfo(state.Environment.ValueString(), false)
@@ -292,14 +422,11 @@ func (r *customDomainResource) Update(ctx context.Context, req resource.UpdateRe
 		return
 	}
 
-	isVerified := customDomainInfo.IsVerified
-	isPending := customDomainInfo.IsPending
-
 	// Update the custom domain info
 	environment := plan.Environment.ValueString()
 	domain := plan.Domain.ValueString()
 	subdomain := plan.Subdomain.ValueStringPointer()
-	isSwitching := isPending || (!isPending && isVerified)
+	isSwitching := isCustomDomainSwitch(customDomainInfo, domain, subdomain)
 	customDomainInfo, err = r.client.UpdateCustomDomainInfo(environment, domain, subdomain, isSwitching)
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -313,20 +440,12 @@ func (r *customDomainResource) Update(ctx context.Context, req resource.UpdateRe
 	// Documentation: https://terraform.io/plugin/log
 	tflog.Trace(ctx, "updated a propelauth_custom_domain resource")
 
-	// Derive fields so they can be used either with or without the full domain
-	cnameRecordKeyParts := strings.Split(*customDomainInfo.CnameRecordKey, ".")
-	cnameRecordKeyWithoutDomain := strings.Join(cnameRecordKeyParts[:len(cnameRecordKeyParts)-2], ".")
-
-	txtRecordKeyParts := strings.Split(*customDomainInfo.TxtRecordKey, ".")
-	txtRecordKeyWithoutDomain := strings.Join(txtRecordKeyParts[:len(txtRecordKeyParts)-2], ".")
-
 	// Save data into Terraform state
-	plan.CnameRecordKey = types.StringPointerValue(customDomainInfo.CnameRecordKey)
-	plan.CnameRecordKeyWithoutDomain = types.StringValue(cnameRecordKeyWithoutDomain)
-	plan.CnameRecordValue = types.StringPointerValue(customDomainInfo.CnameRecordValue)
-	plan.TxtRecordKey = types.StringPointerValue(customDomainInfo.TxtRecordKey)
-	plan.TxtRecordKeyWithoutDomain = types.StringValue(txtRecordKeyWithoutDomain)
-	plan.TxtRecordValue = types.StringPointerValue(customDomainInfo.TxtRecordValue)
+	resp.Diagnostics.Append(updateCustomDomainRecords(&plan, customDomainInfo)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	plan.IsSwitching = types.BoolValue(isSwitching)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
 }
 
@@ -360,30 +479,214 @@ func (r *customDomainResource) ImportState(ctx context.Context, req resource.Imp
 	state.Environment = types.StringValue(environment)
 	state.Domain = types.StringValue(customDomainInfo.Domain)
 	state.Subdomain = types.StringPointerValue(customDomainInfo.Subdomain)
+	state.IsSwitching = types.BoolValue(false)
 
 	// So as not to need an update after verification of a domain,
 	// these fields are only updated if the domain is not verified, which is when they are
 	// returned.
-	if customDomainInfo.TxtRecordKey != nil {
-		state.TxtRecordKey = types.StringPointerValue(customDomainInfo.TxtRecordKey)
+	resp.Diagnostics.Append(updateCustomDomainRecords(&state, customDomainInfo)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
 
-		txtRecordKeyParts := strings.Split(*customDomainInfo.TxtRecordKey, ".")
-		txtRecordKeyWithoutDomain := strings.Join(txtRecordKeyParts[:len(txtRecordKeyParts)-2], ".")
-		state.TxtRecordKeyWithoutDomain = types.StringValue(txtRecordKeyWithoutDomain)
+// isCustomDomainSwitch reports whether a domain should be staged as a switch away from the environment's
+// current custom domain, described by currentDomainInfo. That's the case once the current domain is verified
+// and differs from the requested one, so the current domain keeps serving until the new one is verified.
+func isCustomDomainSwitch(currentDomainInfo *propelauth.CustomDomainInfoResponse, domain string, subdomain *string) bool {
+	return currentDomainInfo.IsVerified && !customDomainMatches(currentDomainInfo, domain, subdomain)
+}
+
+// customDomainMatches reports whether the custom domain info is for the given domain and subdomain.
+func customDomainMatches(customDomainInfo *propelauth.CustomDomainInfoResponse, domain string, subdomain *string) bool {
+	isSameSubdomain := (subdomain == nil && customDomainInfo.Subdomain == nil) ||
+		(subdomain != nil && customDomainInfo.Subdomain != nil && *subdomain == *customDomainInfo.Subdomain)
+	return customDomainInfo.Domain == domain && isSameSubdomain
+}
+
+// updateCustomDomainRecords sets the DNS record attributes from the custom domain info. Record names relative
+// to the zone are derived with the model's `dns_zone`. Records missing from the info keep the model's values
+// if they're known, and are null otherwise.
+func updateCustomDomainRecords(model *customDomainResourceModel, customDomainInfo *propelauth.CustomDomainInfoResponse) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	dnsZone := model.DnsZone.ValueString()
+	if customDomainInfo.TxtRecordKey != nil {
+		txtRecordKeyWithoutDomain, err := propelauth.RelativeRecordName(*customDomainInfo.TxtRecordKey, dnsZone)
+		if err != nil {
+			diags.AddAttributeError(path.Root("dns_zone"), "Invalid DNS zone", "Could not derive the TXT record name: "+err.Error())
+			return diags
+		}
+		model.TxtRecordKey = types.StringPointerValue(customDomainInfo.TxtRecordKey)
+		model.TxtRecordKeyWithoutDomain = types.StringValue(txtRecordKeyWithoutDomain)
 	}
 	if customDomainInfo.TxtRecordValue != nil {
-		state.TxtRecordValue = types.StringPointerValue(customDomainInfo.TxtRecordValue)
+		model.TxtRecordValue = types.StringPointerValue(customDomainInfo.TxtRecordValue)
 	}
 	if customDomainInfo.CnameRecordKey != nil {
-		state.CnameRecordKey = types.StringPointerValue(customDomainInfo.CnameRecordKey)
-
-		cnameRecordKeyParts := strings.Split(*customDomainInfo.CnameRecordKey, ".")
-		cnameRecordKeyWithoutDomain := strings.Join(cnameRecordKeyParts[:len(cnameRecordKeyParts)-2], ".")
-		state.CnameRecordKeyWithoutDomain = types.StringValue(cnameRecordKeyWithoutDomain)
+		cnameRecordKeyWithoutDomain, err := propelauth.RelativeRecordName(*customDomainInfo.CnameRecordKey, dnsZone)
+		if err != nil {
+			diags.AddAttributeError(path.Root("dns_zone"), "Invalid DNS zone", "Could not derive the CNAME record name: "+err.Error())
+			return diags
+		}
+		model.CnameRecordKey = types.StringPointerValue(customDomainInfo.CnameRecordKey)
+		model.CnameRecordKeyWithoutDomain = types.StringValue(cnameRecordKeyWithoutDomain)
 	}
 	if customDomainInfo.CnameRecordValue != nil {
-		state.CnameRecordValue = types.StringPointerValue(customDomainInfo.CnameRecordValue)
+		model.CnameRecordValue = types.StringPointerValue(customDomainInfo.CnameRecordValue)
 	}
 
-	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	for _, value := range []*types.String{
+		&model.TxtRecordKey, &model.TxtRecordKeyWithoutDomain, &model.TxtRecordValue,
+		&model.CnameRecordKey, &model.CnameRecordKeyWithoutDomain, &model.CnameRecordValue,
+	} {
+		if value.IsUnknown() {
+			*value = types.StringNull()
+		}
+	}
+
+	// both records are always listed, as planned, with a null name and value if the API doesn't return them
+	records := []attr.Value{
+		types.ObjectValueMust(customDomainDnsRecordAttrTypes, map[string]attr.Value{
+			"type":          types.StringValue("TXT"),
+			"name":          model.TxtRecordKey,
+			"relative_name": model.TxtRecordKeyWithoutDomain,
+			"value":         model.TxtRecordValue,
+			"ttl":           types.Int64Value(customDomainDnsRecordTtl),
+		}),
+		types.ObjectValueMust(customDomainDnsRecordAttrTypes, map[string]attr.Value{
+			"type":          types.StringValue("CNAME"),
+			"name":          model.CnameRecordKey,
+			"relative_name": model.CnameRecordKeyWithoutDomain,
+			"value":         model.CnameRecordValue,
+			"ttl":           types.Int64Value(customDomainDnsRecordTtl),
+		}),
+	}
+	dnsRecords, listDiags := types.ListValue(types.ObjectType{AttrTypes: customDomainDnsRecordAttrTypes}, records)
+	diags.Append(listDiags...)
+	model.DnsRecords = dnsRecords
+
+	return diags
+}
+
+var _ planmodifier.List = dnsRecordsPlanModifier{}
+
+// dnsRecordsPlanModifier plans DNS records with the attributes that are known ahead of their names and values,
+// such as their types, so a `for_each` over the records can be keyed by them before the records are created.
+// Once created, the records only change along with the domain, and the API stops returning them when the
+// domain is verified, so otherwise the records in state are kept.
+type dnsRecordsPlanModifier struct {
+	attrTypes map[string]attr.Type
+	// plannedRecords are the attributes of each record before it's created, with the unknown ones set to unknown.
+	plannedRecords []map[string]attr.Value
+	// domainPaths are the attributes that new records are created for when they change.
+	domainPaths []path.Path
+}
+
+func (m dnsRecordsPlanModifier) Description(ctx context.Context) string {
+	return "The record types are known before the records are created, and the records are kept until the domain changes."
+}
+
+func (m dnsRecordsPlanModifier) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+func (m dnsRecordsPlanModifier) PlanModifyList(ctx context.Context, req planmodifier.ListRequest, resp *planmodifier.ListResponse) {
+	// Do nothing on resource destroy or if the records are already known
+	if req.Plan.Raw.IsNull() || !req.PlanValue.IsUnknown() {
+		return
+	}
+
+	if !req.State.Raw.IsNull() {
+		isDomainChanged, diags := attributesChanged(ctx, req.Plan, req.State, m.domainPaths)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if !isDomainChanged {
+			// The records in state stay valid, but are left unknown if their relative names change with the zone
+			isZoneChanged, diags := attributesChanged(ctx, req.Plan, req.State, []path.Path{path.Root("dns_zone")})
+			resp.Diagnostics.Append(diags...)
+			if !resp.Diagnostics.HasError() && !isZoneChanged {
+				resp.PlanValue = req.StateValue
+			}
+			return
+		}
+	}
+
+	records := []attr.Value{}
+	for _, plannedRecord := range m.plannedRecords {
+		record, diags := types.ObjectValue(m.attrTypes, plannedRecord)
+		resp.Diagnostics.Append(diags...)
+		records = append(records, record)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plannedRecords, diags := types.ListValue(types.ObjectType{AttrTypes: m.attrTypes}, records)
+	resp.Diagnostics.Append(diags...)
+	resp.PlanValue = plannedRecords
+}
+
+var _ planmodifier.Bool = useStateForUnknownUnlessChangedModifier{}
+var _ planmodifier.String = useStateForUnknownUnlessChangedModifier{}
+
+// useStateForUnknownUnlessChangedModifier keeps the value in state unless one of the string attributes at the paths
+// changes, in which case the value is left unknown to be computed again.
+type useStateForUnknownUnlessChangedModifier struct {
+	paths []path.Path
+}
+
+func (m useStateForUnknownUnlessChangedModifier) Description(ctx context.Context) string {
+	return "The value in state is kept unless the attributes it's computed from change."
+}
+
+func (m useStateForUnknownUnlessChangedModifier) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+func (m useStateForUnknownUnlessChangedModifier) PlanModifyBool(ctx context.Context, req planmodifier.BoolRequest, resp *planmodifier.BoolResponse) {
+	// Do nothing on resource creation or destroy, or if the value is already known
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() || !req.PlanValue.IsUnknown() {
+		return
+	}
+
+	isChanged, diags := attributesChanged(ctx, req.Plan, req.State, m.paths)
+	resp.Diagnostics.Append(diags...)
+	if !resp.Diagnostics.HasError() && !isChanged {
+		resp.PlanValue = req.StateValue
+	}
+}
+
+func (m useStateForUnknownUnlessChangedModifier) PlanModifyString(ctx context.Context, req planmodifier.StringRequest, resp *planmodifier.StringResponse) {
+	// Do nothing on resource creation or destroy, or if the value is already known
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() || !req.PlanValue.IsUnknown() {
+		return
+	}
+
+	isChanged, diags := attributesChanged(ctx, req.Plan, req.State, m.paths)
+	resp.Diagnostics.Append(diags...)
+	if !resp.Diagnostics.HasError() && !isChanged {
+		resp.PlanValue = req.StateValue
+	}
+}
+
+// attributesChanged reports whether any of the string attributes at the paths differ between the plan and state.
+func attributesChanged(ctx context.Context, plan tfsdk.Plan, state tfsdk.State, paths []path.Path) (bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	for _, attributePath := range paths {
+		var planned, prior types.String
+		diags.Append(plan.GetAttribute(ctx, attributePath, &planned)...)
+		diags.Append(state.GetAttribute(ctx, attributePath, &prior)...)
+		if diags.HasError() {
+			return false, diags
+		}
+		if !planned.Equal(prior) {
+			return true, diags
+		}
+	}
+	return false, diags
 }
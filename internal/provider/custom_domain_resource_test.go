@@ -23,6 +23,11 @@ func TestAccCustomDomainResource(t *testing.T) {
 					resource.TestCheckResourceAttrSet("propelauth_custom_domain.test", "cname_record_key"),
 					resource.TestCheckResourceAttrSet("propelauth_custom_domain.test", "cname_record_value"),
 					resource.TestCheckNoResourceAttr("propelauth_custom_domain.test", "subdomain"),
+					resource.TestCheckResourceAttr("propelauth_custom_domain.test", "is_switching", "false"),
+					resource.TestCheckResourceAttr("propelauth_custom_domain.test", "dns_records.#", "2"),
+					resource.TestCheckResourceAttr("propelauth_custom_domain.test", "dns_records.0.type", "TXT"),
+					resource.TestCheckResourceAttr("propelauth_custom_domain.test", "dns_records.1.type", "CNAME"),
+					resource.TestCheckResourceAttr("propelauth_custom_domain.test", "cname_record_key_without_domain", "auth"),
 				),
 			},
 			// Update and Read testing
@@ -50,6 +55,15 @@ func TestAccCustomDomainResource(t *testing.T) {
 					resource.TestCheckResourceAttrSet("propelauth_custom_domain.test", "cname_record_value"),
 				),
 			},
+			// Record names with a multi-label public suffix
+			{
+				Config: testAccCustomDomainResourceConfig("example.co.uk", nil, "Prod"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("propelauth_custom_domain.test", "domain", "example.co.uk"),
+					resource.TestCheckResourceAttr("propelauth_custom_domain.test", "cname_record_key_without_domain", "auth"),
+					resource.TestCheckResourceAttr("propelauth_custom_domain.test", "dns_records.1.relative_name", "auth"),
+				),
+			},
 			// Delete testing automatically occurs in TestCase
 		},
 	})
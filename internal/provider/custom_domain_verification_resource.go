@@ -3,12 +3,15 @@ package provider
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"terraform-provider-propelauth/internal/propelauth"
 
 	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -35,11 +38,19 @@ type customDomainVerificationResource struct {
 
 // projectInfoResourceModel describes the resource data model.
 type customDomainVerificationResourceModel struct {
-	Environment types.String   `tfsdk:"environment"`
-	Domain      types.String   `tfsdk:"domain"`
-	Timeouts    timeouts.Value `tfsdk:"timeouts"`
+	Environment  types.String   `tfsdk:"environment"`
+	Domain       types.String   `tfsdk:"domain"`
+	DnsResolvers types.List     `tfsdk:"dns_resolvers"`
+	Timeouts     timeouts.Value `tfsdk:"timeouts"`
 }
 
+const (
+	// initialVerificationRetryInterval is the wait after the first failed attempt, doubling up to
+	// maxVerificationRetryInterval after each further one.
+	initialVerificationRetryInterval = 5 * time.Second
+	maxVerificationRetryInterval     = 60 * time.Second
+)
+
 func (r *customDomainVerificationResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_custom_domain_verification"
 }
@@ -47,7 +58,8 @@ func (r *customDomainVerificationResource) Metadata(ctx context.Context, req res
 func (r *customDomainVerificationResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		// This description is used by the documentation generator and the language server.
-		Description: "Custom Domain Verification resource. This is for verifying a custom domain for Production or Staging.",
+		Description: "Custom Domain Verification resource. This is for verifying a custom domain for Production or Staging. " +
+			"If the environment is switching away from a verified custom domain, verifying the new domain also cuts over to it.",
 		Attributes: map[string]schema.Attribute{
 			"environment": schema.StringAttribute{
 				Required: true,
@@ -63,6 +75,16 @@ func (r *customDomainVerificationResource) Schema(ctx context.Context, req resou
 				Required:    true,
 				Description: "The domain to verify.",
 			},
+			"dns_resolvers": schema.ListAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Validators: []validator.List{
+					listvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
+				},
+				Description: "The DNS resolvers, as `host` or `host:port`, used to check the TXT and CNAME records before " +
+					"asking PropelAuth to verify the domain. Each resolver must return the expected records. " +
+					"If not set, the resolvers in `/etc/resolv.conf` are used. Set to an empty list to skip the check.",
+			},
 			"timeouts": timeouts.Attributes(ctx, timeouts.Opts{
 				Create: true,
 				Update: true,
@@ -110,33 +132,14 @@ func (r *customDomainVerificationResource) Create(ctx context.Context, req resou
 	ctx, cancel := context.WithTimeout(ctx, createTimeout)
 	defer cancel()
 
-	// Retry interval
-	retryInterval := 30 * time.Second
-
-	// Verify the custom domain with retries
-	environment := plan.Environment.ValueString()
-	for {
-		select {
-		case <-ctx.Done():
-			resp.Diagnostics.AddError("Timeout exceeded", "Could not verify custom domain within the timeout. It can take a few minutes for the DNS records to propagate, please verify the records are set and try again.")
-			return
-		default:
-			verificationErr := r.client.VerifyCustomDomainInfo(environment, false)
-			if verificationErr == nil {
-				// Verification successful
-				// Set the data from the state into the response
-				resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
-				return
-			}
-
-			// Log the retry attempt
-			tflog.Warn(ctx, "Unable to verify the custom domain. It can take a few minutes for the DNS records to propagate. Retrying in 30 seconds...")
-
-			// Wait for the retry interval before the next attempt
-			time.Sleep(retryInterval)
-		}
+	resp.Diagnostics.Append(r.verify(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
+	// Verification successful
+	// Set the data from the state into the response
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
 }
 
 func (r *customDomainVerificationResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -164,12 +167,6 @@ func (r *customDomainVerificationResource) Update(ctx context.Context, req resou
 		return
 	}
 
-	var state customDomainVerificationResourceModel
-	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
-	if resp.Diagnostics.HasError() {
-		return
-	}
-
 	updateTimeout, err := plan.Timeouts.Update(ctx, 5*time.Minute)
 	if err != nil {
 		resp.Diagnostics.AddError("Error creating a timeout", "Could not create a timeout for the custom domain verification.")
@@ -179,33 +176,14 @@ func (r *customDomainVerificationResource) Update(ctx context.Context, req resou
 	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
 	defer cancel()
 
-	// Retry interval
-	retryInterval := 30 * time.Second
-
-	// Verify the custom domain with retries
-	environment := plan.Environment.ValueString()
-	isSwitching := plan.Domain.ValueString() != state.Domain.ValueString()
-	for {
-		select {
-		case <-ctx.Done():
-			resp.Diagnostics.AddError("Timeout exceeded", "Could not verify custom domain within the timeout. It can take a few minutes for the DNS records to propagate, please verify the records are set and try again.")
-			return
-		default:
-			verificationErr := r.client.VerifyCustomDomainInfo(environment, isSwitching)
-			if verificationErr == nil {
-				// Verification successful
-				// Set the data from the state into the response
-				resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
-				return
-			}
-
-			// Log the retry attempt
-			tflog.Warn(ctx, "Unable to verify the custom domain. It can take a few minutes for the DNS records to propagate. Retrying in 30 seconds...")
-
-			// Wait for the retry interval before the next attempt
-			time.Sleep(retryInterval)
-		}
+	resp.Diagnostics.Append(r.verify(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
+
+	// Verification successful
+	// Set the data from the state into the response
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
 }
 
 func (r *customDomainVerificationResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -229,6 +207,7 @@ func (r *customDomainVerificationResource) ImportState(ctx context.Context, req
 
 	state.Environment = types.StringValue(environment)
 	state.Domain = types.StringValue(customDomainInfo.Domain)
+	state.DnsResolvers = types.ListNull(types.StringType)
 	// need to manually pull timeouts from hcl to pass validation
 	var timeouts timeouts.Value
 	resp.Diagnostics.Append(resp.State.GetAttribute(ctx, path.Root("timeouts"), &timeouts)...)
@@ -240,3 +219,138 @@ func (r *customDomainVerificationResource) ImportState(ctx context.Context, req
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 }
+
+// isSwitching reports whether verifying the domain cuts over from the environment's currently verified
+// custom domain to a pending one, rather than verifying the environment's only custom domain.
+func (r *customDomainVerificationResource) isSwitching(environment string, domain string) (bool, error) {
+	customDomainInfo, err := r.client.GetCustomDomainInfo(environment, false)
+	if err != nil {
+		return false, err
+	}
+
+	return customDomainInfo.IsVerified && (customDomainInfo.IsPending || customDomainInfo.Domain != domain), nil
+}
+
+// verify asks PropelAuth to verify the custom domain until it succeeds or the context is done. Each attempt
+// first checks the DNS records through the configured resolvers, so PropelAuth is only asked once they are
+// set, and a timeout can report which records are missing or wrong.
+func (r *customDomainVerificationResource) verify(ctx context.Context, plan *customDomainVerificationResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	environment := plan.Environment.ValueString()
+	isSwitching, err := r.isSwitching(environment, plan.Domain.ValueString())
+	if err != nil {
+		diags.AddError(
+			"Error getting custom domain info",
+			"Could not get custom domain info, unexpected error: "+err.Error(),
+		)
+		return diags
+	}
+
+	resolvers := propelauth.SystemDnsResolvers()
+	if !plan.DnsResolvers.IsNull() {
+		resolvers = []string{}
+		diags.Append(plan.DnsResolvers.ElementsAs(ctx, &resolvers, false)...)
+		if diags.HasError() {
+			return diags
+		}
+	}
+
+	return pollVerification(ctx, "custom domain", func() (string, diag.Diagnostics) {
+		var diags diag.Diagnostics
+
+		failedChecks, err := r.checkDnsRecords(ctx, environment, isSwitching, resolvers)
+		if err != nil {
+			diags.AddError(
+				"Error getting custom domain info",
+				"Could not get custom domain info, unexpected error: "+err.Error(),
+			)
+			return "", diags
+		}
+		if len(failedChecks) > 0 {
+			return strings.Join(failedChecks, "\n"), diags
+		}
+
+		verificationErr := r.client.VerifyCustomDomainInfo(environment, isSwitching)
+		if verificationErr != nil {
+			return "The DNS records are set, but PropelAuth could not verify the domain: " + verificationErr.Error(), diags
+		}
+		return "", diags
+	})
+}
+
+// checkDnsRecords looks up the custom domain's records through the resolvers and describes each one that is
+// missing or doesn't match. Records that can't be looked up are logged and otherwise left for PropelAuth to check.
+func (r *customDomainVerificationResource) checkDnsRecords(ctx context.Context, environment string, isSwitching bool, resolvers []string) ([]string, error) {
+	if len(resolvers) == 0 {
+		return nil, nil
+	}
+
+	customDomainInfo, err := r.client.GetCustomDomainInfo(environment, isSwitching)
+	if err != nil {
+		return nil, err
+	}
+
+	// the records are only returned until the domain is verified
+	return checkExpectedDnsRecords(ctx, resolvers, []expectedDnsRecord{
+		{"TXT", customDomainInfo.TxtRecordKey, customDomainInfo.TxtRecordValue},
+		{"CNAME", customDomainInfo.CnameRecordKey, customDomainInfo.CnameRecordValue},
+	}), nil
+}
+
+// expectedDnsRecord is a DNS record that needs to be set before PropelAuth can verify a domain. Its name and
+// value are nil once PropelAuth no longer returns them.
+type expectedDnsRecord struct {
+	recordType string
+	name       *string
+	value      *string
+}
+
+// checkExpectedDnsRecords looks up the records through the resolvers and describes each one that is missing or
+// doesn't match. Records that can't be looked up are logged and otherwise left for PropelAuth to check.
+func checkExpectedDnsRecords(ctx context.Context, resolvers []string, expectedRecords []expectedDnsRecord) []string {
+	failedChecks := []string{}
+	for _, expectedRecord := range expectedRecords {
+		if expectedRecord.name == nil || expectedRecord.value == nil {
+			continue
+		}
+
+		check := propelauth.CheckDnsRecord(ctx, resolvers, expectedRecord.recordType, *expectedRecord.name, *expectedRecord.value)
+		if check.Err != nil {
+			tflog.Warn(ctx, check.String())
+			continue
+		}
+		if !check.IsMatch() {
+			failedChecks = append(failedChecks, check.String())
+		}
+	}
+	return failedChecks
+}
+
+// pollVerification makes verification attempts until one succeeds or the context is done. An attempt returns
+// why it failed, or an empty string if it succeeded. The subject, e.g. "custom domain", is what is being verified.
+func pollVerification(ctx context.Context, subject string, attempt func() (string, diag.Diagnostics)) diag.Diagnostics {
+	retryInterval := initialVerificationRetryInterval
+	for {
+		failureDetail, diags := attempt()
+		if diags.HasError() || failureDetail == "" {
+			return diags
+		}
+
+		tflog.Warn(ctx, fmt.Sprintf("Unable to verify the %s. It can take a few minutes for the DNS records to propagate. Retrying in %s...", subject, retryInterval),
+			map[string]interface{}{"detail": failureDetail})
+
+		select {
+		case <-ctx.Done():
+			diags.AddError(
+				"Timeout exceeded",
+				fmt.Sprintf("Could not verify %s within the timeout. It can take a few minutes for the DNS records to propagate, ", subject)+
+					"please verify the records are set and try again.\n\n"+failureDetail,
+			)
+			return diags
+		case <-time.After(retryInterval):
+		}
+
+		retryInterval = min(retryInterval*2, maxVerificationRetryInterval)
+	}
+}
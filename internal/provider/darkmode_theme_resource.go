@@ -3,7 +3,6 @@ package provider
 import (
 	"context"
 	"fmt"
-	"regexp"
 
 	"terraform-provider-propelauth/internal/propelauth"
 
@@ -15,6 +14,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int32default"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
@@ -22,6 +22,7 @@ import (
 var _ resource.Resource = &darkmodeThemeResource{}
 var _ resource.ResourceWithConfigure = &darkmodeThemeResource{}
 var _ resource.ResourceWithValidateConfig = &darkmodeThemeResource{}
+var _ resource.ResourceWithModifyPlan = &darkmodeThemeResource{}
 var _ resource.ResourceWithImportState = &darkmodeThemeResource{}
 
 func NewDarkmodeThemeResource() resource.Resource {
@@ -38,32 +39,31 @@ func (r *darkmodeThemeResource) Metadata(ctx context.Context, req resource.Metad
 }
 
 func (r *darkmodeThemeResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
-	hexcode_regex := regexp.MustCompile(`^#(?:[0-9a-f]{3}){1,2}$`)
-
 	resp.Schema = schema.Schema{
 		Description: "Darkmode Pages Look & Feel. This is for creating a darkmode theme for your PropelAuth hosted pages." +
 			"The parameters and behavior are identical to the `propelauth_theme` resource, except this enables an optional darkmode " +
-			"version for your users to toggle to. Altering these settings does not affect the primary theme.",
+			"version for your users to toggle to. Altering these settings does not affect the primary theme. " +
+			"Colors can be written as hex codes (`#fff`, `#ffffff` or `#ffffffff`), `rgb()`, `hsl()` or CSS named colors. " +
+			"The configured spelling is kept as long as PropelAuth returns the same color, and any alpha channel is ignored.",
 		Attributes: map[string]schema.Attribute{
 			"header_font": schema.StringAttribute{
 				Optional: true,
 				Computed: true,
 				Default:  stringdefault.StaticString("Inter"),
 				Validators: []validator.String{
-					stringvalidator.OneOf(
-						"Roboto", "Inter", "OpenSans", "Montserrat", "Lato", "Poppins", "Raleway", "Jost",
-						"Fraunces", "Caveat", "PlusJakartaSans",
-					),
+					fontValidator{},
 				},
 				Description: "The font used for all headings in your hosted pages written in PascalCase. This includes both login and management pages. " +
-					"Options include `Roboto`, `Inter`, `OpenSans`, `Montserrat`, `Lato`, `Poppins`, `Raleway`, `Jost`, " +
-					"`Fraunces`, `Caveat`, `PlusJakartaSans`, etc" +
-					"The default value is `Inter`",
+					"Options are `Roboto`, `Inter`, `OpenSans`, `Montserrat`, `Lato`, `Poppins`, `Raleway`, `Jost`, " +
+					"`Fraunces`, `Caveat` and `PlusJakartaSans`. The default value is `Inter`",
 			},
 			"body_font": schema.StringAttribute{
 				Optional: true,
 				Computed: true,
 				Default:  stringdefault.StaticString("Inter"),
+				Validators: []validator.String{
+					fontValidator{},
+				},
 				Description: "The font used for all body text in your hosted pages. This includes both login and management pages. " +
 					"The available options are the same as for `header_font`. The default value is `Inter`",
 			},
@@ -74,6 +74,14 @@ func (r *darkmodeThemeResource) Schema(ctx context.Context, req resource.SchemaR
 				Description: "If true, the project name is displayed in the header of the login page. " +
 					"The default value is `true`",
 			},
+			"strict_accessibility": schema.BoolAttribute{
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+				Description: "If true, text and background color pairs that fall below the WCAG AA contrast ratio of 4.5:1 " +
+					"are reported as errors instead of warnings. Pairs where neither color is set are PropelAuth's defaults and aren't checked. " +
+					"The default value is `false`",
+			},
 			"login_page_theme": schema.SingleNestedAttribute{
 				Description: "The theme for the login page",
 				Required:    true,
@@ -103,21 +111,17 @@ func (r *darkmodeThemeResource) Schema(ctx context.Context, req resource.SchemaR
 						Description: "The parameters required for a solid background in the login page",
 						Attributes: map[string]schema.Attribute{
 							"background_color": schema.StringAttribute{
-								Optional: true,
-								Computed: true,
-								Default:  stringdefault.StaticString("#f7f7f7"),
-								Validators: []validator.String{
-									stringvalidator.RegexMatches(hexcode_regex, "background_color must be a valid hex color code with lowercase characters"),
-								},
+								Optional:    true,
+								Computed:    true,
+								Default:     stringdefault.StaticString("#f7f7f7"),
+								CustomType:  colorType{},
 								Description: "The color of a solid background in the login page. The default value is `#f7f7f7`",
 							},
 							"background_text_color": schema.StringAttribute{
-								Optional: true,
-								Computed: true,
-								Default:  stringdefault.StaticString("#363636"),
-								Validators: []validator.String{
-									stringvalidator.RegexMatches(hexcode_regex, "background_text_color must be a valid hex color code with lowercase characters"),
-								},
+								Optional:    true,
+								Computed:    true,
+								Default:     stringdefault.StaticString("#363636"),
+								CustomType:  colorType{},
 								Description: "The color of the text on a solid background in the login page. The default value is `#363636`",
 							},
 						},
@@ -127,21 +131,17 @@ func (r *darkmodeThemeResource) Schema(ctx context.Context, req resource.SchemaR
 						Description: "The parameters required for a gradient background in the login page",
 						Attributes: map[string]schema.Attribute{
 							"background_gradient_start_color": schema.StringAttribute{
-								Optional: true,
-								Computed: true,
-								Default:  stringdefault.StaticString("#f7f7f7"),
-								Validators: []validator.String{
-									stringvalidator.RegexMatches(hexcode_regex, "background_gradient_start_color must be a valid hex color code with lowercase characters"),
-								},
+								Optional:    true,
+								Computed:    true,
+								Default:     stringdefault.StaticString("#f7f7f7"),
+								CustomType:  colorType{},
 								Description: "The start color of a gradient background in the login page. The default value is `#f7f7f7`",
 							},
 							"background_gradient_end_color": schema.StringAttribute{
-								Optional: true,
-								Computed: true,
-								Default:  stringdefault.StaticString("#f7f7f7"),
-								Validators: []validator.String{
-									stringvalidator.RegexMatches(hexcode_regex, "background_gradient_end_color must be a valid hex color code with lowercase characters"),
-								},
+								Optional:    true,
+								Computed:    true,
+								Default:     stringdefault.StaticString("#f7f7f7"),
+								CustomType:  colorType{},
 								Description: "The end color of a gradient background in the login page. The default value is `#f7f7f7`",
 							},
 							"background_gradient_angle": schema.Int32Attribute{
@@ -151,12 +151,10 @@ func (r *darkmodeThemeResource) Schema(ctx context.Context, req resource.SchemaR
 								Description: "The angle of the gradient background in the login page. The default value is `135`",
 							},
 							"background_text_color": schema.StringAttribute{
-								Optional: true,
-								Computed: true,
-								Default:  stringdefault.StaticString("#363636"),
-								Validators: []validator.String{
-									stringvalidator.RegexMatches(hexcode_regex, "background_text_color must be a valid hex color code with lowercase characters"),
-								},
+								Optional:    true,
+								Computed:    true,
+								Default:     stringdefault.StaticString("#363636"),
+								CustomType:  colorType{},
 								Description: "The color of the text on a gradient background in the login page. The default value is `#363636`",
 							},
 						},
@@ -166,91 +164,84 @@ func (r *darkmodeThemeResource) Schema(ctx context.Context, req resource.SchemaR
 						Description: "The parameters required for an image background in the login page",
 						Attributes: map[string]schema.Attribute{
 							"default_background_color": schema.StringAttribute{
-								Optional: true,
-								Computed: true,
-								Default:  stringdefault.StaticString("#f7f7f7"),
-								Validators: []validator.String{
-									stringvalidator.RegexMatches(hexcode_regex, "default_background_color must be a valid hex color code with lowercase characters"),
-								},
+								Optional:    true,
+								Computed:    true,
+								Default:     stringdefault.StaticString("#f7f7f7"),
+								CustomType:  colorType{},
 								Description: "The default color behind the background image in the login page. The default value is `#f7f7f7`",
 							},
 							"background_text_color": schema.StringAttribute{
+								Optional:    true,
+								Computed:    true,
+								Default:     stringdefault.StaticString("#363636"),
+								CustomType:  colorType{},
+								Description: "The color of the text on an image background in the login page. The default value is `#363636`",
+							},
+							"background_image_id": schema.StringAttribute{
 								Optional: true,
-								Computed: true,
-								Default:  stringdefault.StaticString("#363636"),
 								Validators: []validator.String{
-									stringvalidator.RegexMatches(hexcode_regex, "background_text_color must be a valid hex color code with lowercase characters"),
+									stringvalidator.LengthAtLeast(1),
 								},
-								Description: "The color of the text on an image background in the login page. The default value is `#363636`",
+								Description: "The `image_id` of a `propelauth_image` with `image_type` `darkmode_background` to display as the background. " +
+									"Referencing the image here makes Terraform upload it before applying the theme. " +
+									"Once the image is uploaded, planning fails if PropelAuth has no background image at all. " +
+									"PropelAuth doesn't return which image it has, so a reference to another image isn't detected. " +
+									"If not set, the most recently uploaded background image is used",
 							},
 						},
 					},
 					"frame_background_color": schema.StringAttribute{
-						Optional: true,
-						Computed: true,
-						Default:  stringdefault.StaticString("#ffffff"),
-						Validators: []validator.String{
-							stringvalidator.RegexMatches(hexcode_regex, "frame_background_color must be a valid hex color code with lowercase characters"),
-						},
+						Optional:   true,
+						Computed:   true,
+						Default:    stringdefault.StaticString("#ffffff"),
+						CustomType: colorType{},
 						Description: "The background color within the frame in the login page. If the the `layout` is `Frameless`, " +
 							"this color is applied to the background of the input components on the page. The default value is `#ffffff`",
 					},
 					"frame_text_color": schema.StringAttribute{
-						Optional: true,
-						Computed: true,
-						Default:  stringdefault.StaticString("#0f0f0f"),
-						Validators: []validator.String{
-							stringvalidator.RegexMatches(hexcode_regex, "frame_text_color must be a valid hex color code with lowercase characters"),
-						},
+						Optional:   true,
+						Computed:   true,
+						Default:    stringdefault.StaticString("#0f0f0f"),
+						CustomType: colorType{},
 						Description: "The color of the text within the frame in the login page.  If the the `layout` is `Frameless`, " +
 							"this color is applied to text within input components on the page. The default value is `#0f0f0f`",
 					},
 					"primary_color": schema.StringAttribute{
-						Optional: true,
-						Computed: true,
-						Default:  stringdefault.StaticString("#50c878"),
-						Validators: []validator.String{
-							stringvalidator.RegexMatches(hexcode_regex, "primary_color must be a valid hex color code with lowercase characters"),
-						},
+						Optional:   true,
+						Computed:   true,
+						Default:    stringdefault.StaticString("#50c878"),
+						CustomType: colorType{},
 						Description: "The primary color of action buttons and links in the login page. " +
 							"The default value is `#50c878`",
 					},
 					"primary_text_color": schema.StringAttribute{
-						Optional: true,
-						Computed: true,
-						Default:  stringdefault.StaticString("#f7f7f7"),
-						Validators: []validator.String{
-							stringvalidator.RegexMatches(hexcode_regex, "primary_text_color must be a valid hex color code with lowercase characters"),
-						},
+						Optional:    true,
+						Computed:    true,
+						Default:     stringdefault.StaticString("#f7f7f7"),
+						CustomType:  colorType{},
 						Description: "The color of the text on action buttons in the login page. The default value is `#f7f7f7`",
 					},
 					"error_color": schema.StringAttribute{
-						Optional: true,
-						Computed: true,
-						Default:  stringdefault.StaticString("#cf222e"),
-						Validators: []validator.String{
-							stringvalidator.RegexMatches(hexcode_regex, "error_color must be a valid hex color code with lowercase characters"),
-						},
+						Optional:   true,
+						Computed:   true,
+						Default:    stringdefault.StaticString("#cf222e"),
+						CustomType: colorType{},
 						Description: "The color for error messages and cancel button in the login page. " +
 							"The default value is `#cf222e`",
 					},
 					"error_button_text_color": schema.StringAttribute{
-						Optional: true,
-						Computed: true,
-						Default:  stringdefault.StaticString("#ffffff"),
-						Validators: []validator.String{
-							stringvalidator.RegexMatches(hexcode_regex, "error_button_text_color must be a valid hex color code with lowercase characters"),
-						},
+						Optional:   true,
+						Computed:   true,
+						Default:    stringdefault.StaticString("#ffffff"),
+						CustomType: colorType{},
 						Description: "The color of the text on error messages and cancel button in the login page. " +
 							"The default value is `#ffffff`",
 					},
 					"border_color": schema.StringAttribute{
-						Optional: true,
-						Computed: true,
-						Default:  stringdefault.StaticString("#e4e4e4"),
-						Validators: []validator.String{
-							stringvalidator.RegexMatches(hexcode_regex, "border_color must be a valid hex color code with lowercase characters"),
-						},
+						Optional:    true,
+						Computed:    true,
+						Default:     stringdefault.StaticString("#e4e4e4"),
+						CustomType:  colorType{},
 						Description: "The color of the borders in the login page. The default value is `#e4e4e4`",
 					},
 					"split_login_page_parameters": schema.SingleNestedAttribute{
@@ -272,10 +263,21 @@ func (r *darkmodeThemeResource) Schema(ctx context.Context, req resource.SchemaR
 								Computed: true,
 								Default:  stringdefault.StaticString("None"),
 								Validators: []validator.String{
-									stringvalidator.OneOf("None", "Text"),
+									stringvalidator.OneOf("None", "Text", "Image"),
 								},
 								Description: "The type of content displayed on the side of the screen opposite the login components. " +
-									"Currently, options include `None` and `Text`. The default value is `None`",
+									"Options include `None`, `Text` and `Image`. `Image` displays the image referenced by `background_image_id`. " +
+									"The default value is `None`",
+							},
+							"background_image_id": schema.StringAttribute{
+								Optional: true,
+								Validators: []validator.String{
+									stringvalidator.LengthAtLeast(1),
+								},
+								Description: "The `image_id` of a `propelauth_image` with `image_type` `darkmode_background` to display on the side of the screen " +
+									"opposite the login components. This is required if `content_type` is `Image`, and can't be set otherwise. " +
+									"PropelAuth has one background image per theme, so if `image_background_parameters` also sets one, " +
+									"it must be the same image. The same plan-time check as for `image_background_parameters` applies",
 							},
 							"header": schema.StringAttribute{
 								Optional: true,
@@ -292,12 +294,10 @@ func (r *darkmodeThemeResource) Schema(ctx context.Context, req resource.SchemaR
 									"This is only displayed if `content_type` is `Text`",
 							},
 							"secondary_background_text_color": schema.StringAttribute{
-								Optional: true,
-								Computed: true,
-								Default:  stringdefault.StaticString("#363636"),
-								Validators: []validator.String{
-									stringvalidator.RegexMatches(hexcode_regex, "secondary_background_text_color must be a valid hex color code with lowercase characters"),
-								},
+								Optional:   true,
+								Computed:   true,
+								Default:    stringdefault.StaticString("#363636"),
+								CustomType: colorType{},
 								Description: "The color of the subheader on the side of the screen opposite the login components. " +
 									"The header text in the same area uses the `background_text_color`. " +
 									"The default value is `#363636`",
@@ -311,66 +311,52 @@ func (r *darkmodeThemeResource) Schema(ctx context.Context, req resource.SchemaR
 				Required:    true,
 				Attributes: map[string]schema.Attribute{
 					"main_background_color": schema.StringAttribute{
-						Optional: true,
-						Computed: true,
-						Default:  stringdefault.StaticString("#f7f7f7"),
-						Validators: []validator.String{
-							stringvalidator.RegexMatches(hexcode_regex, "main_background_color must be a valid hex color code with lowercase characters"),
-						},
+						Optional:    true,
+						Computed:    true,
+						Default:     stringdefault.StaticString("#f7f7f7"),
+						CustomType:  colorType{},
 						Description: "The background color of the main content area in the management pages. The default value is `#f7f7f7`",
 					},
 					"main_text_color": schema.StringAttribute{
-						Optional: true,
-						Computed: true,
-						Default:  stringdefault.StaticString("#363636"),
-						Validators: []validator.String{
-							stringvalidator.RegexMatches(hexcode_regex, "main_text_color must be a valid hex color code with lowercase characters"),
-						},
+						Optional:    true,
+						Computed:    true,
+						Default:     stringdefault.StaticString("#363636"),
+						CustomType:  colorType{},
 						Description: "The color of the text in the main content area of the management pages. The default value is `#363636`",
 					},
 					"navbar_background_color": schema.StringAttribute{
-						Optional: true,
-						Computed: true,
-						Default:  stringdefault.StaticString("#ffffff"),
-						Validators: []validator.String{
-							stringvalidator.RegexMatches(hexcode_regex, "navbar_background_color must be a valid hex color code with lowercase characters"),
-						},
+						Optional:    true,
+						Computed:    true,
+						Default:     stringdefault.StaticString("#ffffff"),
+						CustomType:  colorType{},
 						Description: "The background color of the navigation bar in the management pages. The default value is `#ffffff`",
 					},
 					"navbar_text_color": schema.StringAttribute{
-						Optional: true,
-						Computed: true,
-						Default:  stringdefault.StaticString("#0f0f0f"),
-						Validators: []validator.String{
-							stringvalidator.RegexMatches(hexcode_regex, "navbar_text_color must be a valid hex color code with lowercase characters"),
-						},
+						Optional:    true,
+						Computed:    true,
+						Default:     stringdefault.StaticString("#0f0f0f"),
+						CustomType:  colorType{},
 						Description: "The color of the text in the navigation bar in the management pages. The default value is `#0f0f0f`",
 					},
 					"action_button_color": schema.StringAttribute{
-						Optional: true,
-						Computed: true,
-						Default:  stringdefault.StaticString("#50c878"),
-						Validators: []validator.String{
-							stringvalidator.RegexMatches(hexcode_regex, "action_button_color must be a valid hex color code with lowercase characters"),
-						},
+						Optional:    true,
+						Computed:    true,
+						Default:     stringdefault.StaticString("#50c878"),
+						CustomType:  colorType{},
 						Description: "The color of action buttons in the management pages. The default value is `#50c878`",
 					},
 					"action_button_text_color": schema.StringAttribute{
-						Optional: true,
-						Computed: true,
-						Default:  stringdefault.StaticString("#f7f7f7"),
-						Validators: []validator.String{
-							stringvalidator.RegexMatches(hexcode_regex, "action_button_text_color must be a valid hex color code with lowercase characters"),
-						},
+						Optional:    true,
+						Computed:    true,
+						Default:     stringdefault.StaticString("#f7f7f7"),
+						CustomType:  colorType{},
 						Description: "The color of the text on action buttons in the management pages. The default value is `#f7f7f7`",
 					},
 					"border_color": schema.StringAttribute{
-						Optional: true,
-						Computed: true,
-						Default:  stringdefault.StaticString("#e4e4e4"),
-						Validators: []validator.String{
-							stringvalidator.RegexMatches(hexcode_regex, "border_color must be a valid hex color code with lowercase characters"),
-						},
+						Optional:   true,
+						Computed:   true,
+						Default:    stringdefault.StaticString("#e4e4e4"),
+						CustomType: colorType{},
 						Description: "The color of the border between the navbar and the main content area in the management pages. " +
 							"The default value is `#e4e4e4`",
 					},
@@ -486,6 +472,11 @@ func (r *darkmodeThemeResource) ValidateConfig(ctx context.Context, req resource
 		return
 	}
 
+	validateSplitContentImage(&plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	if plan.LoginPageTheme.SolidBackgroundParameters == nil && plan.LoginPageTheme.GradientBackgroundParameters == nil && plan.LoginPageTheme.ImageBackgroundParameters == nil {
 		resp.Diagnostics.AddAttributeError(
 			path.Root("login_page_theme"),
@@ -494,6 +485,32 @@ func (r *darkmodeThemeResource) ValidateConfig(ctx context.Context, req resource
 		)
 		return
 	}
+
+	validateThemeContrast(&plan, &resp.Diagnostics)
+}
+
+func (r *darkmodeThemeResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// nothing to check when destroying or before the provider is configured
+	if req.Plan.Raw.IsNull() || r.client == nil {
+		return
+	}
+
+	var plan themeResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() || !hasCheckableBackgroundImage(&plan) {
+		return
+	}
+
+	environmentConfig, err := r.client.GetEnvironmentConfig()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading propelauth darkmode theme",
+			"Could not read the darkmode theme to check its background image: "+err.Error(),
+		)
+		return
+	}
+
+	checkBackgroundImage(&plan, environmentConfig.DarkmodeBackgroundUrl, &resp.Diagnostics)
 }
 
 func (r *darkmodeThemeResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -509,8 +526,9 @@ func (r *darkmodeThemeResource) Create(ctx context.Context, req resource.CreateR
 	// Update the configuration in PropelAuth
 	enableDarkmodeTheme := true
 	environmentConfigUpdate := propelauth.EnvironmentConfigUpdate{
-		DarkmodeTheme:       convertPlanToTheme(&plan),
-		EnableDarkmodeTheme: &enableDarkmodeTheme,
+		DarkmodeTheme:             convertPlanToTheme(&plan),
+		EnableDarkmodeTheme:       &enableDarkmodeTheme,
+		DarkmodeBackgroundImageId: backgroundImageIdFromPlan(&plan),
 	}
 	environmentConfig, err := r.client.UpdateEnvironmentConfig(&environmentConfigUpdate)
 	if err != nil {
@@ -570,8 +588,9 @@ func (r *darkmodeThemeResource) Update(ctx context.Context, req resource.UpdateR
 	// Update the configuration in PropelAuth
 	enableDarkmodeTheme := true
 	environmentConfigUpdate := propelauth.EnvironmentConfigUpdate{
-		DarkmodeTheme:       convertPlanToTheme(&plan),
-		EnableDarkmodeTheme: &enableDarkmodeTheme,
+		DarkmodeTheme:             convertPlanToTheme(&plan),
+		EnableDarkmodeTheme:       &enableDarkmodeTheme,
+		DarkmodeBackgroundImageId: backgroundImageIdFromPlan(&plan),
 	}
 	environmentConfig, err := r.client.UpdateEnvironmentConfig(&environmentConfigUpdate)
 	if err != nil {
@@ -630,6 +649,7 @@ func (r *darkmodeThemeResource) ImportState(ctx context.Context, req resource.Im
 	}
 
 	var state themeResourceModel
+	state.StrictAccessibility = types.BoolValue(false)
 	updateStateFromTheme(environmentConfig.DarkmodeTheme, &state)
 
 	// Save updated state into Terraform state
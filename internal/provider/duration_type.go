@@ -0,0 +1,179 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"terraform-provider-propelauth/internal/propelauth"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/attr/xattr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// Ensure the duration type and value fully satisfy framework interfaces.
+var _ basetypes.StringTypable = durationType{}
+var _ basetypes.StringValuableWithSemanticEquals = durationValue{}
+var _ xattr.ValidateableAttribute = durationValue{}
+
+// durationType is a string attribute type for human-readable durations such as `15m` or `30d`. It accepts any
+// spelling supported by propelauth.ParseDuration and considers two spellings equal when they are the same
+// length of time, so a configured `60m` is kept in state when the API returns 3600 seconds. Changing the
+// configured spelling, e.g. from `60m` to `1h`, still plans an update.
+type durationType struct {
+	basetypes.StringType
+}
+
+func (t durationType) Equal(o attr.Type) bool {
+	other, ok := o.(durationType)
+	if !ok {
+		return false
+	}
+
+	return t.StringType.Equal(other.StringType)
+}
+
+func (t durationType) String() string {
+	return "durationType"
+}
+
+func (t durationType) ValueFromString(ctx context.Context, in basetypes.StringValue) (basetypes.StringValuable, diag.Diagnostics) {
+	return durationValue{StringValue: in}, nil
+}
+
+func (t durationType) ValueFromTerraform(ctx context.Context, in tftypes.Value) (attr.Value, error) {
+	attrValue, err := t.StringType.ValueFromTerraform(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+
+	stringValue, ok := attrValue.(basetypes.StringValue)
+	if !ok {
+		return nil, fmt.Errorf("unexpected value type of %T", attrValue)
+	}
+
+	stringValuable, diags := t.ValueFromString(ctx, stringValue)
+	if diags.HasError() {
+		return nil, fmt.Errorf("unexpected error converting StringValue to StringValuable: %v", diags)
+	}
+
+	return stringValuable, nil
+}
+
+func (t durationType) ValueType(ctx context.Context) attr.Value {
+	return durationValue{}
+}
+
+// durationValue is the value of a durationType attribute.
+type durationValue struct {
+	basetypes.StringValue
+}
+
+func newDurationValue(seconds int64) durationValue {
+	return durationValue{StringValue: types.StringValue(propelauth.FormatDuration(time.Duration(seconds) * time.Second))}
+}
+
+func (v durationValue) Equal(o attr.Value) bool {
+	other, ok := o.(durationValue)
+	if !ok {
+		return false
+	}
+
+	return v.StringValue.Equal(other.StringValue)
+}
+
+func (v durationValue) Type(ctx context.Context) attr.Type {
+	return durationType{}
+}
+
+func (v durationValue) StringSemanticEquals(ctx context.Context, newValuable basetypes.StringValuable) (bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	newValue, ok := newValuable.(durationValue)
+	if !ok {
+		diags.AddError(
+			"Semantic Equality Check Error",
+			fmt.Sprintf("Expected value type %T but got value type %T. Please report this issue to the provider developers.", v, newValuable),
+		)
+		return false, diags
+	}
+
+	priorDuration, err := propelauth.ParseDuration(v.ValueString())
+	if err != nil {
+		return false, diags
+	}
+	newDuration, err := propelauth.ParseDuration(newValue.ValueString())
+	if err != nil {
+		return false, diags
+	}
+
+	return priorDuration == newDuration, diags
+}
+
+func (v durationValue) ValidateAttribute(ctx context.Context, req xattr.ValidateAttributeRequest, resp *xattr.ValidateAttributeResponse) {
+	if v.IsNull() || v.IsUnknown() {
+		return
+	}
+
+	if _, err := propelauth.ParseDuration(v.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid duration",
+			fmt.Sprintf("%s must be a duration such as `15m`, `30d` or `1h30m`: %s", req.Path, err.Error()),
+		)
+	}
+}
+
+// Seconds returns the parsed duration in whole seconds, or nil if the value is null. Values are validated
+// before they reach the plan, so an unparsable value here is zero.
+func (v durationValue) Seconds() *int64 {
+	if v.IsNull() || v.IsUnknown() {
+		return nil
+	}
+
+	duration, _ := propelauth.ParseDuration(v.ValueString())
+	seconds := int64(duration / time.Second)
+	return &seconds
+}
+
+var _ validator.String = durationBetweenValidator{}
+
+// durationBetweenValidator checks that a duration is at least min and at most max long.
+type durationBetweenValidator struct {
+	min time.Duration
+	max time.Duration
+}
+
+func (v durationBetweenValidator) Description(ctx context.Context) string {
+	return fmt.Sprintf("value must be a duration between %s and %s", propelauth.FormatDuration(v.min), propelauth.FormatDuration(v.max))
+}
+
+func (v durationBetweenValidator) MarkdownDescription(ctx context.Context) string {
+	return fmt.Sprintf("value must be a duration between `%s` and `%s`", propelauth.FormatDuration(v.min), propelauth.FormatDuration(v.max))
+}
+
+func (v durationBetweenValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	duration, err := propelauth.ParseDuration(req.ConfigValue.ValueString())
+	if err != nil {
+		// reported by the duration type
+		return
+	}
+
+	if duration < v.min || duration > v.max {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Duration out of range",
+			fmt.Sprintf("%s must be between %s and %s, got: %s",
+				req.Path, propelauth.FormatDuration(v.min), propelauth.FormatDuration(v.max), req.ConfigValue.ValueString()),
+		)
+	}
+}
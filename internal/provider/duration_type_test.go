@@ -0,0 +1,44 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestDurationValueStringSemanticEquals(t *testing.T) {
+	ctx := context.Background()
+
+	// The prior value is the configured spelling, and the new one is the duration read from the API in seconds.
+	// When they're semantically equal, the framework keeps the configured spelling in state.
+	tests := []struct {
+		name    string
+		prior   string
+		seconds int64
+		want    bool
+	}{
+		{name: "Test same spelling", prior: "15m", seconds: 900, want: true},
+		{name: "Test seconds", prior: "900s", seconds: 900, want: true},
+		{name: "Test minutes for hours", prior: "480m", seconds: 8 * 60 * 60, want: true},
+		{name: "Test compound duration", prior: "4w2d", seconds: 30 * 24 * 60 * 60, want: true},
+		{name: "Test different duration", prior: "1h", seconds: 60*60 + 1, want: false},
+		{name: "Test invalid duration", prior: "soon", seconds: 900, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prior := durationValue{StringValue: types.StringValue(tt.prior)}
+			read := newDurationValue(tt.seconds)
+			got, diags := prior.StringSemanticEquals(ctx, read)
+			if diags.HasError() {
+				t.Fatalf("StringSemanticEquals() diagnostics = %v", diags)
+			}
+			if got != tt.want {
+				t.Errorf("StringSemanticEquals() = %v, want %v", got, tt.want)
+			}
+			if seconds := read.Seconds(); seconds == nil || *seconds != tt.seconds {
+				t.Errorf("Seconds() = %v, want %d", seconds, tt.seconds)
+			}
+		})
+	}
+}
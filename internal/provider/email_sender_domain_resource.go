@@ -0,0 +1,510 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"terraform-provider-propelauth/internal/propelauth"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &emailSenderDomainResource{}
+var _ resource.ResourceWithConfigure = &emailSenderDomainResource{}
+var _ resource.ResourceWithImportState = &emailSenderDomainResource{}
+var _ resource.ResourceWithValidateConfig = &emailSenderDomainResource{}
+
+func NewEmailSenderDomainResource() resource.Resource {
+	return &emailSenderDomainResource{}
+}
+
+// emailSenderDomainResource defines the resource implementation.
+type emailSenderDomainResource struct {
+	client *propelauth.PropelAuthClient
+}
+
+// emailSenderDomainResourceModel describes the resource data model.
+type emailSenderDomainResourceModel struct {
+	Environment                      types.String `tfsdk:"environment"`
+	Domain                           types.String `tfsdk:"domain"`
+	SenderName                       types.String `tfsdk:"sender_name"`
+	ReplyTo                          types.String `tfsdk:"reply_to"`
+	DnsZone                          types.String `tfsdk:"dns_zone"`
+	SpfRecordKey                     types.String `tfsdk:"spf_record_key"`
+	SpfRecordKeyWithoutDomain        types.String `tfsdk:"spf_record_key_without_domain"`
+	SpfRecordValue                   types.String `tfsdk:"spf_record_value"`
+	DkimRecordKey                    types.String `tfsdk:"dkim_record_key"`
+	DkimRecordKeyWithoutDomain       types.String `tfsdk:"dkim_record_key_without_domain"`
+	DkimRecordValue                  types.String `tfsdk:"dkim_record_value"`
+	ReturnPathRecordKey              types.String `tfsdk:"return_path_record_key"`
+	ReturnPathRecordKeyWithoutDomain types.String `tfsdk:"return_path_record_key_without_domain"`
+	ReturnPathRecordValue            types.String `tfsdk:"return_path_record_value"`
+	DnsRecords                       types.List   `tfsdk:"dns_records"`
+}
+
+var emailSenderDomainDnsRecordAttrTypes = map[string]attr.Type{
+	"purpose":       types.StringType,
+	"type":          types.StringType,
+	"name":          types.StringType,
+	"relative_name": types.StringType,
+	"value":         types.StringType,
+	"ttl":           types.Int64Type,
+}
+
+// emailSenderDomainDnsRecordTypes are the types of the SPF, DKIM, and return-path records, by purpose.
+var emailSenderDomainDnsRecordTypes = map[string]string{
+	"SPF":         "TXT",
+	"DKIM":        "TXT",
+	"RETURN_PATH": "CNAME",
+}
+
+// plannedEmailSenderDomainDnsRecord is an email sender domain DNS record for the given purpose before it's created.
+func plannedEmailSenderDomainDnsRecord(purpose string) map[string]attr.Value {
+	return map[string]attr.Value{
+		"purpose":       types.StringValue(purpose),
+		"type":          types.StringValue(emailSenderDomainDnsRecordTypes[purpose]),
+		"name":          types.StringUnknown(),
+		"relative_name": types.StringUnknown(),
+		"value":         types.StringUnknown(),
+		"ttl":           types.Int64Value(customDomainDnsRecordTtl),
+	}
+}
+
+func (r *emailSenderDomainResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_email_sender_domain"
+}
+
+func (r *emailSenderDomainResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	// a lower case only domain name, with TLD of at least 2 characters
+	domainNameRegex := regexp.MustCompile(`^[a-z0-9][a-z0-9\-\.]*\.[a-z0-9]{2,}$`)
+
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		Description: "Email Sender Domain resource. This sets up sending the emails of an environment, such as magic links " +
+			"and invitations, from your own domain instead of PropelAuth's default sender. " +
+			"It will return the SPF, DKIM, and return-path records that you need to add to your DNS settings, " +
+			"manually or using Terraform. Then, the `propelauth_email_sender_domain_verification` resource will verify the domain. " +
+			"Until the domain is verified, emails keep being sent from PropelAuth's default sender. " +
+			"Deleting the resource goes back to PropelAuth's default sender.",
+		Attributes: map[string]schema.Attribute{
+			"environment": schema.StringAttribute{
+				Required: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("Staging", "Prod"),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Description: "The environment whose emails are sent from the domain. Accepted values are `Staging` and `Prod`. " +
+					"Note: Staging environments are only available on some pricing plans.",
+			},
+			"domain": schema.StringAttribute{
+				Required: true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(
+						domainNameRegex,
+						"`domain` must be a valid domain name with lowercase characters such as 'mail.example.com'.",
+					),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Description: "The domain emails are sent from, such as `example.com` or `mail.example.com`. " +
+					"Emails are sent from `noreply@<domain>`. Changing the domain requires new DNS records and a new verification.",
+			},
+			"sender_name": schema.StringAttribute{
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.LengthBetween(1, 64),
+					stringvalidator.RegexMatches(
+						regexp.MustCompile(`^[^<>"\r\n]*$`),
+						"`sender_name` must not contain `<`, `>`, `\"`, or line breaks.",
+					),
+				},
+				Description: "The name emails are sent from, e.g. `Acme`. If not set, the project name is used.",
+			},
+			"reply_to": schema.StringAttribute{
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(
+						regexp.MustCompile(`^[^@\s]+@[^@\s]+\.[^@\s]+$`),
+						"`reply_to` must be an email address such as 'support@example.com'.",
+					),
+				},
+				Description: "The email address replies are sent to. If not set, replies go to the sending address.",
+			},
+			"dns_zone": schema.StringAttribute{
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(
+						domainNameRegex,
+						"`dns_zone` must be a valid domain name with lowercase characters such as 'your.site.com'.",
+					),
+				},
+				Description: "The DNS zone the records will be created in, such as `example.com` or a delegated `mail.example.com`. " +
+					"This is used to derive the record names without the domain. " +
+					"If not set, the zone is the registrable domain of `domain` according to the public suffix list, " +
+					"e.g. `example.co.uk` for `mail.example.co.uk`.",
+			},
+			"spf_record_key": schema.StringAttribute{
+				Computed:    true,
+				Description: "The name of the SPF TXT record for the domain.",
+			},
+			"spf_record_key_without_domain": schema.StringAttribute{
+				Computed: true,
+				Description: "The name of the SPF TXT record relative to `dns_zone` (e.g. just mail instead of mail.example.com), " +
+					"or `@` if the record is at the apex of the zone.",
+			},
+			"spf_record_value": schema.StringAttribute{
+				Computed:    true,
+				Description: "The value of the SPF TXT record for the domain.",
+			},
+			"dkim_record_key": schema.StringAttribute{
+				Computed:    true,
+				Description: "The name of the DKIM TXT record for the domain.",
+			},
+			"dkim_record_key_without_domain": schema.StringAttribute{
+				Computed:    true,
+				Description: "The name of the DKIM TXT record relative to `dns_zone`.",
+			},
+			"dkim_record_value": schema.StringAttribute{
+				Computed:    true,
+				Description: "The value of the DKIM TXT record for the domain, the public key emails are signed with.",
+			},
+			"return_path_record_key": schema.StringAttribute{
+				Computed:    true,
+				Description: "The name of the return-path CNAME record for the domain, which bounces are sent to.",
+			},
+			"return_path_record_key_without_domain": schema.StringAttribute{
+				Computed:    true,
+				Description: "The name of the return-path CNAME record relative to `dns_zone`.",
+			},
+			"return_path_record_value": schema.StringAttribute{
+				Computed:    true,
+				Description: "The value of the return-path CNAME record for the domain.",
+			},
+			"dns_records": schema.ListNestedAttribute{
+				Computed: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"purpose": schema.StringAttribute{
+							Computed:    true,
+							Description: "What the DNS record is for, `SPF`, `DKIM`, or `RETURN_PATH`.",
+						},
+						"type": schema.StringAttribute{
+							Computed:    true,
+							Description: "The type of the DNS record, `TXT` or `CNAME`.",
+						},
+						"name": schema.StringAttribute{
+							Computed:    true,
+							Description: "The full name of the DNS record, e.g. `mail.example.com`.",
+						},
+						"relative_name": schema.StringAttribute{
+							Computed:    true,
+							Description: "The name of the DNS record relative to `dns_zone`, e.g. `mail`.",
+						},
+						"value": schema.StringAttribute{
+							Computed:    true,
+							Description: "The value of the DNS record.",
+						},
+						"ttl": schema.Int64Attribute{
+							Computed:    true,
+							Description: "A suggested TTL for the DNS record, in seconds.",
+						},
+					},
+				},
+				PlanModifiers: []planmodifier.List{
+					dnsRecordsPlanModifier{
+						attrTypes: emailSenderDomainDnsRecordAttrTypes,
+						plannedRecords: []map[string]attr.Value{
+							plannedEmailSenderDomainDnsRecord("SPF"),
+							plannedEmailSenderDomainDnsRecord("DKIM"),
+							plannedEmailSenderDomainDnsRecord("RETURN_PATH"),
+						},
+						domainPaths: []path.Path{path.Root("domain")},
+					},
+				},
+				Description: "The SPF, DKIM, and return-path records for the domain, in a form that can be passed to " +
+					"your DNS provider's record resources with `for_each`, keyed by `purpose`.",
+			},
+		},
+	}
+}
+
+func (r *emailSenderDomainResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config emailSenderDomainResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if config.DnsZone.IsNull() || config.DnsZone.IsUnknown() || config.Domain.IsUnknown() {
+		return
+	}
+
+	domain := config.Domain.ValueString()
+	dnsZone := config.DnsZone.ValueString()
+	if domain != dnsZone && !strings.HasSuffix(domain, "."+dnsZone) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("dns_zone"),
+			"Invalid DNS zone",
+			fmt.Sprintf("The DNS records for %s can't be created in the DNS zone %s. "+
+				"`dns_zone` must be the domain itself or one of its parent domains.", domain, dnsZone),
+		)
+	}
+}
+
+func (r *emailSenderDomainResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*propelauth.PropelAuthClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *propelauth.PropelAuthClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *emailSenderDomainResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan emailSenderDomainResourceModel
+
+	// Read Terraform plan data into the model
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	emailSenderDomainInfo, err := r.client.UpdateEmailSenderDomain(
+		plan.Environment.ValueString(),
+		plan.Domain.ValueString(),
+		plan.SenderName.ValueStringPointer(),
+		plan.ReplyTo.ValueStringPointer(),
+	)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error setting email sender domain",
+			"Could not set email sender domain, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	// Write logs using the tflog package
+	// Documentation: https://terraform.io/plugin/log
+	tflog.Trace(ctx, "created a propelauth_email_sender_domain resource")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(updateEmailSenderDomainRecords(&plan, emailSenderDomainInfo)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *emailSenderDomainResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// Read the data from the state
+	var state emailSenderDomainResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	emailSenderDomainInfo, err := r.client.GetEmailSenderDomain(state.Environment.ValueString())
+	if err != nil {
+		// If error is "not_found", it indicates that the resource should be deleted.
+		if propelauth.IsPropelAuthNotFoundError(err) {
+			tflog.Trace(ctx, "deleting a propelauth_email_sender_domain resource because it was not found in PropelAuth")
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Error getting email sender domain",
+			"Could not get email sender domain, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	tflog.Trace(ctx, "read a propelauth_email_sender_domain resource")
+
+	state.Domain = types.StringValue(emailSenderDomainInfo.Domain)
+	state.SenderName = types.StringPointerValue(emailSenderDomainInfo.SenderName)
+	state.ReplyTo = types.StringPointerValue(emailSenderDomainInfo.ReplyTo)
+	resp.Diagnostics.Append(updateEmailSenderDomainRecords(&state, emailSenderDomainInfo)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *emailSenderDomainResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Read Terraform plan data into the model
+	var plan emailSenderDomainResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Read the current state data
+	var state emailSenderDomainResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	emailSenderDomainInfo, err := r.client.UpdateEmailSenderDomain(
+		plan.Environment.ValueString(),
+		plan.Domain.ValueString(),
+		plan.SenderName.ValueStringPointer(),
+		plan.ReplyTo.ValueStringPointer(),
+	)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error setting email sender domain",
+			"Could not set email sender domain, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	tflog.Trace(ctx, "updated a propelauth_email_sender_domain resource")
+
+	// The API doesn't return the records of a verified domain, so those come from the state
+	// and just get their relative names derived again.
+	for _, record := range []struct {
+		info  **string
+		prior types.String
+	}{
+		{&emailSenderDomainInfo.SpfRecordKey, state.SpfRecordKey},
+		{&emailSenderDomainInfo.SpfRecordValue, state.SpfRecordValue},
+		{&emailSenderDomainInfo.DkimRecordKey, state.DkimRecordKey},
+		{&emailSenderDomainInfo.DkimRecordValue, state.DkimRecordValue},
+		{&emailSenderDomainInfo.ReturnPathRecordKey, state.ReturnPathRecordKey},
+		{&emailSenderDomainInfo.ReturnPathRecordValue, state.ReturnPathRecordValue},
+	} {
+		if *record.info == nil {
+			*record.info = record.prior.ValueStringPointer()
+		}
+	}
+	resp.Diagnostics.Append(updateEmailSenderDomainRecords(&plan, emailSenderDomainInfo)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *emailSenderDomainResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state emailSenderDomainResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Go back to sending emails from PropelAuth's default sender
+	err := r.client.DeleteEmailSenderDomain(state.Environment.ValueString())
+	if err != nil && !propelauth.IsPropelAuthNotFoundError(err) {
+		resp.Diagnostics.AddError(
+			"Error deleting email sender domain",
+			"Could not delete email sender domain, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	tflog.Trace(ctx, "deleted a propelauth_email_sender_domain resource")
+}
+
+func (r *emailSenderDomainResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	environment := req.ID
+	if environment != "Staging" && environment != "Prod" {
+		resp.Diagnostics.AddError(
+			"Invalid import ID",
+			"Import ID must be either `Staging` or `Prod`.",
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("environment"), environment)...)
+}
+
+// updateEmailSenderDomainRecords sets the DNS record attributes from the email sender domain info. Record names
+// relative to the zone are derived with the model's `dns_zone`. Records missing from the info keep the model's
+// values if they're known, and are null otherwise.
+func updateEmailSenderDomainRecords(model *emailSenderDomainResourceModel, emailSenderDomainInfo *propelauth.EmailSenderDomainInfo) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	recordAttributes := []struct {
+		purpose               string
+		key, keyWithoutDomain *types.String
+		value                 *types.String
+		infoKey, infoValue    *string
+	}{
+		{"SPF", &model.SpfRecordKey, &model.SpfRecordKeyWithoutDomain, &model.SpfRecordValue,
+			emailSenderDomainInfo.SpfRecordKey, emailSenderDomainInfo.SpfRecordValue},
+		{"DKIM", &model.DkimRecordKey, &model.DkimRecordKeyWithoutDomain, &model.DkimRecordValue,
+			emailSenderDomainInfo.DkimRecordKey, emailSenderDomainInfo.DkimRecordValue},
+		{"RETURN_PATH", &model.ReturnPathRecordKey, &model.ReturnPathRecordKeyWithoutDomain, &model.ReturnPathRecordValue,
+			emailSenderDomainInfo.ReturnPathRecordKey, emailSenderDomainInfo.ReturnPathRecordValue},
+	}
+
+	records := []attr.Value{}
+	for _, recordAttribute := range recordAttributes {
+		if recordAttribute.infoKey != nil {
+			keyWithoutDomain, err := propelauth.RelativeRecordName(*recordAttribute.infoKey, model.DnsZone.ValueString())
+			if err != nil {
+				diags.AddAttributeError(path.Root("dns_zone"), "Invalid DNS zone",
+					fmt.Sprintf("Could not derive the %s record name: %s", recordAttribute.purpose, err.Error()))
+				return diags
+			}
+			*recordAttribute.key = types.StringValue(*recordAttribute.infoKey)
+			*recordAttribute.keyWithoutDomain = types.StringValue(keyWithoutDomain)
+		}
+		if recordAttribute.infoValue != nil {
+			*recordAttribute.value = types.StringValue(*recordAttribute.infoValue)
+		}
+
+		for _, value := range []*types.String{recordAttribute.key, recordAttribute.keyWithoutDomain, recordAttribute.value} {
+			if value.IsUnknown() {
+				*value = types.StringNull()
+			}
+		}
+
+		if !recordAttribute.key.IsNull() && !recordAttribute.value.IsNull() {
+			records = append(records, types.ObjectValueMust(emailSenderDomainDnsRecordAttrTypes, map[string]attr.Value{
+				"purpose":       types.StringValue(recordAttribute.purpose),
+				"type":          types.StringValue(emailSenderDomainDnsRecordTypes[recordAttribute.purpose]),
+				"name":          *recordAttribute.key,
+				"relative_name": *recordAttribute.keyWithoutDomain,
+				"value":         *recordAttribute.value,
+				"ttl":           types.Int64Value(customDomainDnsRecordTtl),
+			}))
+		}
+	}
+
+	dnsRecords, listDiags := types.ListValue(types.ObjectType{AttrTypes: emailSenderDomainDnsRecordAttrTypes}, records)
+	diags.Append(listDiags...)
+	model.DnsRecords = dnsRecords
+
+	return diags
+}
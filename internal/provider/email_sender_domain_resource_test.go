@@ -0,0 +1,61 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccEmailSenderDomainResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccEmailSenderDomainResourceConfig("mail.example.com", "Acme"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("propelauth_email_sender_domain.test", "environment", "Prod"),
+					resource.TestCheckResourceAttr("propelauth_email_sender_domain.test", "domain", "mail.example.com"),
+					resource.TestCheckResourceAttr("propelauth_email_sender_domain.test", "sender_name", "Acme"),
+					resource.TestCheckResourceAttrSet("propelauth_email_sender_domain.test", "spf_record_value"),
+					resource.TestCheckResourceAttrSet("propelauth_email_sender_domain.test", "dkim_record_key"),
+					resource.TestCheckResourceAttrSet("propelauth_email_sender_domain.test", "dkim_record_value"),
+					resource.TestCheckResourceAttrSet("propelauth_email_sender_domain.test", "return_path_record_value"),
+					resource.TestCheckResourceAttr("propelauth_email_sender_domain.test", "spf_record_key_without_domain", "mail"),
+					resource.TestCheckResourceAttr("propelauth_email_sender_domain.test", "dns_records.#", "3"),
+					resource.TestCheckResourceAttr("propelauth_email_sender_domain.test", "dns_records.0.purpose", "SPF"),
+					resource.TestCheckResourceAttr("propelauth_email_sender_domain.test", "dns_records.2.type", "CNAME"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:                         "propelauth_email_sender_domain.test",
+				ImportState:                          true,
+				ImportStateId:                        "Prod",
+				ImportStateVerify:                    true,
+				ImportStateVerifyIdentifierAttribute: "environment",
+			},
+			// Update and Read testing
+			{
+				Config: testAccEmailSenderDomainResourceConfig("mail.example.com", "Acme Support"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("propelauth_email_sender_domain.test", "sender_name", "Acme Support"),
+					resource.TestCheckResourceAttr("propelauth_email_sender_domain.test", "reply_to", "support@example.com"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccEmailSenderDomainResourceConfig(domain string, senderName string) string {
+	return providerConfig + fmt.Sprintf(`
+resource "propelauth_email_sender_domain" "test" {
+  environment = "Prod"
+  domain      = %[1]q
+  sender_name = %[2]q
+  reply_to    = "support@example.com"
+}
+`, domain, senderName)
+}
@@ -0,0 +1,288 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"terraform-provider-propelauth/internal/propelauth"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &emailSenderDomainVerificationResource{}
+var _ resource.ResourceWithConfigure = &emailSenderDomainVerificationResource{}
+var _ resource.ResourceWithImportState = &emailSenderDomainVerificationResource{}
+
+func NewEmailSenderDomainVerificationResource() resource.Resource {
+	return &emailSenderDomainVerificationResource{}
+}
+
+// emailSenderDomainVerificationResource defines the resource implementation.
+type emailSenderDomainVerificationResource struct {
+	client *propelauth.PropelAuthClient
+}
+
+// emailSenderDomainVerificationResourceModel describes the resource data model.
+type emailSenderDomainVerificationResourceModel struct {
+	Environment  types.String   `tfsdk:"environment"`
+	Domain       types.String   `tfsdk:"domain"`
+	DnsResolvers types.List     `tfsdk:"dns_resolvers"`
+	Timeouts     timeouts.Value `tfsdk:"timeouts"`
+}
+
+func (r *emailSenderDomainVerificationResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_email_sender_domain_verification"
+}
+
+func (r *emailSenderDomainVerificationResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		Description: "Email Sender Domain Verification resource. This is for verifying the domain set up with " +
+			"`propelauth_email_sender_domain`, after which the environment's emails are sent from it.",
+		Attributes: map[string]schema.Attribute{
+			"environment": schema.StringAttribute{
+				Required: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("Staging", "Prod"),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Description: "The environment whose email sender domain is verified. Accepted values are `Staging`, `Prod`.",
+			},
+			"domain": schema.StringAttribute{
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Description: "The domain to verify.",
+			},
+			"dns_resolvers": schema.ListAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Validators: []validator.List{
+					listvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
+				},
+				Description: "The DNS resolvers, as `host` or `host:port`, used to check the SPF, DKIM, and return-path records " +
+					"before asking PropelAuth to verify the domain. Each resolver must return the expected records. " +
+					"If not set, the resolvers in `/etc/resolv.conf` are used. Set to an empty list to skip the check.",
+			},
+			"timeouts": timeouts.Attributes(ctx, timeouts.Opts{
+				Create: true,
+				Update: true,
+			}),
+		},
+	}
+}
+
+func (r *emailSenderDomainVerificationResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*propelauth.PropelAuthClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *propelauth.PropelAuthClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *emailSenderDomainVerificationResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan emailSenderDomainVerificationResourceModel
+
+	// Read Terraform plan data into the model
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, err := plan.Timeouts.Create(ctx, 5*time.Minute)
+	if err != nil {
+		resp.Diagnostics.AddError("Error creating a timeout", "Could not create a timeout for the email sender domain verification.")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	resp.Diagnostics.Append(r.verify(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "created a propelauth_email_sender_domain_verification resource")
+
+	// Verification successful
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *emailSenderDomainVerificationResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// Read the data from the state
+	var state emailSenderDomainVerificationResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	emailSenderDomainInfo, err := r.client.GetEmailSenderDomain(state.Environment.ValueString())
+	if err != nil && !propelauth.IsPropelAuthNotFoundError(err) {
+		resp.Diagnostics.AddError(
+			"Error getting email sender domain",
+			"Could not get email sender domain, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	// Verify again if the domain was removed, replaced, or is no longer verified
+	if err != nil || !emailSenderDomainInfo.IsVerified || emailSenderDomainInfo.Domain != state.Domain.ValueString() {
+		tflog.Trace(ctx, "deleting a propelauth_email_sender_domain_verification resource because the domain is no longer verified")
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	tflog.Trace(ctx, "read a propelauth_email_sender_domain_verification resource")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *emailSenderDomainVerificationResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan emailSenderDomainVerificationResourceModel
+
+	// Read Terraform plan data into the model
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, err := plan.Timeouts.Update(ctx, 5*time.Minute)
+	if err != nil {
+		resp.Diagnostics.AddError("Error creating a timeout", "Could not create a timeout for the email sender domain verification.")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	resp.Diagnostics.Append(r.verify(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "updated a propelauth_email_sender_domain_verification resource")
+
+	// Verification successful
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *emailSenderDomainVerificationResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	tflog.Trace(ctx, "deleted a propelauth_email_sender_domain_verification resource")
+}
+
+func (r *emailSenderDomainVerificationResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	var state emailSenderDomainVerificationResourceModel
+
+	environment := req.ID
+	if environment != "Staging" && environment != "Prod" {
+		resp.Diagnostics.AddError("Invalid import ID", "The import ID must be either `Staging` or `Prod`.")
+		return
+	}
+
+	emailSenderDomainInfo, err := r.client.GetEmailSenderDomain(environment)
+	if err != nil {
+		resp.Diagnostics.AddError("Error fetching email sender domain", "Could not fetch the email sender domain for the environment.")
+		return
+	}
+
+	state.Environment = types.StringValue(environment)
+	state.Domain = types.StringValue(emailSenderDomainInfo.Domain)
+	state.DnsResolvers = types.ListNull(types.StringType)
+	// need to manually pull timeouts from hcl to pass validation
+	var timeouts timeouts.Value
+	resp.Diagnostics.Append(resp.State.GetAttribute(ctx, path.Root("timeouts"), &timeouts)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state.Timeouts = timeouts
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// verify asks PropelAuth to verify the email sender domain until it succeeds or the context is done. Like
+// custom domain verification, each attempt first checks the DNS records through the configured resolvers.
+func (r *emailSenderDomainVerificationResource) verify(ctx context.Context, plan *emailSenderDomainVerificationResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	environment := plan.Environment.ValueString()
+	resolvers := propelauth.SystemDnsResolvers()
+	if !plan.DnsResolvers.IsNull() {
+		resolvers = []string{}
+		diags.Append(plan.DnsResolvers.ElementsAs(ctx, &resolvers, false)...)
+		if diags.HasError() {
+			return diags
+		}
+	}
+
+	return pollVerification(ctx, "email sender domain", func() (string, diag.Diagnostics) {
+		var diags diag.Diagnostics
+
+		emailSenderDomainInfo, err := r.client.GetEmailSenderDomain(environment)
+		if err != nil {
+			diags.AddError(
+				"Error getting email sender domain",
+				"Could not get email sender domain, unexpected error: "+err.Error(),
+			)
+			return "", diags
+		}
+		if emailSenderDomainInfo.Domain != plan.Domain.ValueString() {
+			diags.AddAttributeError(
+				path.Root("domain"),
+				"Email sender domain mismatch",
+				fmt.Sprintf("The %s environment sends emails from %s, not %s. Verify the domain of its `propelauth_email_sender_domain`.",
+					environment, emailSenderDomainInfo.Domain, plan.Domain.ValueString()),
+			)
+			return "", diags
+		}
+
+		if len(resolvers) > 0 {
+			failedChecks := checkExpectedDnsRecords(ctx, resolvers, []expectedDnsRecord{
+				{"TXT", emailSenderDomainInfo.SpfRecordKey, emailSenderDomainInfo.SpfRecordValue},
+				{"TXT", emailSenderDomainInfo.DkimRecordKey, emailSenderDomainInfo.DkimRecordValue},
+				{"CNAME", emailSenderDomainInfo.ReturnPathRecordKey, emailSenderDomainInfo.ReturnPathRecordValue},
+			})
+			if len(failedChecks) > 0 {
+				return strings.Join(failedChecks, "\n"), diags
+			}
+		}
+
+		verificationErr := r.client.VerifyEmailSenderDomain(environment)
+		if verificationErr != nil {
+			return "The DNS records are set, but PropelAuth could not verify the domain: " + verificationErr.Error(), diags
+		}
+		return "", diags
+	})
+}
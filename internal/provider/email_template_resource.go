@@ -0,0 +1,269 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"terraform-provider-propelauth/internal/propelauth"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &emailTemplateResource{}
+var _ resource.ResourceWithConfigure = &emailTemplateResource{}
+var _ resource.ResourceWithImportState = &emailTemplateResource{}
+var _ resource.ResourceWithValidateConfig = &emailTemplateResource{}
+
+func NewEmailTemplateResource() resource.Resource {
+	return &emailTemplateResource{}
+}
+
+// emailTemplateResource defines the resource implementation.
+type emailTemplateResource struct {
+	client *propelauth.PropelAuthClient
+}
+
+// emailTemplateResourceModel describes the resource data model.
+type emailTemplateResourceModel struct {
+	TemplateType types.String `tfsdk:"template_type"`
+	Subject      types.String `tfsdk:"subject"`
+	HtmlBody     types.String `tfsdk:"html_body"`
+	TextBody     types.String `tfsdk:"text_body"`
+}
+
+func (r *emailTemplateResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_email_template"
+}
+
+func (r *emailTemplateResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Email Template resource. This is for customizing one of the transactional emails PropelAuth sends " +
+			"to your users. Email templates are shared by all environments. Placeholders are written as `{{variable_name}}` " +
+			"and are checked against the ones supported by the template type when planning. Deleting the resource resets " +
+			"the email to PropelAuth's default.",
+		Attributes: map[string]schema.Attribute{
+			"template_type": schema.StringAttribute{
+				Required: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(propelauth.EmailTemplateTypeNames()...),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Description: "The email being customized. Accepted values are `ConfirmEmail`, `MagicLink`, `Invite`, and `ResetPassword`.",
+			},
+			"subject": schema.StringAttribute{
+				Required: true,
+				Validators: []validator.String{
+					stringvalidator.LengthBetween(1, 200),
+				},
+				Description: "The subject line of the email.",
+			},
+			"html_body": schema.StringAttribute{
+				Required: true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+				Description: "The HTML body of the email. It must use the link placeholder of the template type, " +
+					"e.g. `{{confirmation_link}}` for `ConfirmEmail`.",
+			},
+			"text_body": schema.StringAttribute{
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+				Description: "The plain text body of the email, for email clients that don't display HTML. " +
+					"If set, it must also use the link placeholder of the template type. If unset, it is generated from `html_body`.",
+			},
+		},
+	}
+}
+
+func (r *emailTemplateResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*propelauth.PropelAuthClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *propelauth.PropelAuthClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *emailTemplateResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config emailTemplateResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() || config.TemplateType.IsUnknown() {
+		return
+	}
+
+	templateType, ok := propelauth.LookupEmailTemplateType(config.TemplateType.ValueString())
+	if !ok {
+		// reported by the template_type validator
+		return
+	}
+
+	parts := []struct {
+		attribute string
+		value     types.String
+		isBody    bool
+	}{
+		{"subject", config.Subject, false},
+		{"html_body", config.HtmlBody, true},
+		{"text_body", config.TextBody, true},
+	}
+	for _, part := range parts {
+		if part.value.IsNull() || part.value.IsUnknown() {
+			continue
+		}
+		if err := templateType.CheckBody(part.value.ValueString(), part.isBody); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root(part.attribute),
+				"Invalid email template",
+				fmt.Sprintf("The %s of the email template is invalid: %s.", strings.ReplaceAll(part.attribute, "_", " "), err.Error()),
+			)
+		}
+	}
+}
+
+func (r *emailTemplateResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan emailTemplateResourceModel
+
+	// Read Terraform plan data into the model
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.UpdateEmailTemplate(plan.TemplateType.ValueString(), emailTemplateRequestFromPlan(plan))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating email template",
+			"Could not customize email template, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	// Write logs using the tflog package
+	// Documentation: https://terraform.io/plugin/log
+	tflog.Trace(ctx, "created a propelauth_email_template resource")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *emailTemplateResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// Get current state and read it into the model
+	var state emailTemplateResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	emailTemplate, err := r.client.GetEmailTemplate(state.TemplateType.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading PropelAuth Email Template",
+			"Could not read PropelAuth Email Template: "+err.Error(),
+		)
+		return
+	}
+
+	// a template that was reset in the dashboard is no longer managed
+	if !emailTemplate.IsCustomized {
+		tflog.Trace(ctx, "deleting a propelauth_email_template resource because the email template is no longer customized")
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	state.Subject = types.StringValue(emailTemplate.Subject)
+	state.HtmlBody = types.StringValue(emailTemplate.HtmlBody)
+	state.TextBody = types.StringPointerValue(emailTemplate.TextBody)
+
+	// Save updated state into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *emailTemplateResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Read Terraform plan data into the model
+	var plan emailTemplateResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.UpdateEmailTemplate(plan.TemplateType.ValueString(), emailTemplateRequestFromPlan(plan))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating email template",
+			"Could not update email template, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	tflog.Trace(ctx, "updated a propelauth_email_template resource")
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *emailTemplateResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state emailTemplateResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Reset the email template to PropelAuth's default
+	err := r.client.ResetEmailTemplate(state.TemplateType.ValueString())
+	if err != nil && !propelauth.IsPropelAuthNotFoundError(err) {
+		resp.Diagnostics.AddError(
+			"Error deleting email template",
+			"Could not reset email template, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	tflog.Trace(ctx, "deleted a propelauth_email_template resource")
+}
+
+func (r *emailTemplateResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if _, ok := propelauth.LookupEmailTemplateType(req.ID); !ok {
+		resp.Diagnostics.AddError(
+			"Invalid import ID",
+			fmt.Sprintf("Expected the template type as the import ID, one of %s, got: %s",
+				strings.Join(propelauth.EmailTemplateTypeNames(), ", "), req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("template_type"), req.ID)...)
+}
+
+// emailTemplateRequestFromPlan builds the request to save the planned email template.
+func emailTemplateRequestFromPlan(plan emailTemplateResourceModel) propelauth.EmailTemplateUpdateRequest {
+	return propelauth.EmailTemplateUpdateRequest{
+		Subject:  plan.Subject.ValueString(),
+		HtmlBody: plan.HtmlBody.ValueString(),
+		TextBody: plan.TextBody.ValueStringPointer(),
+	}
+}
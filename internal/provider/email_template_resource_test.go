@@ -0,0 +1,56 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccEmailTemplateResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Unsupported placeholders are rejected when planning
+			{
+				Config:      testAccEmailTemplateResourceConfig("Join {{org_name}}", "<a href=\"{{invite_lnk}}\">Accept</a>"),
+				ExpectError: regexp.MustCompile(`did you mean \{\{invite_link\}\}`),
+			},
+			// Create and Read testing
+			{
+				Config: testAccEmailTemplateResourceConfig("Join {{org_name}}", "<a href=\"{{invite_link}}\">Accept</a>"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("propelauth_email_template.test", "template_type", "Invite"),
+					resource.TestCheckResourceAttr("propelauth_email_template.test", "subject", "Join {{org_name}}"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:                         "propelauth_email_template.test",
+				ImportState:                          true,
+				ImportStateId:                        "Invite",
+				ImportStateVerify:                    true,
+				ImportStateVerifyIdentifierAttribute: "template_type",
+			},
+			// Update and Read testing
+			{
+				Config: testAccEmailTemplateResourceConfig("{{inviter_email}} invited you to {{org_name}}", "<a href=\"{{invite_link}}\">Join {{org_name}}</a>"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("propelauth_email_template.test", "subject", "{{inviter_email}} invited you to {{org_name}}"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccEmailTemplateResourceConfig(subject string, htmlBody string) string {
+	return providerConfig + fmt.Sprintf(`
+resource "propelauth_email_template" "test" {
+  template_type = "Invite"
+  subject       = %[1]q
+  html_body     = %[2]q
+}
+`, subject, htmlBody)
+}
@@ -0,0 +1,98 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"terraform-provider-propelauth/internal/propelauth"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource = &feIntegrationAllEnvironmentsDataSource{}
+)
+
+// NewFeIntegrationAllEnvironmentsDataSource is a helper function to simplify the provider implementation.
+func NewFeIntegrationAllEnvironmentsDataSource() datasource.DataSource {
+	return &feIntegrationAllEnvironmentsDataSource{}
+}
+
+type feIntegrationAllEnvironmentsDataSource struct {
+	client *propelauth.PropelAuthClient
+}
+
+type feIntegrationAllEnvironmentsDataSourceModel struct {
+	Test    feIntegrationInfoModel `tfsdk:"test"`
+	Staging feIntegrationInfoModel `tfsdk:"staging"`
+	Prod    feIntegrationInfoModel `tfsdk:"prod"`
+}
+
+// Metadata returns the data source type name.
+func (d *feIntegrationAllEnvironmentsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_fe_integration_all_environments"
+}
+
+// Schema defines the schema for the data source.
+func (d *feIntegrationAllEnvironmentsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Retrieve the front-end integrations of all of your PropelAuth environments in one call.",
+		Attributes: map[string]schema.Attribute{
+			"test": schema.SingleNestedAttribute{
+				Computed:    true,
+				Attributes:  feIntegrationInfoAttributes(),
+				Description: "The front-end integration of the `Test` environment.",
+			},
+			"staging": schema.SingleNestedAttribute{
+				Computed:    true,
+				Attributes:  feIntegrationInfoAttributes(),
+				Description: "The front-end integration of the `Staging` environment.",
+			},
+			"prod": schema.SingleNestedAttribute{
+				Computed:    true,
+				Attributes:  feIntegrationInfoAttributes(),
+				Description: "The front-end integration of the `Prod` environment.",
+			},
+		},
+	}
+}
+
+func (d *feIntegrationAllEnvironmentsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*propelauth.PropelAuthClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data source Configure Type",
+			fmt.Sprintf("Expected *propelauth.PropelAuthClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *feIntegrationAllEnvironmentsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	// Fetch the data from the PropelAuth API
+	feIntegrationInfo, err := d.client.GetFeIntegrationInfo()
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to fetch data from PropelAuth API", err.Error())
+		return
+	}
+
+	state := feIntegrationAllEnvironmentsDataSourceModel{
+		Test:    convertTestFeIntegrationInfo(&feIntegrationInfo.Test),
+		Staging: convertLiveFeIntegrationInfo(&feIntegrationInfo.Staging),
+		Prod:    convertLiveFeIntegrationInfo(&feIntegrationInfo.Prod),
+	}
+
+	// Write the data to the Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
@@ -0,0 +1,205 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"terraform-provider-propelauth/internal/propelauth"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource = &feIntegrationDataSource{}
+)
+
+// NewFeIntegrationDataSource is a helper function to simplify the provider implementation.
+func NewFeIntegrationDataSource() datasource.DataSource {
+	return &feIntegrationDataSource{}
+}
+
+type feIntegrationDataSource struct {
+	client *propelauth.PropelAuthClient
+}
+
+type feIntegrationDataSourceModel struct {
+	Environment           types.String                `tfsdk:"environment"`
+	AuthUrl               types.String                `tfsdk:"auth_url"`
+	ApplicationUrl        types.String                `tfsdk:"application_url"`
+	LoginRedirectPath     types.String                `tfsdk:"login_redirect_path"`
+	LogoutRedirectPath    types.String                `tfsdk:"logout_redirect_path"`
+	AdditionalFeLocations []additionalFeLocationModel `tfsdk:"additional_fe_locations"`
+	VerifiedDomain        types.String                `tfsdk:"verified_domain"`
+}
+
+// feIntegrationInfoModel is the front-end integration of one environment, shared by the front-end integration data sources.
+type feIntegrationInfoModel struct {
+	AuthUrl               types.String                `tfsdk:"auth_url"`
+	ApplicationUrl        types.String                `tfsdk:"application_url"`
+	LoginRedirectPath     types.String                `tfsdk:"login_redirect_path"`
+	LogoutRedirectPath    types.String                `tfsdk:"logout_redirect_path"`
+	AdditionalFeLocations []additionalFeLocationModel `tfsdk:"additional_fe_locations"`
+	VerifiedDomain        types.String                `tfsdk:"verified_domain"`
+}
+
+// Metadata returns the data source type name.
+func (d *feIntegrationDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_fe_integration"
+}
+
+// Schema defines the schema for the data source.
+func (d *feIntegrationDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	attributes := feIntegrationInfoAttributes()
+	attributes["environment"] = schema.StringAttribute{
+		Required: true,
+		Validators: []validator.String{
+			stringvalidator.OneOf("Test", "Staging", "Prod"),
+		},
+		Description: "The environment of the front-end integration. Accepted values are `Test`, `Staging`, and `Prod`.",
+	}
+
+	resp.Schema = schema.Schema{
+		Description: "Retrieve the front-end integration of one of your PropelAuth environments, such as the auth URL " +
+			"your front-end libraries need, without managing the `propelauth_fe_integration` resource.",
+		Attributes: attributes,
+	}
+}
+
+func (d *feIntegrationDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*propelauth.PropelAuthClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data source Configure Type",
+			fmt.Sprintf("Expected *propelauth.PropelAuthClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *feIntegrationDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state feIntegrationDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Fetch the data from the PropelAuth API
+	feIntegrationInfo, err := d.client.GetFeIntegrationInfo()
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to fetch data from PropelAuth API", err.Error())
+		return
+	}
+	var environmentInfo feIntegrationInfoModel
+	switch state.Environment.ValueString() {
+	case "Test":
+		environmentInfo = convertTestFeIntegrationInfo(&feIntegrationInfo.Test)
+	case "Staging":
+		environmentInfo = convertLiveFeIntegrationInfo(&feIntegrationInfo.Staging)
+	case "Prod":
+		environmentInfo = convertLiveFeIntegrationInfo(&feIntegrationInfo.Prod)
+	}
+	state.AuthUrl = environmentInfo.AuthUrl
+	state.ApplicationUrl = environmentInfo.ApplicationUrl
+	state.LoginRedirectPath = environmentInfo.LoginRedirectPath
+	state.LogoutRedirectPath = environmentInfo.LogoutRedirectPath
+	state.AdditionalFeLocations = environmentInfo.AdditionalFeLocations
+	state.VerifiedDomain = environmentInfo.VerifiedDomain
+
+	// Write the data to the Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func feIntegrationInfoAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"auth_url": schema.StringAttribute{
+			Computed:    true,
+			Description: "The URL of the environment's hosted pages. This is needed in PropelAuth front-end libraries.",
+		},
+		"application_url": schema.StringAttribute{
+			Computed:    true,
+			Description: "The URL of the application integrated with PropelAuth.",
+		},
+		"login_redirect_path": schema.StringAttribute{
+			Computed:    true,
+			Description: "The URL path users are redirected to after they log in.",
+		},
+		"logout_redirect_path": schema.StringAttribute{
+			Computed:    true,
+			Description: "The URL path users are redirected to after they log out.",
+		},
+		"additional_fe_locations": schema.SetNestedAttribute{
+			Computed:    true,
+			Description: "Additional front-end locations that are allowed to integrate with PropelAuth.",
+			NestedObject: schema.NestedAttributeObject{
+				Attributes: map[string]schema.Attribute{
+					"domain": schema.StringAttribute{
+						Computed:    true,
+						Description: "A domain that is also allowed to access user information.",
+					},
+					"allow_any_subdomain": schema.BoolAttribute{
+						Computed:    true,
+						Description: "If true, any subdomain of the domain is also allowed to access user information.",
+					},
+				},
+			},
+		},
+		"verified_domain": schema.StringAttribute{
+			Computed:    true,
+			Description: "The verified custom domain of the environment. This is null for the `Test` environment.",
+		},
+	}
+}
+
+func convertTestFeIntegrationInfo(feIntegrationInfo *propelauth.TestFeIntegrationInfo) feIntegrationInfoModel {
+	return feIntegrationInfoModel{
+		AuthUrl:               types.StringValue(feIntegrationInfo.AuthUrl),
+		ApplicationUrl:        types.StringValue(testEnvironmentApplicationUrl(feIntegrationInfo)),
+		LoginRedirectPath:     types.StringValue(feIntegrationInfo.LoginRedirectPath),
+		LogoutRedirectPath:    types.StringValue(feIntegrationInfo.LogoutRedirectPath),
+		AdditionalFeLocations: convertAdditionalFeLocations(feIntegrationInfo.AdditionalFeLocations.AdditionalFeLocations),
+		VerifiedDomain:        types.StringNull(),
+	}
+}
+
+func convertLiveFeIntegrationInfo(feIntegrationInfo *propelauth.FeIntegrationInfoForEnv) feIntegrationInfoModel {
+	verifiedDomain := types.StringNull()
+	if feIntegrationInfo.VerifiedDomain != "" {
+		verifiedDomain = types.StringValue(feIntegrationInfo.VerifiedDomain)
+	}
+
+	return feIntegrationInfoModel{
+		AuthUrl:               types.StringValue(feIntegrationInfo.AuthUrl),
+		ApplicationUrl:        types.StringValue(feIntegrationInfo.ApplicationUrl),
+		LoginRedirectPath:     types.StringValue(feIntegrationInfo.LoginRedirectPath),
+		LogoutRedirectPath:    types.StringValue(feIntegrationInfo.LogoutRedirectPath),
+		AdditionalFeLocations: convertAdditionalFeLocations(feIntegrationInfo.AdditionalFeLocations.AdditionalFeLocations),
+		VerifiedDomain:        verifiedDomain,
+	}
+}
+
+func convertAdditionalFeLocations(additionalLocations []propelauth.AdditionalFeLocation) []additionalFeLocationModel {
+	locations := make([]additionalFeLocationModel, len(additionalLocations))
+	for i, location := range additionalLocations {
+		locations[i] = additionalFeLocationModel{
+			Domain:            types.StringValue(location.Domain),
+			AllowAnySubdomain: types.BoolValue(location.AllowAnySubdomain),
+		}
+	}
+	return locations
+}
@@ -4,10 +4,12 @@ import (
 	"context"
 	"fmt"
 	"regexp"
+	"strings"
 
 	"terraform-provider-propelauth/internal/propelauth"
 
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -23,6 +25,8 @@ import (
 var _ resource.Resource = &feIntegrationResource{}
 var _ resource.ResourceWithConfigure = &feIntegrationResource{}
 var _ resource.ResourceWithImportState = &feIntegrationResource{}
+var _ resource.ResourceWithValidateConfig = &feIntegrationResource{}
+var _ resource.ResourceWithUpgradeState = &feIntegrationResource{}
 
 func NewFeIntegrationResource() resource.Resource {
 	return &feIntegrationResource{}
@@ -59,6 +63,7 @@ func (r *feIntegrationResource) Schema(ctx context.Context, req resource.SchemaR
 	resp.Schema = schema.Schema{
 		// This description is used by the documentation generator and the language server.
 		Description: "Front-end Integration. This is for configuring the front-end integration for one of your project's environments.",
+		Version:     1,
 		Attributes: map[string]schema.Attribute{
 			"environment": schema.StringAttribute{
 				Required: true,
@@ -78,7 +83,9 @@ func (r *feIntegrationResource) Schema(ctx context.Context, req resource.SchemaR
 				},
 				Description: "The URL of the application that will be integrated with PropelAuth. This url is used in combination with " +
 					"the `login_redirect_path` and `logout_redirect_path` to redirect users to your application after logging in or out. " +
-					"`application_url` must be a valid URL and can only be set for `Test` environments. For `Staging` and `Prod` environments, " +
+					"`application_url` must be a valid URL with only a scheme, host and port and can only be set for `Test` environments. " +
+					"In `Test`, an `http` URL on the local machine, such as `http://localhost:3000` or `http://127.0.0.1:3000`, is stored " +
+					"as a localhost port, and a URL without a port uses the scheme's default. For `Staging` and `Prod` environments, " +
 					"the `application_url` must be the verified domain for the environment or a subdomain of it. See " +
 					" `propelauth_custom_domain_verification` resource for more information. Do not include any trailing path separator (`/`) " +
 					"in your URL. For example, `https://any.subdomain.example.com` where `example.com` has been verified.",
@@ -101,18 +108,21 @@ func (r *feIntegrationResource) Schema(ctx context.Context, req resource.SchemaR
 					"full URL. For example, if `application_url` is `https://example.com` and `logout_redirect_path` is `/goodbye`, the " +
 					"full URL will be `https://example.com/goodbye`.",
 			},
-			"additional_fe_locations": schema.ListNestedAttribute{
-				Optional:    true,
-				Description: "Additional front-end locations that are allowed to integrate with PropelAuth.",
+			"additional_fe_locations": schema.SetNestedAttribute{
+				Optional: true,
+				Description: "Additional front-end locations that are allowed to integrate with PropelAuth. " +
+					"Each domain can only be listed once, and not if it's a subdomain of a domain with `allow_any_subdomain`.",
 				NestedObject: schema.NestedAttributeObject{
 					Attributes: map[string]schema.Attribute{
 						"domain": schema.StringAttribute{
 							Required: true,
 							Validators: []validator.String{
 								stringvalidator.RegexMatches(hasSchemeRegex, "Must be a valid URL with either http or https scheme"),
+								originValidator{},
 							},
-							Description: "A domain that will also be allowed to access user information. The domain must include a scheme. " +
-								"For example, `https://example.com`.",
+							Description: "A domain that will also be allowed to access user information. The domain must include a scheme " +
+								"and may include a port, but no path. For example, `https://example.com`. Internationalized domain names " +
+								"are sent to PropelAuth in their punycode form.",
 						},
 						"allow_any_subdomain": schema.BoolAttribute{
 							Optional: true,
@@ -128,6 +138,91 @@ func (r *feIntegrationResource) Schema(ctx context.Context, req resource.SchemaR
 	}
 }
 
+func (r *feIntegrationResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var environment types.String
+	var applicationUrl types.String
+	var additionalFeLocations types.Set
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("environment"), &environment)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("application_url"), &applicationUrl)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("additional_fe_locations"), &additionalFeLocations)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	validateAdditionalFeLocations(ctx, additionalFeLocations, &resp.Diagnostics)
+
+	if applicationUrl.IsNull() || applicationUrl.IsUnknown() {
+		return
+	}
+
+	parsedApplicationUrl, err := propelauth.ParseApplicationUrl(applicationUrl.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("application_url"), "Invalid application URL", err.Error())
+		return
+	}
+
+	if environment.IsUnknown() {
+		return
+	}
+	switch {
+	case environment.ValueString() != "Test" && parsedApplicationUrl.Kind == propelauth.ApplicationUrlKindLoopback:
+		resp.Diagnostics.AddAttributeError(
+			path.Root("application_url"),
+			"Local application URL outside the Test environment",
+			fmt.Sprintf("%s points at the local machine, which can only be used in the `Test` environment. "+
+				"For `%s`, use the environment's verified domain or a subdomain of it.", applicationUrl.ValueString(), environment.ValueString()),
+		)
+	case environment.ValueString() != "Test" && parsedApplicationUrl.Kind == propelauth.ApplicationUrlKindDevTunnel:
+		resp.Diagnostics.AddAttributeError(
+			path.Root("application_url"),
+			"Tunnel application URL outside the Test environment",
+			fmt.Sprintf("%s is on a development tunnel, which can only be used in the `Test` environment. "+
+				"For `%s`, use the environment's verified domain or a subdomain of it.", applicationUrl.ValueString(), environment.ValueString()),
+		)
+	case parsedApplicationUrl.IsLocalhost() && parsedApplicationUrl.Hostname != "localhost":
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("application_url"),
+			"Application URL stored as localhost",
+			fmt.Sprintf("PropelAuth stores %s as the localhost port %d, so users are redirected to http://localhost:%d "+
+				"after logging in or out.", applicationUrl.ValueString(), parsedApplicationUrl.Port, parsedApplicationUrl.Port),
+		)
+	}
+}
+
+func (r *feIntegrationResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		// additional_fe_locations changed from a list to a set, so the order the server returns them in doesn't matter
+		0: {
+			PriorSchema: &schema.Schema{
+				Attributes: map[string]schema.Attribute{
+					"environment":          schema.StringAttribute{Required: true},
+					"application_url":      schema.StringAttribute{Required: true},
+					"login_redirect_path":  schema.StringAttribute{Required: true},
+					"logout_redirect_path": schema.StringAttribute{Required: true},
+					"additional_fe_locations": schema.ListNestedAttribute{
+						Optional: true,
+						NestedObject: schema.NestedAttributeObject{
+							Attributes: map[string]schema.Attribute{
+								"domain":              schema.StringAttribute{Required: true},
+								"allow_any_subdomain": schema.BoolAttribute{Optional: true, Computed: true},
+							},
+						},
+					},
+				},
+			},
+			StateUpgrader: func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+				var priorState feIntegrationResourceModel
+				resp.Diagnostics.Append(req.State.Get(ctx, &priorState)...)
+				if resp.Diagnostics.HasError() {
+					return
+				}
+
+				resp.Diagnostics.Append(resp.State.Set(ctx, &priorState)...)
+			},
+		},
+	}
+}
+
 func (r *feIntegrationResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	// Prevent panic if the provider has not been configured.
 	if req.ProviderData == nil {
@@ -278,8 +373,12 @@ func convertPlanToUpdate(plan *feIntegrationResourceModel) propelauth.FeIntegrat
 	}
 
 	for i, location := range plan.AdditionalFeLocations {
+		domain := location.Domain.ValueString()
+		if parsedDomain, err := propelauth.ParseApplicationUrl(domain); err == nil {
+			domain = parsedDomain.String()
+		}
 		update.AdditionalFeLocations[i] = propelauth.AdditionalFeLocation{
-			Domain:            location.Domain.ValueString(),
+			Domain:            domain,
 			AllowAnySubdomain: location.AllowAnySubdomain.ValueBool(),
 		}
 	}
@@ -288,11 +387,11 @@ func convertPlanToUpdate(plan *feIntegrationResourceModel) propelauth.FeIntegrat
 }
 
 func updateStateForTestEnvironment(state *feIntegrationResourceModel, feIntegrationInfo *propelauth.TestFeIntegrationInfo) {
-	switch feIntegrationInfo.TestEnvFeIntegrationApplicationUrl.Type {
-	case "Localhost":
-		state.ApplicationUrl = types.StringValue(fmt.Sprintf("http://localhost:%d", feIntegrationInfo.TestEnvFeIntegrationApplicationUrl.Port))
-	case "SchemeAndDomain":
-		state.ApplicationUrl = types.StringValue(feIntegrationInfo.TestEnvFeIntegrationApplicationUrl.ApplicationUrl)
+	applicationUrl := testEnvironmentApplicationUrl(feIntegrationInfo)
+
+	// keep the configured spelling of the URL, e.g. `http://127.0.0.1:3000` for the localhost port 3000
+	if applicationUrl != "" && !propelauth.IsSameApplicationUrl(state.ApplicationUrl.ValueString(), applicationUrl) {
+		state.ApplicationUrl = types.StringValue(applicationUrl)
 	}
 
 	state.LoginRedirectPath = types.StringValue(feIntegrationInfo.LoginRedirectPath)
@@ -301,6 +400,19 @@ func updateStateForTestEnvironment(state *feIntegrationResourceModel, feIntegrat
 	updateAdditionalLocationsInState(state, feIntegrationInfo.AdditionalFeLocations.AdditionalFeLocations)
 }
 
+// testEnvironmentApplicationUrl returns the application URL of the test environment, which PropelAuth stores
+// either as a localhost port or as a URL.
+func testEnvironmentApplicationUrl(feIntegrationInfo *propelauth.TestFeIntegrationInfo) string {
+	switch feIntegrationInfo.TestEnvFeIntegrationApplicationUrl.Type {
+	case "Localhost":
+		return fmt.Sprintf("http://localhost:%d", feIntegrationInfo.TestEnvFeIntegrationApplicationUrl.Port)
+	case "SchemeAndDomain":
+		return feIntegrationInfo.TestEnvFeIntegrationApplicationUrl.ApplicationUrl
+	default:
+		return ""
+	}
+}
+
 func updateStateForLiveEnvironment(state *feIntegrationResourceModel, feIntegrationInfo *propelauth.FeIntegrationInfoForEnv) {
 	state.ApplicationUrl = types.StringValue(feIntegrationInfo.ApplicationUrl)
 	state.LoginRedirectPath = types.StringValue(feIntegrationInfo.LoginRedirectPath)
@@ -310,25 +422,75 @@ func updateStateForLiveEnvironment(state *feIntegrationResourceModel, feIntegrat
 }
 
 func updateAdditionalLocationsInState(state *feIntegrationResourceModel, additionalLocations []propelauth.AdditionalFeLocation) {
-	for i := range state.AdditionalFeLocations {
-		if i >= len(additionalLocations) {
-			state.AdditionalFeLocations = state.AdditionalFeLocations[:i]
-			return
+	if len(additionalLocations) == 0 && state.AdditionalFeLocations == nil {
+		return
+	}
+
+	updatedLocations := make([]additionalFeLocationModel, len(additionalLocations))
+	for i, location := range additionalLocations {
+		updatedLocations[i] = additionalFeLocationModel{
+			Domain:            types.StringValue(location.Domain),
+			AllowAnySubdomain: types.BoolValue(location.AllowAnySubdomain),
 		}
-		if state.AdditionalFeLocations[i].Domain.ValueString() != additionalLocations[i].Domain {
-			state.AdditionalFeLocations[i].Domain = types.StringValue(additionalLocations[i].Domain)
+
+		// keep the configured spelling of the domain, e.g. an internationalized domain name rather than its punycode
+		for _, stateLocation := range state.AdditionalFeLocations {
+			if propelauth.IsSameApplicationUrl(stateLocation.Domain.ValueString(), location.Domain) {
+				updatedLocations[i].Domain = stateLocation.Domain
+				break
+			}
 		}
-		if state.AdditionalFeLocations[i].AllowAnySubdomain.ValueBool() != additionalLocations[i].AllowAnySubdomain {
-			state.AdditionalFeLocations[i].AllowAnySubdomain = types.BoolValue(additionalLocations[i].AllowAnySubdomain)
+	}
+	state.AdditionalFeLocations = updatedLocations
+}
+
+// validateAdditionalFeLocations checks that no additional front-end location is listed twice, or is a subdomain
+// of another location that already allows any subdomain.
+func validateAdditionalFeLocations(ctx context.Context, additionalFeLocations types.Set, diags *diag.Diagnostics) {
+	if additionalFeLocations.IsNull() || additionalFeLocations.IsUnknown() {
+		return
+	}
+
+	var locations []additionalFeLocationModel
+	diags.Append(additionalFeLocations.ElementsAs(ctx, &locations, false)...)
+	if diags.HasError() {
+		return
+	}
+
+	parsedDomains := make([]*propelauth.ApplicationUrl, len(locations))
+	for i, location := range locations {
+		if location.Domain.IsUnknown() {
+			continue
 		}
+		// invalid domains are reported by the attribute's validators
+		parsedDomains[i], _ = propelauth.ParseApplicationUrl(location.Domain.ValueString())
 	}
 
-	if len(state.AdditionalFeLocations) < len(additionalLocations) {
-		for i := len(state.AdditionalFeLocations); i < len(additionalLocations); i++ {
-			state.AdditionalFeLocations = append(state.AdditionalFeLocations, additionalFeLocationModel{
-				Domain:            types.StringValue(additionalLocations[i].Domain),
-				AllowAnySubdomain: types.BoolValue(additionalLocations[i].AllowAnySubdomain),
-			})
+	for i, domain := range parsedDomains {
+		if domain == nil {
+			continue
+		}
+		for j, otherDomain := range parsedDomains {
+			if i == j || otherDomain == nil || domain.Scheme != otherDomain.Scheme || domain.Port != otherDomain.Port {
+				continue
+			}
+
+			if domain.Hostname == otherDomain.Hostname && i < j {
+				diags.AddAttributeError(
+					path.Root("additional_fe_locations"),
+					"Duplicate additional front-end location",
+					fmt.Sprintf("%s and %s are the same location. Each location can only be listed once.",
+						locations[i].Domain.ValueString(), locations[j].Domain.ValueString()),
+				)
+			}
+			if strings.HasSuffix(domain.Hostname, "."+otherDomain.Hostname) && locations[j].AllowAnySubdomain.ValueBool() {
+				diags.AddAttributeError(
+					path.Root("additional_fe_locations"),
+					"Redundant additional front-end location",
+					fmt.Sprintf("%s is already allowed by %s, which has `allow_any_subdomain` set. Remove %s.",
+						locations[i].Domain.ValueString(), locations[j].Domain.ValueString(), locations[i].Domain.ValueString()),
+				)
+			}
 		}
 	}
 }
@@ -30,10 +30,10 @@ func TestAccFeIntegrationResource(t *testing.T) {
 						"logout_redirect_path",
 						"/goodbye",
 					),
-					resource.TestCheckResourceAttr(
+					resource.TestCheckTypeSetElemNestedAttrs(
 						"propelauth_fe_integration.test",
-						"additional_fe_locations.0.domain",
-						"http://localhost:3001",
+						"additional_fe_locations.*",
+						map[string]string{"domain": "http://localhost:3001"},
 					),
 				),
 			},
@@ -56,10 +56,10 @@ func TestAccFeIntegrationResource(t *testing.T) {
 						"logout_redirect_path",
 						"/goodbye",
 					),
-					resource.TestCheckResourceAttr(
+					resource.TestCheckTypeSetElemNestedAttrs(
 						"propelauth_fe_integration.test",
-						"additional_fe_locations.0.domain",
-						"http://localhost:3002",
+						"additional_fe_locations.*",
+						map[string]string{"domain": "http://localhost:3002"},
 					),
 				),
 			},
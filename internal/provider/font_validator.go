@@ -0,0 +1,50 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"terraform-provider-propelauth/internal/propelauth"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+// supportedFonts are the font families available on the hosted pages, written in PascalCase.
+var supportedFonts = []string{
+	"Roboto", "Inter", "OpenSans", "Montserrat", "Lato", "Poppins", "Raleway", "Jost",
+	"Fraunces", "Caveat", "PlusJakartaSans",
+}
+
+var _ validator.String = fontValidator{}
+
+// fontValidator checks that a font is supported by the hosted pages. Unlike a plain OneOf validator,
+// it suggests the closest supported font so a typo doesn't ship a login page with fallback fonts.
+type fontValidator struct{}
+
+func (v fontValidator) Description(ctx context.Context) string {
+	return "value must be one of: " + strings.Join(supportedFonts, ", ")
+}
+
+func (v fontValidator) MarkdownDescription(ctx context.Context) string {
+	return "value must be one of: `" + strings.Join(supportedFonts, "`, `") + "`"
+}
+
+func (v fontValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	font := req.ConfigValue.ValueString()
+	if propelauth.Contains(supportedFonts, font) {
+		return
+	}
+
+	detail := fmt.Sprintf("`%s` is not a font supported by the hosted pages. Supported fonts are `%s`.",
+		font, strings.Join(supportedFonts, "`, `"))
+	if suggestion, ok := propelauth.ClosestMatch(supportedFonts, font, 3); ok {
+		detail = fmt.Sprintf("`%s` is not a font supported by the hosted pages. Did you mean `%s`?", font, suggestion)
+	}
+
+	resp.Diagnostics.AddAttributeError(req.Path, "Unsupported font", detail)
+}
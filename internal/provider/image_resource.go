@@ -57,9 +57,10 @@ func (r *imageResource) Schema(ctx context.Context, req resource.SchemaRequest,
 			"image_type": schema.StringAttribute{
 				Required: true,
 				Description: "The type of the image. This is used to determine where the image is used in PropelAuth. " +
-					"Accepted values are `logo`, `favicon`, `background`, `darkmode_logo`, or `darkmode_background`.",
+					"Accepted values are `logo`, `favicon`, `background`, `darkmode_logo`, `darkmode_background`, or `icon`. " +
+					"An `icon` is only uploaded, to be referenced by its `image_id` such as in a `propelauth_oidc_provider`.",
 				Validators: []validator.String{
-					stringvalidator.OneOf("logo", "favicon", "background", "darkmode_logo", "darkmode_background"),
+					stringvalidator.OneOf("logo", "favicon", "background", "darkmode_logo", "darkmode_background", "icon"),
 				},
 			},
 			"image_id": schema.StringAttribute{
@@ -117,6 +118,14 @@ func (r *imageResource) Create(ctx context.Context, req resource.CreateRequest,
 	// Save updated state into Terraform state
 	plan.ImageId = types.StringValue(imageUploadResponse.ImageId)
 
+	// icons aren't part of the environment config, they are referenced by their image_id instead
+	if plan.ImageType.ValueString() == "icon" {
+		plan.ImageUrl = types.StringNull()
+		tflog.Trace(ctx, "created a propelauth_image resource")
+		resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+		return
+	}
+
 	// Update the PropelAuth environment config
 	environmentConfigUpdate := propelauth.EnvironmentConfigUpdate{}
 	if plan.ImageType.ValueString() == "logo" {
@@ -216,6 +225,14 @@ func (r *imageResource) Update(ctx context.Context, req resource.UpdateRequest,
 	// Save updated state into Terraform state
 	plan.ImageId = types.StringValue(imageUploadResponse.ImageId)
 
+	// icons aren't part of the environment config, they are referenced by their image_id instead
+	if plan.ImageType.ValueString() == "icon" {
+		plan.ImageUrl = types.StringNull()
+		tflog.Trace(ctx, "updated a propelauth_image resource")
+		resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+		return
+	}
+
 	// Update the PropelAuth environment config
 	environmentConfigUpdate := propelauth.EnvironmentConfigUpdate{}
 	if plan.ImageType.ValueString() == "logo" {
@@ -0,0 +1,56 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// jsonObjectFromStringMap converts a map of strings, such as an organization's metadata, to the JSON object PropelAuth stores.
+func jsonObjectFromStringMap(ctx context.Context, value types.Map) (map[string]interface{}, diag.Diagnostics) {
+	stringValues := map[string]string{}
+	if !value.IsNull() {
+		diags := value.ElementsAs(ctx, &stringValues, false)
+		if diags.HasError() {
+			return nil, diags
+		}
+	}
+
+	object := make(map[string]interface{}, len(stringValues))
+	for key, stringValue := range stringValues {
+		object[key] = stringValue
+	}
+	return object, nil
+}
+
+// stringMapFromJsonObject converts a JSON object stored by PropelAuth to a map of strings. Values set outside of
+// Terraform may be any JSON, so values that aren't strings are kept in their JSON form. An empty object is
+// returned as the current value's null or empty map, so leaving it unset doesn't show a difference.
+func stringMapFromJsonObject(ctx context.Context, object map[string]interface{}, current types.Map) (types.Map, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	if len(object) == 0 {
+		if current.IsNull() {
+			return current, diags
+		}
+		return types.MapValueMust(types.StringType, map[string]attr.Value{}), diags
+	}
+
+	stringValues := make(map[string]string, len(object))
+	for key, value := range object {
+		if stringValue, ok := value.(string); ok {
+			stringValues[key] = stringValue
+			continue
+		}
+		jsonValue, err := json.Marshal(value)
+		if err != nil {
+			diags.AddError("Error reading a JSON value", err.Error())
+			return current, diags
+		}
+		stringValues[key] = string(jsonValue)
+	}
+	return types.MapValueFrom(ctx, types.StringType, stringValues)
+}
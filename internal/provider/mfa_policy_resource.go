@@ -0,0 +1,379 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"terraform-provider-propelauth/internal/propelauth"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &mfaPolicyResource{}
+var _ resource.ResourceWithConfigure = &mfaPolicyResource{}
+var _ resource.ResourceWithImportState = &mfaPolicyResource{}
+var _ resource.ResourceWithValidateConfig = &mfaPolicyResource{}
+
+func NewMfaPolicyResource() resource.Resource {
+	return &mfaPolicyResource{}
+}
+
+// mfaPolicyResource defines the resource implementation.
+type mfaPolicyResource struct {
+	client *propelauth.PropelAuthClient
+}
+
+// mfaPolicyResourceModel describes the resource data model.
+type mfaPolicyResourceModel struct {
+	AllowedFactors        types.Set     `tfsdk:"allowed_factors"`
+	RequireMfaForAllUsers types.Bool    `tfsdk:"require_mfa_for_all_users"`
+	OrgsCanRequireMfa     types.Bool    `tfsdk:"orgs_can_require_mfa"`
+	EnrollmentGracePeriod durationValue `tfsdk:"enrollment_grace_period"`
+	RecoveryCodesEnabled  types.Bool    `tfsdk:"recovery_codes_enabled"`
+	RecoveryCodeCount     types.Int64   `tfsdk:"recovery_code_count"`
+}
+
+// mfaFactors are the factors users can set up as their second factor.
+var mfaFactors = []string{"TOTP", "SMS", "Passkey"}
+
+func (r *mfaPolicyResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_mfa_policy"
+}
+
+func (r *mfaPolicyResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "MFA Policy resource. This is for configuring multifactor authentication for your project: which " +
+			"factors users can set up, who is required to use MFA, and how users recover their accounts. " +
+			"It replaces `has_phone_mfa` and `all_users_must_setup_2fa` of `propelauth_basic_auth_configuration` and " +
+			"`orgs_can_require_2fa` of `propelauth_organization_configuration`, which must not be set alongside it. " +
+			"Deleting the resource leaves the policy as it is.",
+		Attributes: map[string]schema.Attribute{
+			"allowed_factors": schema.SetAttribute{
+				Required:    true,
+				ElementType: types.StringType,
+				Validators: []validator.Set{
+					setvalidator.ValueStringsAre(stringvalidator.OneOf(mfaFactors...)),
+				},
+				Description: "The factors users can set up as their second factor. Accepted values are `TOTP` (an authenticator app), " +
+					"`SMS`, and `Passkey`. An empty set turns MFA off. " +
+					"See `https://docs.propelauth.com/overview/authentication/mfa#sms-mfa` for more information on setting up SMS MFA.",
+			},
+			"require_mfa_for_all_users": schema.BoolAttribute{
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+				Description: "If true, all users will be required to use multifactor authentication when logging in. " +
+					"The default setting is false. Note: This feature is only available on some pricing plans.",
+			},
+			"orgs_can_require_mfa": schema.BoolAttribute{
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+				Description: "If true, organizations can require their users to use multifactor authentication. " +
+					"The default setting is false. Warning: This is only applied in prod for some billing plans.",
+			},
+			"enrollment_grace_period": schema.StringAttribute{
+				CustomType: durationType{},
+				Optional:   true,
+				Validators: []validator.String{
+					durationBetweenValidator{min: time.Hour, max: 90 * 24 * time.Hour},
+				},
+				Description: "How long users who are required to use MFA can keep logging in without it, e.g. `7d`, " +
+					"between `1h` and `90d`. It starts when MFA becomes required for the user. " +
+					"If not set, users must set up MFA the next time they log in.",
+			},
+			"recovery_codes_enabled": schema.BoolAttribute{
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(true),
+				Description: "If true, users are given single-use recovery codes when they set up MFA, to log in if they " +
+					"lose their second factor. The default setting is true.",
+			},
+			"recovery_code_count": schema.Int64Attribute{
+				Optional: true,
+				Computed: true,
+				Default:  int64default.StaticInt64(10),
+				Validators: []validator.Int64{
+					int64validator.Between(5, 20),
+				},
+				Description: "The number of recovery codes users are given, between 5 and 20. The default setting is 10.",
+			},
+		},
+	}
+}
+
+func (r *mfaPolicyResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*propelauth.PropelAuthClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *propelauth.PropelAuthClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *mfaPolicyResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config mfaPolicyResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// a requirement is unknown until apply if it comes from another resource, in which case it's only checked then
+	requiresMfa := config.RequireMfaForAllUsers.ValueBool() || config.OrgsCanRequireMfa.ValueBool()
+	mayRequireMfa := requiresMfa || config.RequireMfaForAllUsers.IsUnknown() || config.OrgsCanRequireMfa.IsUnknown()
+
+	if requiresMfa && !config.AllowedFactors.IsUnknown() && len(config.AllowedFactors.Elements()) == 0 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("allowed_factors"),
+			"Invalid MFA policy",
+			"MFA can't be required while no factor is allowed, as users would have no way to set it up. "+
+				"Allow at least one factor in `allowed_factors`, or set `require_mfa_for_all_users` and `orgs_can_require_mfa` to false.",
+		)
+	}
+
+	if !config.EnrollmentGracePeriod.IsNull() && !mayRequireMfa {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("enrollment_grace_period"),
+			"Invalid MFA policy",
+			"`enrollment_grace_period` only applies when MFA is required. "+
+				"Set `require_mfa_for_all_users` or `orgs_can_require_mfa` to true, or remove `enrollment_grace_period`.",
+		)
+	}
+
+	if !config.RecoveryCodeCount.IsNull() && !config.RecoveryCodesEnabled.IsNull() && !config.RecoveryCodesEnabled.IsUnknown() &&
+		!config.RecoveryCodesEnabled.ValueBool() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("recovery_code_count"),
+			"Invalid MFA policy",
+			"`recovery_code_count` can't be set while `recovery_codes_enabled` is false.",
+		)
+	}
+
+	if config.RequireMfaForAllUsers.ValueBool() && !config.RecoveryCodesEnabled.IsNull() && !config.RecoveryCodesEnabled.IsUnknown() &&
+		!config.RecoveryCodesEnabled.ValueBool() {
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("recovery_codes_enabled"),
+			"Users may be locked out",
+			"MFA is required for all users while recovery codes are disabled, so users who lose their second factor "+
+				"can't log in until their MFA is reset from the dashboard or the API.",
+		)
+	}
+}
+
+func (r *mfaPolicyResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan mfaPolicyResourceModel
+
+	// Read Terraform plan data into the model
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.updateMfaPolicy(ctx, plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Write logs using the tflog package
+	// Documentation: https://terraform.io/plugin/log
+	tflog.Trace(ctx, "created a propelauth_mfa_policy resource")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *mfaPolicyResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// Get current state and read it into the model
+	var state mfaPolicyResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// retrieve the environment config from PropelAuth
+	environmentConfigResponse, err := r.client.GetEnvironmentConfig()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading PropelAuth MFA policy",
+			"Could not read PropelAuth MFA policy: "+err.Error(),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(updateMfaPolicyModel(ctx, &state, environmentConfigResponse)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save updated state into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *mfaPolicyResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan mfaPolicyResourceModel
+
+	// Read Terraform plan data into the model
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.updateMfaPolicy(ctx, plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Write logs using the tflog package
+	// Documentation: https://terraform.io/plugin/log
+	tflog.Trace(ctx, "updated a propelauth_mfa_policy resource")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *mfaPolicyResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	tflog.Trace(ctx, "deleted a propelauth_mfa_policy resource")
+}
+
+func (r *mfaPolicyResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	var state mfaPolicyResourceModel
+
+	// retrieve the environment config from PropelAuth
+	environmentConfigResponse, err := r.client.GetEnvironmentConfig()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Importing PropelAuth MFA policy",
+			"Could not read PropelAuth MFA policy: "+err.Error(),
+		)
+		return
+	}
+
+	// Save into the Terraform state all values from the dashboard.
+	resp.Diagnostics.Append(updateMfaPolicyModel(ctx, &state, environmentConfigResponse)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save updated state into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// updateMfaPolicy saves the planned MFA policy and checks that it was updated.
+func (r *mfaPolicyResource) updateMfaPolicy(ctx context.Context, plan mfaPolicyResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	allowedFactors := []string{}
+	diags.Append(plan.AllowedFactors.ElementsAs(ctx, &allowedFactors, false)...)
+	if diags.HasError() {
+		return diags
+	}
+
+	enrollmentGracePeriodSeconds := int64(0)
+	if seconds := plan.EnrollmentGracePeriod.Seconds(); seconds != nil {
+		enrollmentGracePeriodSeconds = *seconds
+	}
+
+	hasTotpMfa := slices.Contains(allowedFactors, "TOTP")
+	hasPhoneMfa := slices.Contains(allowedFactors, "SMS")
+	hasPasskeyMfa := slices.Contains(allowedFactors, "Passkey")
+	environmentConfigUpdate := propelauth.EnvironmentConfigUpdate{
+		HasTotpMfa:              &hasTotpMfa,
+		HasPhoneMfa:             &hasPhoneMfa,
+		HasPasskeyMfa:           &hasPasskeyMfa,
+		AllUsersMustSetup2fa:    plan.RequireMfaForAllUsers.ValueBoolPointer(),
+		OrgsCanRequire2fa:       plan.OrgsCanRequireMfa.ValueBoolPointer(),
+		MfaGracePeriodSeconds:   &enrollmentGracePeriodSeconds,
+		MfaRecoveryCodesEnabled: plan.RecoveryCodesEnabled.ValueBoolPointer(),
+		MfaRecoveryCodeCount:    plan.RecoveryCodeCount.ValueInt64Pointer(),
+	}
+
+	environmentConfigResponse, err := r.client.UpdateEnvironmentConfig(&environmentConfigUpdate)
+	if err != nil {
+		diags.AddError(
+			"Error setting MFA policy",
+			"Could not set MFA policy, unexpected error: "+err.Error(),
+		)
+		return diags
+	}
+
+	// Check that the policy was updated
+	var updated mfaPolicyResourceModel
+	diags.Append(updateMfaPolicyModel(ctx, &updated, environmentConfigResponse)...)
+	if diags.HasError() {
+		return diags
+	}
+	if !updated.AllowedFactors.Equal(plan.AllowedFactors) ||
+		updated.RequireMfaForAllUsers.ValueBool() != plan.RequireMfaForAllUsers.ValueBool() ||
+		updated.OrgsCanRequireMfa.ValueBool() != plan.OrgsCanRequireMfa.ValueBool() ||
+		environmentConfigResponse.MfaGracePeriodSeconds != enrollmentGracePeriodSeconds ||
+		updated.RecoveryCodesEnabled.ValueBool() != plan.RecoveryCodesEnabled.ValueBool() ||
+		updated.RecoveryCodeCount.ValueInt64() != plan.RecoveryCodeCount.ValueInt64() {
+		diags.AddError(
+			"Error updating MFA policy",
+			fmt.Sprintf("The MFA policy failed to update. The allowed factors are instead %s, require_mfa_for_all_users is %t, "+
+				"orgs_can_require_mfa is %t, the enrollment grace period is %ds, recovery_codes_enabled is %t, "+
+				"and recovery_code_count is %d. Check that your pricing plan includes these MFA features.",
+				updated.AllowedFactors.String(), environmentConfigResponse.AllUsersMustSetup2fa, environmentConfigResponse.OrgsCanRequire2fa,
+				environmentConfigResponse.MfaGracePeriodSeconds, environmentConfigResponse.MfaRecoveryCodesEnabled,
+				environmentConfigResponse.MfaRecoveryCodeCount),
+		)
+	}
+
+	return diags
+}
+
+// updateMfaPolicyModel sets the whole MFA policy from the environment config, as the resource owns all of it.
+func updateMfaPolicyModel(ctx context.Context, model *mfaPolicyResourceModel, environmentConfig *propelauth.EnvironmentConfigResponse) diag.Diagnostics {
+	allowedFactors := []string{}
+	for factor, isAllowed := range map[string]bool{
+		"TOTP":    environmentConfig.HasTotpMfa,
+		"SMS":     environmentConfig.HasPhoneMfa,
+		"Passkey": environmentConfig.HasPasskeyMfa,
+	} {
+		if isAllowed {
+			allowedFactors = append(allowedFactors, factor)
+		}
+	}
+
+	allowedFactorsSet, diags := types.SetValueFrom(ctx, types.StringType, allowedFactors)
+	model.AllowedFactors = allowedFactorsSet
+	model.RequireMfaForAllUsers = types.BoolValue(environmentConfig.AllUsersMustSetup2fa)
+	model.OrgsCanRequireMfa = types.BoolValue(environmentConfig.OrgsCanRequire2fa)
+	model.RecoveryCodesEnabled = types.BoolValue(environmentConfig.MfaRecoveryCodesEnabled)
+	model.RecoveryCodeCount = types.Int64Value(environmentConfig.MfaRecoveryCodeCount)
+	// no grace period is written as an unset enrollment_grace_period
+	if environmentConfig.MfaGracePeriodSeconds == 0 {
+		model.EnrollmentGracePeriod = durationValue{StringValue: types.StringNull()}
+	} else {
+		model.EnrollmentGracePeriod = newDurationValue(environmentConfig.MfaGracePeriodSeconds)
+	}
+
+	return diags
+}
@@ -0,0 +1,60 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccMfaPolicyResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Requiring MFA without any factor is rejected when planning
+			{
+				Config:      testAccMfaPolicyResourceConfig(`[]`, true),
+				ExpectError: regexp.MustCompile("MFA can't be required while no factor is allowed"),
+			},
+			// Create and Read testing
+			{
+				Config: testAccMfaPolicyResourceConfig(`["TOTP", "Passkey"]`, true),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("propelauth_mfa_policy.test", "allowed_factors.#", "2"),
+					resource.TestCheckTypeSetElemAttr("propelauth_mfa_policy.test", "allowed_factors.*", "Passkey"),
+					resource.TestCheckResourceAttr("propelauth_mfa_policy.test", "require_mfa_for_all_users", "true"),
+					resource.TestCheckResourceAttr("propelauth_mfa_policy.test", "enrollment_grace_period", "7d"),
+					resource.TestCheckResourceAttr("propelauth_mfa_policy.test", "recovery_codes_enabled", "true"),
+					resource.TestCheckResourceAttr("propelauth_mfa_policy.test", "recovery_code_count", "10"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:                         "propelauth_mfa_policy.test",
+				ImportState:                          true,
+				ImportStateId:                        "arbitrary_string_here",
+				ImportStateVerify:                    true,
+				ImportStateVerifyIdentifierAttribute: "require_mfa_for_all_users",
+			},
+			// Update and Read testing
+			{
+				Config: testAccMfaPolicyResourceConfig(`["TOTP", "SMS"]`, true),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckTypeSetElemAttr("propelauth_mfa_policy.test", "allowed_factors.*", "SMS"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccMfaPolicyResourceConfig(allowedFactors string, requireMfaForAllUsers bool) string {
+	return providerConfig + fmt.Sprintf(`
+resource "propelauth_mfa_policy" "test" {
+  allowed_factors           = %[1]s
+  require_mfa_for_all_users = %[2]t
+  enrollment_grace_period   = "7d"
+}
+`, allowedFactors, requireMfaForAllUsers)
+}
@@ -0,0 +1,32 @@
+package provider
+
+import (
+	"context"
+
+	"terraform-provider-propelauth/internal/propelauth"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+var _ validator.String = oidcIssuerValidator{}
+
+// oidcIssuerValidator checks that an OpenID Connect issuer is a well-formed https URL, before its discovery document is fetched.
+type oidcIssuerValidator struct{}
+
+func (v oidcIssuerValidator) Description(ctx context.Context) string {
+	return "value must be an https URL without a query or fragment"
+}
+
+func (v oidcIssuerValidator) MarkdownDescription(ctx context.Context) string {
+	return "value must be an `https` URL without a query or fragment"
+}
+
+func (v oidcIssuerValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if err := propelauth.ValidateOidcIssuerUrl(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid OIDC issuer", err.Error())
+	}
+}
@@ -0,0 +1,104 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"terraform-provider-propelauth/internal/propelauth"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource = &oidcProviderRedirectDataSource{}
+)
+
+// NewOidcProviderRedirectDataSource is a helper function to simplify the provider implementation.
+func NewOidcProviderRedirectDataSource() datasource.DataSource {
+	return &oidcProviderRedirectDataSource{}
+}
+
+type oidcProviderRedirectDataSource struct {
+	client *propelauth.PropelAuthClient
+}
+
+type oidcProviderRedirectDataSourceModel struct {
+	Environment    types.String `tfsdk:"environment"`
+	OidcProviderId types.String `tfsdk:"oidc_provider_id"`
+	RedirectUrl    types.String `tfsdk:"redirect_url"`
+}
+
+// Metadata returns the data source type name.
+func (d *oidcProviderRedirectDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_oidc_provider_redirect"
+}
+
+// Schema defines the schema for the data source.
+func (d *oidcProviderRedirectDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Retrieves the redirect url needed for registering PropelAuth as a client of an OIDC provider.",
+		Attributes: map[string]schema.Attribute{
+			"environment": schema.StringAttribute{
+				Required: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("Test", "Staging", "Prod"),
+				},
+				Description: "The environment for which you are configuring the OIDC provider. Accepted values are `Test`, `Staging`, and `Prod`.",
+			},
+			"oidc_provider_id": schema.StringAttribute{
+				Required:    true,
+				Description: "The `id` of the `propelauth_oidc_provider` for which you need the redirect URL.",
+			},
+			"redirect_url": schema.StringAttribute{
+				Computed:    true,
+				Description: "The redirect URL to be white-listed in the oauth client registered with the identity provider.",
+			},
+		},
+	}
+}
+
+func (d *oidcProviderRedirectDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*propelauth.PropelAuthClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data source Configure Type",
+			fmt.Sprintf("Expected *propelauth.PropelAuthClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *oidcProviderRedirectDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state oidcProviderRedirectDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Fetch the data from the PropelAuth API
+	redirectUrl, err := d.client.GetOidcProviderRedirectUrl(state.Environment.ValueString(), state.OidcProviderId.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to fetch OIDC provider redirect url from PropelAuth API", err.Error())
+		return
+	}
+	state.RedirectUrl = types.StringValue(*redirectUrl)
+
+	// Write the data to the Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
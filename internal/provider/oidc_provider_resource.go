@@ -0,0 +1,401 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"time"
+
+	"terraform-provider-propelauth/internal/propelauth"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &oidcProviderResource{}
+var _ resource.ResourceWithConfigure = &oidcProviderResource{}
+var _ resource.ResourceWithImportState = &oidcProviderResource{}
+var _ resource.ResourceWithValidateConfig = &oidcProviderResource{}
+
+func NewOidcProviderResource() resource.Resource {
+	return &oidcProviderResource{}
+}
+
+// oidcProviderResource defines the resource implementation.
+type oidcProviderResource struct {
+	client *propelauth.PropelAuthClient
+}
+
+// oidcProviderResourceModel describes the resource data model.
+type oidcProviderResourceModel struct {
+	Id                    types.String `tfsdk:"id"`
+	Issuer                types.String `tfsdk:"issuer"`
+	ClientId              types.String `tfsdk:"client_id"`
+	ClientSecret          types.String `tfsdk:"client_secret"`
+	Scopes                types.Set    `tfsdk:"scopes"`
+	ClaimMappings         types.Map    `tfsdk:"claim_mappings"`
+	ButtonLabel           types.String `tfsdk:"button_label"`
+	IconImageId           types.String `tfsdk:"icon_image_id"`
+	AuthorizationEndpoint types.String `tfsdk:"authorization_endpoint"`
+	TokenEndpoint         types.String `tfsdk:"token_endpoint"`
+	UserinfoEndpoint      types.String `tfsdk:"userinfo_endpoint"`
+	JwksUri               types.String `tfsdk:"jwks_uri"`
+}
+
+func (r *oidcProviderResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_oidc_provider"
+}
+
+func (r *oidcProviderResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	userPropertyNameRegex := regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+	resp.Schema = schema.Schema{
+		Description: "OIDC Provider resource. This is for federating login with an OpenID Connect identity provider " +
+			"that isn't one of the built-in social logins, such as Keycloak or Okta.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Description: "The ID of the OIDC provider set by PropelAuth.",
+			},
+			"issuer": schema.StringAttribute{
+				Required: true,
+				Validators: []validator.String{
+					oidcIssuerValidator{},
+				},
+				Description: "The issuer URL of the identity provider, e.g. `https://keycloak.example.com/realms/employees`. " +
+					"It must be an https URL, and its endpoints are discovered from `<issuer>/.well-known/openid-configuration` " +
+					"when the provider is created and whenever `issuer` changes.",
+			},
+			"client_id": schema.StringAttribute{
+				Required:    true,
+				Description: "The client ID of the oauth client registered with the identity provider.",
+			},
+			"client_secret": schema.StringAttribute{
+				Required:    true,
+				Sensitive:   true,
+				Description: "The client secret of the oauth client registered with the identity provider.",
+			},
+			"scopes": schema.SetAttribute{
+				Optional:    true,
+				Computed:    true,
+				ElementType: types.StringType,
+				Default: setdefault.StaticValue(types.SetValueMust(types.StringType, []attr.Value{
+					types.StringValue("openid"),
+					types.StringValue("email"),
+					types.StringValue("profile"),
+				})),
+				Validators: []validator.Set{
+					setvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
+				},
+				Description: "The scopes to request from the identity provider. They must include `openid`. " +
+					"The default is `openid`, `email` and `profile`.",
+			},
+			"claim_mappings": schema.MapAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Validators: []validator.Map{
+					mapvalidator.KeysAre(stringvalidator.RegexMatches(userPropertyNameRegex, "must be the name of a user property")),
+					mapvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
+				},
+				Description: "Maps user properties to the claims that fill them, e.g. `{ first_name = \"given_name\" }`. " +
+					"The `email` claim is always used for the user's email.",
+			},
+			"button_label": schema.StringAttribute{
+				Required: true,
+				Validators: []validator.String{
+					stringvalidator.LengthBetween(1, 40),
+				},
+				Description: "The label of the login button for this provider on the hosted pages, e.g. `Log in with Acme SSO`.",
+			},
+			"icon_image_id": schema.StringAttribute{
+				Optional: true,
+				Description: "The ID of the icon shown on the login button. This is the `image_id` of a `propelauth_image` " +
+					"with the `icon` image type.",
+			},
+			"authorization_endpoint": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					useStateForUnknownUnlessChangedModifier{paths: []path.Path{path.Root("issuer")}},
+				},
+				Description: "The authorization endpoint discovered from the issuer.",
+			},
+			"token_endpoint": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					useStateForUnknownUnlessChangedModifier{paths: []path.Path{path.Root("issuer")}},
+				},
+				Description: "The token endpoint discovered from the issuer.",
+			},
+			"userinfo_endpoint": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					useStateForUnknownUnlessChangedModifier{paths: []path.Path{path.Root("issuer")}},
+				},
+				Description: "The userinfo endpoint discovered from the issuer, if it has one.",
+			},
+			"jwks_uri": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					useStateForUnknownUnlessChangedModifier{paths: []path.Path{path.Root("issuer")}},
+				},
+				Description: "The URL of the identity provider's signing keys, discovered from the issuer.",
+			},
+		},
+	}
+}
+
+func (r *oidcProviderResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*propelauth.PropelAuthClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *propelauth.PropelAuthClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *oidcProviderResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var scopes types.Set
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("scopes"), &scopes)...)
+	if resp.Diagnostics.HasError() || scopes.IsNull() || scopes.IsUnknown() {
+		return
+	}
+
+	for _, element := range scopes.Elements() {
+		if scope, ok := element.(types.String); ok && (scope.IsUnknown() || scope.ValueString() == "openid") {
+			return
+		}
+	}
+	resp.Diagnostics.AddAttributeError(
+		path.Root("scopes"),
+		"Missing openid scope",
+		"`scopes` must include `openid`, otherwise the identity provider doesn't return an ID token.",
+	)
+}
+
+func (r *oidcProviderResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan oidcProviderResourceModel
+
+	// Read Terraform plan data into the model
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	request, diags := oidcProviderRequestFromPlan(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// create the OIDC provider
+	oidcProvider, err := r.client.CreateOidcProvider(*request)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating PropelAuth OIDC Provider",
+			"Could not create OIDC provider, unexpected error: "+err.Error(),
+		)
+		return
+	}
+	plan.Id = types.StringValue(oidcProvider.OidcProviderId)
+
+	// Write logs using the tflog package
+	// Documentation: https://terraform.io/plugin/log
+	tflog.Trace(ctx, "created a propelauth_oidc_provider resource")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *oidcProviderResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// Get current state and read it into the model
+	var state oidcProviderResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// retrieve the OIDC provider from PropelAuth
+	oidcProvider, err := r.client.GetOidcProvider(state.Id.ValueString())
+	if err != nil {
+		// If error is "not_found", it indicates that the resource should be deleted.
+		if propelauth.IsPropelAuthNotFoundError(err) {
+			tflog.Trace(ctx, "deleting a propelauth_oidc_provider resource because it was not found in PropelAuth")
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Error Reading PropelAuth OIDC Provider",
+			"Could not read PropelAuth OIDC Provider: "+err.Error(),
+		)
+		return
+	}
+
+	// update the state for the OIDC provider, the client secret isn't returned
+	state.Issuer = types.StringValue(oidcProvider.Issuer)
+	state.ClientId = types.StringValue(oidcProvider.ClientId)
+	state.ButtonLabel = types.StringValue(oidcProvider.ButtonLabel)
+	state.IconImageId = types.StringPointerValue(oidcProvider.IconImageId)
+	state.AuthorizationEndpoint = types.StringValue(oidcProvider.AuthorizationEndpoint)
+	state.TokenEndpoint = types.StringValue(oidcProvider.TokenEndpoint)
+	state.UserinfoEndpoint = types.StringValue(oidcProvider.UserinfoEndpoint)
+	state.JwksUri = types.StringValue(oidcProvider.JwksUri)
+
+	scopes, diags := types.SetValueFrom(ctx, types.StringType, oidcProvider.Scopes)
+	resp.Diagnostics.Append(diags...)
+	state.Scopes = scopes
+
+	if len(oidcProvider.ClaimMappings) > 0 || !state.ClaimMappings.IsNull() {
+		claimMappings, diags := types.MapValueFrom(ctx, types.StringType, oidcProvider.ClaimMappings)
+		resp.Diagnostics.Append(diags...)
+		state.ClaimMappings = claimMappings
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save updated state into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *oidcProviderResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Read Terraform plan data into the model
+	var plan oidcProviderResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	request, diags := oidcProviderRequestFromPlan(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Update the OIDC provider
+	err := r.client.UpdateOidcProvider(plan.Id.ValueString(), *request)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating OIDC provider",
+			"Could not update the OIDC provider, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	tflog.Trace(ctx, "updated a propelauth_oidc_provider resource")
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *oidcProviderResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state oidcProviderResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Delete existing OIDC provider
+	err := r.client.DeleteOidcProvider(state.Id.ValueString())
+	if err != nil && !propelauth.IsPropelAuthNotFoundError(err) {
+		resp.Diagnostics.AddError(
+			"Error Deleting PropelAuth OIDC Provider",
+			"Could not delete OIDC provider, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	tflog.Trace(ctx, "deleted a propelauth_oidc_provider resource")
+}
+
+func (r *oidcProviderResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// oidcProviderRequestFromPlan discovers the issuer's endpoints, stores the ones that aren't planned yet in the plan and
+// builds the request for PropelAuth.
+func oidcProviderRequestFromPlan(ctx context.Context, plan *oidcProviderResourceModel) (*propelauth.OidcProviderRequest, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	document, err := propelauth.DiscoverOidcIssuer(ctx, &http.Client{Timeout: 10 * time.Second}, plan.Issuer.ValueString())
+	if err != nil {
+		diags.AddAttributeError(
+			path.Root("issuer"),
+			"Error discovering the OIDC issuer",
+			"Could not discover the endpoints of the identity provider: "+err.Error(),
+		)
+		return nil, diags
+	}
+	// endpoints kept from state while the issuer is unchanged are saved as planned
+	for endpoint, discovered := range map[*types.String]string{
+		&plan.AuthorizationEndpoint: document.AuthorizationEndpoint,
+		&plan.TokenEndpoint:         document.TokenEndpoint,
+		&plan.UserinfoEndpoint:      document.UserinfoEndpoint,
+		&plan.JwksUri:               document.JwksUri,
+	} {
+		if endpoint.IsUnknown() || endpoint.IsNull() {
+			*endpoint = types.StringValue(discovered)
+		}
+	}
+
+	scopes := []string{}
+	diags.Append(plan.Scopes.ElementsAs(ctx, &scopes, false)...)
+	claimMappings := map[string]string{}
+	if !plan.ClaimMappings.IsNull() {
+		diags.Append(plan.ClaimMappings.ElementsAs(ctx, &claimMappings, false)...)
+	}
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	for _, scope := range scopes {
+		if len(document.ScopesSupported) > 0 && !propelauth.Contains(document.ScopesSupported, scope) {
+			diags.AddAttributeWarning(
+				path.Root("scopes"),
+				"Scope not advertised by the identity provider",
+				fmt.Sprintf("The identity provider doesn't list `%s` in its supported scopes, so it may reject the login.", scope),
+			)
+		}
+	}
+
+	return &propelauth.OidcProviderRequest{
+		Issuer:                plan.Issuer.ValueString(),
+		AuthorizationEndpoint: plan.AuthorizationEndpoint.ValueString(),
+		TokenEndpoint:         plan.TokenEndpoint.ValueString(),
+		UserinfoEndpoint:      plan.UserinfoEndpoint.ValueString(),
+		JwksUri:               plan.JwksUri.ValueString(),
+		ClientId:              plan.ClientId.ValueString(),
+		ClientSecret:          plan.ClientSecret.ValueString(),
+		Scopes:                scopes,
+		ClaimMappings:         claimMappings,
+		ButtonLabel:           plan.ButtonLabel.ValueString(),
+		IconImageId:           plan.IconImageId.ValueStringPointer(),
+	}, diags
+}
@@ -0,0 +1,57 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccOidcProviderResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Scopes without openid are rejected when planning
+			{
+				Config:      testAccOidcProviderResourceConfig(`["email"]`, "Log in with Google"),
+				ExpectError: regexp.MustCompile("Missing openid scope"),
+			},
+			// Create and Read testing
+			{
+				Config: testAccOidcProviderResourceConfig(`["openid", "email"]`, "Log in with Google"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("propelauth_oidc_provider.test", "id"),
+					resource.TestCheckResourceAttr("propelauth_oidc_provider.test", "authorization_endpoint", "https://accounts.google.com/o/oauth2/v2/auth"),
+					resource.TestCheckResourceAttr("propelauth_oidc_provider.test", "jwks_uri", "https://www.googleapis.com/oauth2/v3/certs"),
+					resource.TestCheckResourceAttr("propelauth_oidc_provider.test", "scopes.#", "2"),
+				),
+			},
+			// Update and Read testing
+			{
+				Config: testAccOidcProviderResourceConfig(`["openid", "email", "profile"]`, "Log in with Google SSO"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("propelauth_oidc_provider.test", "button_label", "Log in with Google SSO"),
+					resource.TestCheckResourceAttr("propelauth_oidc_provider.test", "token_endpoint", "https://oauth2.googleapis.com/token"),
+					resource.TestCheckResourceAttr("propelauth_oidc_provider.test", "scopes.#", "3"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccOidcProviderResourceConfig(scopes string, buttonLabel string) string {
+	return providerConfig + fmt.Sprintf(`
+resource "propelauth_oidc_provider" "test" {
+  issuer        = "https://accounts.google.com"
+  client_id     = "client-id"
+  client_secret = "SECRET"
+  scopes        = %[1]s
+  claim_mappings = {
+    first_name = "given_name"
+  }
+  button_label = %[2]q
+}
+`, scopes, buttonLabel)
+}
@@ -0,0 +1,356 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"terraform-provider-propelauth/internal/propelauth"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &orgMembershipResource{}
+var _ resource.ResourceWithConfigure = &orgMembershipResource{}
+var _ resource.ResourceWithImportState = &orgMembershipResource{}
+var _ resource.ResourceWithValidateConfig = &orgMembershipResource{}
+
+func NewOrgMembershipResource() resource.Resource {
+	return &orgMembershipResource{}
+}
+
+// orgMembershipResource defines the resource implementation.
+type orgMembershipResource struct {
+	client *propelauth.PropelAuthClient
+}
+
+// orgMembershipResourceModel describes the resource data model.
+type orgMembershipResourceModel struct {
+	Id              types.String `tfsdk:"id"`
+	Environment     types.String `tfsdk:"environment"`
+	AllowInProd     types.Bool   `tfsdk:"allow_in_prod"`
+	OrgId           types.String `tfsdk:"org_id"`
+	UserId          types.String `tfsdk:"user_id"`
+	Role            types.String `tfsdk:"role"`
+	AdditionalRoles types.Set    `tfsdk:"additional_roles"`
+}
+
+func (r *orgMembershipResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_org_membership"
+}
+
+func (r *orgMembershipResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Org Membership resource. This is for seeding the membership of a user in an organization, such as the " +
+			"accounts of end-to-end tests. It is rejected in the `Prod` environment unless `allow_in_prod` is set, and needs " +
+			"a BE API key of the environment in `backend_api_keys`.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Description: "The ID of the membership, which is `<org_id>/<user_id>`.",
+			},
+			"environment": schema.StringAttribute{
+				Required: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("Test", "Staging", "Prod"),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Description: "The environment of the organization and user. Accepted values are `Test`, `Staging`, and `Prod`.",
+			},
+			"allow_in_prod": schema.BoolAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+				Description: "Must be true for the membership to be in the `Prod` environment. The default is false.",
+			},
+			"org_id": schema.StringAttribute{
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Description: "The ID of the organization.",
+			},
+			"user_id": schema.StringAttribute{
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Description: "The ID of the user.",
+			},
+			"role": schema.StringAttribute{
+				Required: true,
+				Description: "The role of the user in the organization. It must be one of the roles in " +
+					"`propelauth_roles_and_permissions`, and is checked against them when the membership is saved. " +
+					"If the organization has a `custom_role_mapping_name`, it must be one of the roles of that mapping instead, " +
+					"which isn't checked by the provider.",
+			},
+			"additional_roles": schema.SetAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Validators: []validator.Set{
+					setvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
+				},
+				Description: "More roles of the user in the organization. These can only be given when `multiple_roles_per_user` " +
+					"is enabled in `propelauth_roles_and_permissions`.",
+			},
+		},
+	}
+}
+
+func (r *orgMembershipResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*propelauth.PropelAuthClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *propelauth.PropelAuthClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *orgMembershipResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config orgMembershipResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	validateSeedDataEnvironment("propelauth_org_membership", config.Environment, config.AllowInProd, &resp.Diagnostics)
+}
+
+func (r *orgMembershipResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan orgMembershipResourceModel
+
+	// Read Terraform plan data into the model
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	backendClient, request, diags := r.membershipRequestFromPlan(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := backendClient.AddUserToOrg(*request)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating org membership",
+			"Could not add the user to the organization, unexpected error: "+err.Error(),
+		)
+		return
+	}
+	plan.Id = types.StringValue(plan.OrgId.ValueString() + "/" + plan.UserId.ValueString())
+
+	// Write logs using the tflog package
+	// Documentation: https://terraform.io/plugin/log
+	tflog.Trace(ctx, "created a propelauth_org_membership resource")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *orgMembershipResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// Get current state and read it into the model
+	var state orgMembershipResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	backendClient, err := r.client.BackendClient(state.Environment.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Error Reading PropelAuth Org Membership", err.Error())
+		return
+	}
+
+	user, err := backendClient.GetUser(state.UserId.ValueString())
+	if err != nil {
+		// If error is "not_found", it indicates that the resource should be deleted.
+		if propelauth.IsPropelAuthNotFoundError(err) {
+			tflog.Trace(ctx, "deleting a propelauth_org_membership resource because its user was not found in PropelAuth")
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Error Reading PropelAuth Org Membership",
+			"Could not read PropelAuth Org Membership: "+err.Error(),
+		)
+		return
+	}
+
+	membership, ok := user.OrgIdToOrgInfo[state.OrgId.ValueString()]
+	if !ok {
+		tflog.Trace(ctx, "deleting a propelauth_org_membership resource because the user is no longer in the organization")
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	state.Id = types.StringValue(state.OrgId.ValueString() + "/" + state.UserId.ValueString())
+	state.Role = types.StringValue(membership.UserRole)
+	if len(membership.AdditionalRoles) > 0 || !state.AdditionalRoles.IsNull() {
+		additionalRoles, diags := types.SetValueFrom(ctx, types.StringType, membership.AdditionalRoles)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		state.AdditionalRoles = additionalRoles
+	}
+
+	// Save updated state into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *orgMembershipResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Read Terraform plan data into the model
+	var plan orgMembershipResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	backendClient, request, diags := r.membershipRequestFromPlan(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := backendClient.ChangeUserRoleInOrg(*request)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating org membership",
+			"Could not change the user's roles in the organization, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	tflog.Trace(ctx, "updated a propelauth_org_membership resource")
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *orgMembershipResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state orgMembershipResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	backendClient, err := r.client.BackendClient(state.Environment.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Error deleting org membership", err.Error())
+		return
+	}
+
+	// Remove the user from the organization
+	err = backendClient.RemoveUserFromOrg(state.UserId.ValueString(), state.OrgId.ValueString())
+	if err != nil && !propelauth.IsPropelAuthNotFoundError(err) {
+		resp.Diagnostics.AddError(
+			"Error deleting org membership",
+			"Could not remove the user from the organization, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	tflog.Trace(ctx, "deleted a propelauth_org_membership resource")
+}
+
+func (r *orgMembershipResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	parts := strings.Split(req.ID, "/")
+	if len(parts) != 3 || (parts[0] != "Test" && parts[0] != "Staging" && parts[0] != "Prod") || parts[1] == "" || parts[2] == "" {
+		resp.Diagnostics.AddError(
+			"Invalid import ID",
+			fmt.Sprintf("Expected an import ID of the form `<environment>/<org_id>/<user_id>`, "+
+				"e.g. `Test/1189c444-8a2d-4c41-8b4b-ae43ce79a492/31c41c16-c281-44ae-9602-8a047e3bf33d`, got: %s", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("environment"), parts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("org_id"), parts[1])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("user_id"), parts[2])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("allow_in_prod"), parts[0] == "Prod")...)
+}
+
+// membershipRequestFromPlan checks the planned roles against the project's roles and builds the request to save them.
+// The roles of an organization with a custom role mapping aren't checked.
+func (r *orgMembershipResource) membershipRequestFromPlan(ctx context.Context, plan orgMembershipResourceModel) (*propelauth.PropelAuthBackendClient, *propelauth.OrgMembershipRequest, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	additionalRoles := []string{}
+	if !plan.AdditionalRoles.IsNull() {
+		diags.Append(plan.AdditionalRoles.ElementsAs(ctx, &additionalRoles, false)...)
+		if diags.HasError() {
+			return nil, nil, diags
+		}
+	}
+
+	backendClient, err := r.client.BackendClient(plan.Environment.ValueString())
+	if err != nil {
+		diags.AddError("Error saving org membership", err.Error())
+		return nil, nil, diags
+	}
+
+	// an organization with a custom role mapping has its own roles, which can't be read to check against
+	organization, err := backendClient.GetOrganization(plan.OrgId.ValueString())
+	if err != nil {
+		diags.AddError(
+			"Error reading organization",
+			"Could not read the organization to check the membership's roles against: "+err.Error(),
+		)
+		return nil, nil, diags
+	}
+	if organization.CustomRoleMappingName != nil {
+		tflog.Debug(ctx, "skipping the role check of a propelauth_org_membership in an organization with a custom role mapping")
+	} else {
+		// roles are read when the membership is saved, so that roles added in the same apply are known
+		rolesAndPermissions, err := r.client.GetRolesAndPermissions()
+		if err != nil {
+			diags.AddError(
+				"Error reading roles and permissions",
+				"Could not read the roles to check the membership's roles against: "+err.Error(),
+			)
+			return nil, nil, diags
+		}
+		err = rolesAndPermissions.ValidateOrgRoles(plan.Role.ValueString(), additionalRoles)
+		if err != nil {
+			diags.AddAttributeError(path.Root("role"), "Invalid org role", err.Error())
+			return nil, nil, diags
+		}
+	}
+
+	return backendClient, &propelauth.OrgMembershipRequest{
+		UserId:          plan.UserId.ValueString(),
+		OrgId:           plan.OrgId.ValueString(),
+		Role:            plan.Role.ValueString(),
+		AdditionalRoles: additionalRoles,
+	}, diags
+}
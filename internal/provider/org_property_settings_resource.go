@@ -0,0 +1,399 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"terraform-provider-propelauth/internal/propelauth"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &orgPropertySettingsResource{}
+var _ resource.ResourceWithConfigure = &orgPropertySettingsResource{}
+var _ resource.ResourceWithImportState = &orgPropertySettingsResource{}
+var _ resource.ResourceWithValidateConfig = &orgPropertySettingsResource{}
+
+func NewOrgPropertySettingsResource() resource.Resource {
+	return &orgPropertySettingsResource{}
+}
+
+// orgPropertySettingsResource defines the resource implementation.
+type orgPropertySettingsResource struct {
+	client *propelauth.PropelAuthClient
+}
+
+// orgPropertySettingsResourceModel describes the resource data model.
+type orgPropertySettingsResourceModel struct {
+	Properties []orgPropertyModel `tfsdk:"properties"`
+}
+
+type orgPropertyModel struct {
+	Name         types.String   `tfsdk:"name"`
+	DisplayName  types.String   `tfsdk:"display_name"`
+	FieldType    types.String   `tfsdk:"field_type"`
+	Required     types.Bool     `tfsdk:"required"`
+	InJwt        types.Bool     `tfsdk:"in_jwt"`
+	UserWritable types.String   `tfsdk:"user_writable"`
+	EnumValues   []types.String `tfsdk:"enum_values"`
+}
+
+func (r *orgPropertySettingsResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_org_property_settings"
+}
+
+func (r *orgPropertySettingsResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Org Property Settings. This is for configuring the typed properties stored on organizations, " +
+			"such as their plan tier or region.",
+		Attributes: map[string]schema.Attribute{
+			"properties": schema.ListNestedAttribute{
+				Optional: true,
+				Description: "Properties for organizations. If no blocks are provided, no org properties will be enabled. " +
+					"Note: Org properties are only available on some pricing plans.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Required: true,
+							Validators: []validator.String{
+								stringvalidator.RegexMatches(regexp.MustCompile(`^[a-z][a-z0-9_]*$`),
+									"must start with a lowercase letter and only contain lowercase letters, digits and underscores"),
+							},
+							Description: "The field name used to identify the property in the API and SDKs (e.g. plan_tier). " +
+								"It cannot be changed after creation.",
+						},
+						"display_name": schema.StringAttribute{
+							Required:    true,
+							Description: "The field name org admins see in the UI for the property.",
+						},
+						"field_type": schema.StringAttribute{
+							Required: true,
+							Description: "The type of the field. Accepted values are `Checkbox`, `Date`, `Enum`, " +
+								"`Integer`, `Json`, `LongText`, `Text`, `Toggle`, and `Url`. Once set, this cannot be changed.",
+							Validators: []validator.String{
+								stringvalidator.OneOf("Checkbox", "Date", "Enum", "Integer", "Json", "LongText", "Text", "Toggle", "Url"),
+							},
+						},
+						"required": schema.BoolAttribute{
+							Optional: true,
+							Computed: true,
+							Default:  booldefault.StaticBool(false),
+							Description: "Whether every organization must have a value for the property. " +
+								"The default value is `false`.",
+						},
+						"in_jwt": schema.BoolAttribute{
+							Optional: true,
+							Computed: true,
+							Default:  booldefault.StaticBool(false),
+							Description: "Whether the property should be included in the organization's information in the " +
+								"tokens of its members. The default value is `false`.",
+						},
+						"user_writable": schema.StringAttribute{
+							Optional: true,
+							Computed: true,
+							Default:  stringdefault.StaticString("Read"),
+							Validators: []validator.String{
+								stringvalidator.OneOf("Write", "Read", "WriteIfUnset"),
+							},
+							Description: "This setting determines whether org admins can edit the value of the property " +
+								"and how many times. Options are `Write`, `Read`, and `WriteIfUnset`. With `Read`, only " +
+								"your backend can set it. The default value is `Read`",
+						},
+						"enum_values": schema.ListAttribute{
+							Optional: true,
+							Validators: []validator.List{
+								listvalidator.UniqueValues(),
+								listvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
+							},
+							Description: "A list of possible values for the property. This is only required for the `Enum` field type.",
+							ElementType: types.StringType,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r *orgPropertySettingsResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*propelauth.PropelAuthClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *propelauth.PropelAuthClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *orgPropertySettingsResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config orgPropertySettingsResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	names := map[string]bool{}
+	for i, property := range config.Properties {
+		propertyPath := path.Root("properties").AtListIndex(i)
+
+		if !property.Name.IsUnknown() {
+			if names[property.Name.ValueString()] {
+				resp.Diagnostics.AddAttributeError(
+					propertyPath.AtName("name"),
+					"Duplicate org property",
+					fmt.Sprintf("The org property %s is defined more than once.", property.Name.ValueString()),
+				)
+			}
+			names[property.Name.ValueString()] = true
+		}
+
+		if property.FieldType.IsUnknown() {
+			continue
+		}
+		if property.FieldType.ValueString() == "Enum" && len(property.EnumValues) == 0 {
+			resp.Diagnostics.AddAttributeError(
+				propertyPath.AtName("enum_values"),
+				"Missing enum values",
+				"An org property with the `Enum` field type needs at least one value in `enum_values`.",
+			)
+		} else if property.FieldType.ValueString() != "Enum" && property.EnumValues != nil {
+			resp.Diagnostics.AddAttributeError(
+				propertyPath.AtName("enum_values"),
+				"Unexpected enum values",
+				"`enum_values` can only be set for an org property with the `Enum` field type.",
+			)
+		}
+	}
+}
+
+func (r *orgPropertySettingsResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan orgPropertySettingsResourceModel
+
+	// Read Terraform plan data into the model
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Fetch the current org property settings from PropelAuth
+	orgPropertySettings, err := r.client.GetOrgProperties()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading PropelAuth org properties settings",
+			"Could not read PropelAuth org properties settings: "+err.Error(),
+		)
+		return
+	}
+
+	// Update the configuration in PropelAuth
+	updateOrgPropertiesFromPlan(&plan, orgPropertySettings)
+
+	_, err = r.client.UpdateOrgProperties(orgPropertySettings)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error setting org properties settings",
+			"Could not set org properties settings, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	// Write logs using the tflog package
+	// Documentation: https://terraform.io/plugin/log
+	tflog.Trace(ctx, "created a propelauth_org_property_settings resource")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *orgPropertySettingsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// Get current state and read it into the model
+	var state orgPropertySettingsResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Fetch the current org property settings from PropelAuth
+	orgPropertySettings, err := r.client.GetOrgProperties()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading PropelAuth org properties settings",
+			"Could not read PropelAuth org properties settings: "+err.Error(),
+		)
+		return
+	}
+
+	reconcileOrgProperties(&state, orgPropertySettings)
+
+	// Save updated state into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *orgPropertySettingsResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan orgPropertySettingsResourceModel
+
+	// Read Terraform plan data into the model
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Fetch the current org property settings from PropelAuth
+	orgPropertySettings, err := r.client.GetOrgProperties()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading PropelAuth org properties settings",
+			"Could not read PropelAuth org properties settings: "+err.Error(),
+		)
+		return
+	}
+
+	// Update the configuration in PropelAuth
+	updateOrgPropertiesFromPlan(&plan, orgPropertySettings)
+
+	_, err = r.client.UpdateOrgProperties(orgPropertySettings)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error setting org properties settings",
+			"Could not set org properties settings, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	// Write logs using the tflog package
+	// Documentation: https://terraform.io/plugin/log
+	tflog.Trace(ctx, "updated a propelauth_org_property_settings resource")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *orgPropertySettingsResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	tflog.Trace(ctx, "deleted a propelauth_org_property_settings resource")
+}
+
+func (r *orgPropertySettingsResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	var state orgPropertySettingsResourceModel
+
+	// Fetch the current org property settings from PropelAuth
+	orgPropertySettings, err := r.client.GetOrgProperties()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Importing PropelAuth org properties settings",
+			"Could not read PropelAuth org properties settings: "+err.Error(),
+		)
+		return
+	}
+
+	for _, hangingProperty := range orgPropertySettings.GetHangingProperties([]string{}) {
+		state.Properties = append(state.Properties, convertOrgPropertyToModel(&hangingProperty))
+	}
+
+	// Save updated state into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func updateOrgPropertiesFromPlan(plan *orgPropertySettingsResourceModel, orgPropertySettings *propelauth.OrgProperties) {
+	orgPropertyUpdates := make([]propelauth.OrgPropertySettings, len(plan.Properties))
+	for i, orgProperty := range plan.Properties {
+		orgPropertyUpdates[i] = convertOrgPropertyFromModel(orgProperty)
+	}
+
+	for _, orgPropertyUpdate := range orgPropertyUpdates {
+		orgPropertySettings.UpsertProperty(orgPropertyUpdate)
+	}
+	orgPropertySettings.DisableDroppedProperties(orgPropertyUpdates)
+}
+
+func reconcileOrgProperties(state *orgPropertySettingsResourceModel, orgPropertySettings *propelauth.OrgProperties) {
+	// properties disabled outside of Terraform are dropped from the state, so they are planned to be enabled again
+	properties := make([]orgPropertyModel, 0, len(state.Properties))
+	propertyNamesInState := make([]string, 0, len(state.Properties))
+	for _, orgPropertyInState := range state.Properties {
+		activeOrgProperty, ok := orgPropertySettings.GetEnabledProperty(orgPropertyInState.Name.ValueString())
+		if !ok {
+			continue
+		}
+
+		convertedOrgPropertyInState := convertOrgPropertyFromModel(orgPropertyInState)
+		if convertedOrgPropertyInState.IsEqual(activeOrgProperty) {
+			properties = append(properties, orgPropertyInState)
+		} else {
+			properties = append(properties, convertOrgPropertyToModel(&activeOrgProperty))
+		}
+		propertyNamesInState = append(propertyNamesInState, orgPropertyInState.Name.ValueString())
+	}
+
+	for _, hangingProperty := range orgPropertySettings.GetHangingProperties(propertyNamesInState) {
+		properties = append(properties, convertOrgPropertyToModel(&hangingProperty))
+	}
+
+	if len(properties) > 0 || state.Properties != nil {
+		state.Properties = properties
+	}
+}
+
+func convertOrgPropertyToModel(orgProperty *propelauth.OrgPropertySettings) orgPropertyModel {
+	orgPropertyModel := orgPropertyModel{
+		Name:         types.StringValue(orgProperty.Name),
+		DisplayName:  types.StringValue(orgProperty.DisplayName),
+		FieldType:    types.StringValue(orgProperty.FieldType),
+		Required:     types.BoolValue(orgProperty.Required),
+		InJwt:        types.BoolValue(orgProperty.InJwt),
+		UserWritable: types.StringValue(orgProperty.UserWritable),
+	}
+
+	if orgProperty.FieldType == "Enum" {
+		enumValues := make([]types.String, len(orgProperty.EnumValues))
+		for i, enumValue := range orgProperty.EnumValues {
+			enumValues[i] = types.StringValue(enumValue)
+		}
+		orgPropertyModel.EnumValues = enumValues
+	}
+
+	return orgPropertyModel
+}
+
+func convertOrgPropertyFromModel(orgPropertyModel orgPropertyModel) propelauth.OrgPropertySettings {
+	orgProperty := propelauth.OrgPropertySettings{
+		Name:         orgPropertyModel.Name.ValueString(),
+		DisplayName:  orgPropertyModel.DisplayName.ValueString(),
+		FieldType:    orgPropertyModel.FieldType.ValueString(),
+		Required:     orgPropertyModel.Required.ValueBool(),
+		InJwt:        orgPropertyModel.InJwt.ValueBool(),
+		UserWritable: orgPropertyModel.UserWritable.ValueString(),
+	}
+
+	if orgPropertyModel.FieldType.ValueString() == "Enum" {
+		enumValues := make([]string, len(orgPropertyModel.EnumValues))
+		for i, enumValue := range orgPropertyModel.EnumValues {
+			enumValues[i] = enumValue.ValueString()
+		}
+		orgProperty.EnumValues = enumValues
+	}
+
+	return orgProperty
+}
@@ -0,0 +1,56 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccOrgPropertySettingsResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccOrgPropertySettingsResourceConfig(false, "Plan Tier"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("propelauth_org_property_settings.test", "properties.0.display_name", "Plan Tier"),
+					resource.TestCheckResourceAttr("propelauth_org_property_settings.test", "properties.0.in_jwt", "false"),
+					resource.TestCheckResourceAttr("propelauth_org_property_settings.test", "properties.0.enum_values.1", "enterprise"),
+					resource.TestCheckResourceAttr("propelauth_org_property_settings.test", "properties.1.user_writable", "Read"),
+				),
+			},
+			// Update and Read testing
+			{
+				Config: testAccOrgPropertySettingsResourceConfig(true, "Plan"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("propelauth_org_property_settings.test", "properties.0.display_name", "Plan"),
+					resource.TestCheckResourceAttr("propelauth_org_property_settings.test", "properties.0.in_jwt", "true"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccOrgPropertySettingsResourceConfig(inJwt bool, displayName string) string {
+	return providerConfig + fmt.Sprintf(`
+resource "propelauth_org_property_settings" "test" {
+  properties = [
+    {
+      name         = "plan_tier"
+      display_name = %[2]q
+      field_type   = "Enum"
+      in_jwt       = %[1]t
+      enum_values  = ["free", "enterprise"]
+    },
+    {
+      name         = "csm_owner"
+      display_name = "CSM Owner"
+      field_type   = "Text"
+    },
+  ]
+}
+`, inJwt, displayName)
+}
@@ -0,0 +1,525 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"terraform-provider-propelauth/internal/propelauth"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &orgSamlConnectionResource{}
+var _ resource.ResourceWithConfigure = &orgSamlConnectionResource{}
+var _ resource.ResourceWithImportState = &orgSamlConnectionResource{}
+var _ resource.ResourceWithValidateConfig = &orgSamlConnectionResource{}
+
+// samlCertificateExpiryWarning is how long before its IdP certificate expires a SAML connection is warned about.
+const samlCertificateExpiryWarning = 30 * 24 * time.Hour
+
+func NewOrgSamlConnectionResource() resource.Resource {
+	return &orgSamlConnectionResource{}
+}
+
+// orgSamlConnectionResource defines the resource implementation.
+type orgSamlConnectionResource struct {
+	client *propelauth.PropelAuthClient
+}
+
+// orgSamlConnectionResourceModel describes the resource data model.
+type orgSamlConnectionResourceModel struct {
+	Environment          types.String `tfsdk:"environment"`
+	OrgId                types.String `tfsdk:"org_id"`
+	IdpMetadataXml       types.String `tfsdk:"idp_metadata_xml"`
+	IdpMetadataUrl       types.String `tfsdk:"idp_metadata_url"`
+	IdpEntityId          types.String `tfsdk:"idp_entity_id"`
+	IdpSsoUrl            types.String `tfsdk:"idp_sso_url"`
+	IdpCertificate       types.String `tfsdk:"idp_certificate"`
+	IdpProvider          types.String `tfsdk:"idp_provider"`
+	AttributeMappings    types.Map    `tfsdk:"attribute_mappings"`
+	RoleMappings         types.Map    `tfsdk:"role_mappings"`
+	Live                 types.Bool   `tfsdk:"live"`
+	CertificateExpiresAt types.String `tfsdk:"certificate_expires_at"`
+	SpEntityId           types.String `tfsdk:"sp_entity_id"`
+	SpAcsUrl             types.String `tfsdk:"sp_acs_url"`
+	SpLogoutUrl          types.String `tfsdk:"sp_logout_url"`
+}
+
+func (r *orgSamlConnectionResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_org_saml_connection"
+}
+
+func (r *orgSamlConnectionResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	httpsUrlRegex := regexp.MustCompile(`^https://[^\s/]+(/\S*)?$`)
+	userPropertyNameRegex := regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+	resp.Schema = schema.Schema{
+		Description: "Org SAML Connection resource. This is for setting up the SAML connection of an organization, " +
+			"which its users otherwise set up themselves. It needs a BE API key of the environment in `backend_api_keys`.",
+		Attributes: map[string]schema.Attribute{
+			"environment": schema.StringAttribute{
+				Required: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("Test", "Staging", "Prod"),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Description: "The environment of the organization. Accepted values are `Test`, `Staging`, and `Prod`.",
+			},
+			"org_id": schema.StringAttribute{
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Description: "The ID of the organization. The organization must be allowed to set up SAML.",
+			},
+			"idp_metadata_xml": schema.StringAttribute{
+				Optional: true,
+				Description: "The metadata XML of the identity provider. This is one of the three ways to describe the identity " +
+					"provider, along with `idp_metadata_url` and setting `idp_entity_id`, `idp_sso_url` and `idp_certificate`.",
+			},
+			"idp_metadata_url": schema.StringAttribute{
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(httpsUrlRegex, "must be an https URL"),
+				},
+				Description: "The URL of the identity provider's metadata XML. It is fetched when the connection is created or updated.",
+			},
+			"idp_entity_id": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "The entity ID of the identity provider. This is read from the metadata if it is used.",
+			},
+			"idp_sso_url": schema.StringAttribute{
+				Optional: true,
+				Computed: true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(httpsUrlRegex, "must be an https URL"),
+				},
+				Description: "The single sign-on URL of the identity provider. This is read from the metadata if it is used.",
+			},
+			"idp_certificate": schema.StringAttribute{
+				Optional: true,
+				Computed: true,
+				Description: "The certificate the identity provider signs its assertions with, PEM or base64 encoded. " +
+					"This is read from the metadata if it is used.",
+			},
+			"idp_provider": schema.StringAttribute{
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString("Generic"),
+				Validators: []validator.String{
+					stringvalidator.OneOf("Google", "Rippling", "OneLogin", "JumpCloud", "Okta", "Azure", "Duo", "Generic"),
+				},
+				Description: "The kind of identity provider, which tailors the instructions shown to the organization's admins. " +
+					"Accepted values are `Google`, `Rippling`, `OneLogin`, `JumpCloud`, `Okta`, `Azure`, `Duo`, and `Generic`. " +
+					"The default is `Generic`.",
+			},
+			"attribute_mappings": schema.MapAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Validators: []validator.Map{
+					mapvalidator.KeysAre(stringvalidator.RegexMatches(userPropertyNameRegex, "must be the name of a user property")),
+					mapvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
+				},
+				Description: "Maps user properties to the SAML attributes that fill them, e.g. `{ first_name = \"firstName\" }`. " +
+					"PropelAuth's backend API doesn't document this field of the connection yet, so check the mappings in the " +
+					"organization's SAML settings after applying.",
+			},
+			"role_mappings": schema.MapAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Validators: []validator.Map{
+					mapvalidator.KeysAre(stringvalidator.LengthAtLeast(1)),
+					mapvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
+				},
+				Description: "Maps the identity provider's groups to the organization roles their members get, e.g. `{ engineering = \"Admin\" }`. " +
+					"PropelAuth's backend API doesn't document this field of the connection yet, so check the mappings in the " +
+					"organization's SAML settings after applying.",
+			},
+			"live": schema.BoolAttribute{
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(true),
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplaceIf(
+						func(ctx context.Context, req planmodifier.BoolRequest, resp *boolplanmodifier.RequiresReplaceIfFuncResponse) {
+							resp.RequiresReplace = req.StateValue.ValueBool() && !req.PlanValue.ValueBool()
+						},
+						"A live SAML connection can only be taken offline by recreating it.",
+						"A live SAML connection can only be taken offline by recreating it.",
+					),
+				},
+				Description: "If true, the organization's users can log in with the connection. A live connection can only be " +
+					"taken offline by recreating it. The default is true.",
+			},
+			"certificate_expires_at": schema.StringAttribute{
+				Computed:    true,
+				Description: "When the identity provider's certificate expires, in RFC 3339 format.",
+			},
+			"sp_entity_id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Description: "The entity ID of PropelAuth, to enter in the identity provider.",
+			},
+			"sp_acs_url": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Description: "The assertion consumer service URL of PropelAuth, to enter in the identity provider.",
+			},
+			"sp_logout_url": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Description: "The logout URL of PropelAuth, to enter in the identity provider if it supports single logout.",
+			},
+		},
+	}
+}
+
+func (r *orgSamlConnectionResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*propelauth.PropelAuthClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *propelauth.PropelAuthClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *orgSamlConnectionResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config orgSamlConnectionResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	usesXml := !config.IdpMetadataXml.IsNull()
+	usesUrl := !config.IdpMetadataUrl.IsNull()
+	usesExplicit := !config.IdpEntityId.IsNull() || !config.IdpSsoUrl.IsNull() || !config.IdpCertificate.IsNull()
+	sources := 0
+	for _, used := range []bool{usesXml, usesUrl, usesExplicit} {
+		if used {
+			sources++
+		}
+	}
+	if sources != 1 {
+		resp.Diagnostics.AddError(
+			"Invalid identity provider configuration",
+			"Describe the identity provider in exactly one way: with `idp_metadata_xml`, with `idp_metadata_url`, "+
+				"or with `idp_entity_id`, `idp_sso_url` and `idp_certificate`.",
+		)
+		return
+	}
+	if usesExplicit && (config.IdpEntityId.IsNull() || config.IdpSsoUrl.IsNull() || config.IdpCertificate.IsNull()) {
+		resp.Diagnostics.AddError(
+			"Incomplete identity provider configuration",
+			"Without metadata, `idp_entity_id`, `idp_sso_url` and `idp_certificate` must all be set.",
+		)
+		return
+	}
+
+	if usesXml && !config.IdpMetadataXml.IsUnknown() {
+		metadata, err := propelauth.ParseSamlIdpMetadata(config.IdpMetadataXml.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("idp_metadata_xml"), "Invalid IdP metadata", err.Error())
+			return
+		}
+		checkSamlCertificateExpiry(path.Root("idp_metadata_xml"), metadata.Certificate, time.Now(), &resp.Diagnostics)
+	}
+	if usesExplicit && !config.IdpCertificate.IsUnknown() {
+		checkSamlCertificateExpiry(path.Root("idp_certificate"), config.IdpCertificate.ValueString(), time.Now(), &resp.Diagnostics)
+	}
+}
+
+func (r *orgSamlConnectionResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan orgSamlConnectionResourceModel
+
+	// Read Terraform plan data into the model
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.upsertSamlConnection(ctx, &plan, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Write logs using the tflog package
+	// Documentation: https://terraform.io/plugin/log
+	tflog.Trace(ctx, "created a propelauth_org_saml_connection resource")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *orgSamlConnectionResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// Get current state and read it into the model
+	var state orgSamlConnectionResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	backendClient, err := r.client.BackendClient(state.Environment.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Error Reading PropelAuth Org SAML Connection", err.Error())
+		return
+	}
+
+	// the identity provider's side isn't returned, but the organization says whether there's a connection
+	orgInfo, err := backendClient.GetOrganization(state.OrgId.ValueString())
+	if err != nil {
+		// If error is "not_found", it indicates that the resource should be deleted.
+		if propelauth.IsPropelAuthNotFoundError(err) {
+			tflog.Trace(ctx, "deleting a propelauth_org_saml_connection resource because its organization was not found in PropelAuth")
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Error Reading PropelAuth Org SAML Connection",
+			"Could not read PropelAuth Org SAML Connection: "+err.Error(),
+		)
+		return
+	}
+	if !orgInfo.IsSamlConfigured && !orgInfo.IsSamlInTestMode {
+		tflog.Trace(ctx, "deleting a propelauth_org_saml_connection resource because its organization has no SAML connection in PropelAuth")
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	spMetadata, err := backendClient.GetSamlSpMetadata(state.OrgId.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading PropelAuth Org SAML Connection",
+			"Could not read PropelAuth Org SAML Connection: "+err.Error(),
+		)
+		return
+	}
+	state.SpEntityId = types.StringValue(spMetadata.EntityId)
+	state.SpAcsUrl = types.StringValue(spMetadata.AcsUrl)
+	state.SpLogoutUrl = types.StringValue(spMetadata.LogoutUrl)
+
+	// Save updated state into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *orgSamlConnectionResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Read Terraform plan and state data into the models
+	var plan orgSamlConnectionResourceModel
+	var state orgSamlConnectionResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.upsertSamlConnection(ctx, &plan, state.Live.ValueBool())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "updated a propelauth_org_saml_connection resource")
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *orgSamlConnectionResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state orgSamlConnectionResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	backendClient, err := r.client.BackendClient(state.Environment.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Error Deleting PropelAuth Org SAML Connection", err.Error())
+		return
+	}
+
+	// Delete existing SAML connection
+	err = backendClient.DeleteSamlConnection(state.OrgId.ValueString())
+	if err != nil && !propelauth.IsPropelAuthNotFoundError(err) {
+		resp.Diagnostics.AddError(
+			"Error Deleting PropelAuth Org SAML Connection",
+			"Could not delete the org SAML connection, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	tflog.Trace(ctx, "deleted a propelauth_org_saml_connection resource")
+}
+
+func (r *orgSamlConnectionResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	environment, orgId, found := strings.Cut(req.ID, "/")
+	if !found || (environment != "Test" && environment != "Staging" && environment != "Prod") || orgId == "" {
+		resp.Diagnostics.AddError(
+			"Invalid import ID",
+			fmt.Sprintf("Expected an import ID of the form `<environment>/<org_id>`, e.g. `Prod/1189c444-8a2d-4c41-8b4b-ae43ce79a492`, got: %s", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("environment"), environment)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("org_id"), orgId)...)
+}
+
+// upsertSamlConnection resolves the identity provider from the plan, sets it up in PropelAuth and stores
+// the result in the plan.
+func (r *orgSamlConnectionResource) upsertSamlConnection(ctx context.Context, plan *orgSamlConnectionResourceModel, isLive bool) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	backendClient, err := r.client.BackendClient(plan.Environment.ValueString())
+	if err != nil {
+		diags.AddError("Error setting up the org SAML connection", err.Error())
+		return diags
+	}
+
+	metadata := &propelauth.SamlIdpMetadata{
+		EntityId:    plan.IdpEntityId.ValueString(),
+		SsoUrl:      plan.IdpSsoUrl.ValueString(),
+		Certificate: plan.IdpCertificate.ValueString(),
+	}
+	if !plan.IdpMetadataXml.IsNull() {
+		metadata, err = propelauth.ParseSamlIdpMetadata(plan.IdpMetadataXml.ValueString())
+	} else if !plan.IdpMetadataUrl.IsNull() {
+		metadata, err = propelauth.FetchSamlIdpMetadata(ctx, &http.Client{Timeout: 10 * time.Second}, plan.IdpMetadataUrl.ValueString())
+	}
+	if err != nil {
+		diags.AddError("Error reading the IdP metadata", err.Error())
+		return diags
+	}
+	checkSamlCertificateExpiry(path.Root("idp_certificate"), metadata.Certificate, time.Now(), &diags)
+	if diags.HasError() {
+		return diags
+	}
+	certificate, _ := propelauth.ParseSamlCertificate(metadata.Certificate)
+
+	attributeMappings := map[string]string{}
+	roleMappings := map[string]string{}
+	if !plan.AttributeMappings.IsNull() {
+		diags.Append(plan.AttributeMappings.ElementsAs(ctx, &attributeMappings, false)...)
+	}
+	if !plan.RoleMappings.IsNull() {
+		diags.Append(plan.RoleMappings.ElementsAs(ctx, &roleMappings, false)...)
+	}
+	if diags.HasError() {
+		return diags
+	}
+
+	err = backendClient.UpsertSamlIdpMetadata(propelauth.SamlIdpMetadataRequest{
+		OrgId:             plan.OrgId.ValueString(),
+		IdpEntityId:       metadata.EntityId,
+		IdpSsoUrl:         metadata.SsoUrl,
+		IdpCertificate:    metadata.Certificate,
+		Provider:          plan.IdpProvider.ValueString(),
+		AttributeMappings: attributeMappings,
+		RoleMappings:      roleMappings,
+	})
+	if err != nil {
+		diags.AddError(
+			"Error setting up the org SAML connection",
+			"Could not set up the org SAML connection, unexpected error: "+err.Error(),
+		)
+		return diags
+	}
+
+	if plan.Live.ValueBool() && !isLive {
+		err = backendClient.SetSamlConnectionLive(plan.OrgId.ValueString())
+		if err != nil {
+			diags.AddError(
+				"Error setting the org SAML connection live",
+				"The SAML connection was set up, but could not be set live, unexpected error: "+err.Error(),
+			)
+			return diags
+		}
+	}
+
+	spMetadata, err := backendClient.GetSamlSpMetadata(plan.OrgId.ValueString())
+	if err != nil {
+		diags.AddError(
+			"Error reading the org SAML connection",
+			"Could not read PropelAuth's side of the SAML connection: "+err.Error(),
+		)
+		return diags
+	}
+
+	// keep the configured spelling of the identity provider, e.g. a base64 certificate rather than its PEM form
+	if plan.IdpEntityId.IsUnknown() {
+		plan.IdpEntityId = types.StringValue(metadata.EntityId)
+	}
+	if plan.IdpSsoUrl.IsUnknown() {
+		plan.IdpSsoUrl = types.StringValue(metadata.SsoUrl)
+	}
+	if plan.IdpCertificate.IsUnknown() {
+		plan.IdpCertificate = types.StringValue(metadata.Certificate)
+	}
+	plan.CertificateExpiresAt = types.StringValue(certificate.NotAfter.UTC().Format(time.RFC3339))
+	plan.SpEntityId = types.StringValue(spMetadata.EntityId)
+	plan.SpAcsUrl = types.StringValue(spMetadata.AcsUrl)
+	plan.SpLogoutUrl = types.StringValue(spMetadata.LogoutUrl)
+
+	return diags
+}
+
+// checkSamlCertificateExpiry errors if an identity provider's certificate is invalid or expired, and warns if it expires soon.
+func checkSamlCertificateExpiry(attributePath path.Path, certificate string, now time.Time, diags *diag.Diagnostics) {
+	parsedCertificate, err := propelauth.ParseSamlCertificate(certificate)
+	if err != nil {
+		diags.AddAttributeError(attributePath, "Invalid IdP certificate", err.Error())
+		return
+	}
+
+	expiresAt := parsedCertificate.NotAfter.UTC().Format(time.RFC3339)
+	if now.After(parsedCertificate.NotAfter) {
+		diags.AddAttributeError(
+			attributePath,
+			"Expired IdP certificate",
+			fmt.Sprintf("The identity provider's certificate expired at %s, so PropelAuth would reject every login. "+
+				"Download the identity provider's current certificate or metadata.", expiresAt),
+		)
+	} else if now.Add(samlCertificateExpiryWarning).After(parsedCertificate.NotAfter) {
+		diags.AddAttributeWarning(
+			attributePath,
+			"IdP certificate expires soon",
+			fmt.Sprintf("The identity provider's certificate expires at %s. Logins will fail after that unless the "+
+				"connection is updated with the identity provider's new certificate.", expiresAt),
+		)
+	}
+}
@@ -0,0 +1,103 @@
+package provider
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccOrgSamlConnectionResource(t *testing.T) {
+	certificate := testAccIdpCertificate(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// An identity provider described both ways is rejected when planning
+			{
+				Config: testAccOrgSamlConnectionResourceConfig(certificate, "Admin") + `
+resource "propelauth_org_saml_connection" "conflicting" {
+  environment      = "Test"
+  org_id           = propelauth_organization.test.id
+  idp_metadata_url = "https://idp.example.com/metadata"
+  idp_entity_id    = "https://idp.example.com"
+}
+`,
+				ExpectError: regexp.MustCompile("Invalid identity provider configuration"),
+			},
+			// Create and Read testing
+			{
+				Config: testAccOrgSamlConnectionResourceConfig(certificate, "Admin"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("propelauth_org_saml_connection.test", "idp_entity_id", "https://idp.example.com"),
+					resource.TestCheckResourceAttr("propelauth_org_saml_connection.test", "live", "true"),
+					resource.TestCheckResourceAttr("propelauth_org_saml_connection.test", "attribute_mappings.first_name", "firstName"),
+					resource.TestCheckResourceAttr("propelauth_org_saml_connection.test", "role_mappings.engineering", "Admin"),
+					resource.TestCheckResourceAttrSet("propelauth_org_saml_connection.test", "certificate_expires_at"),
+					resource.TestCheckResourceAttrSet("propelauth_org_saml_connection.test", "sp_acs_url"),
+					resource.TestCheckResourceAttrSet("propelauth_org_saml_connection.test", "sp_entity_id"),
+				),
+			},
+			// Update and Read testing
+			{
+				Config: testAccOrgSamlConnectionResourceConfig(certificate, "Member"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("propelauth_org_saml_connection.test", "role_mappings.engineering", "Member"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+// testAccIdpCertificate generates a self-signed certificate for the identity provider, valid for a year.
+func testAccIdpCertificate(t *testing.T) string {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "idp.example.com"},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(365 * 24 * time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, &template, &template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+}
+
+func testAccOrgSamlConnectionResourceConfig(certificate string, engineeringRole string) string {
+	return providerConfig + fmt.Sprintf(`
+resource "propelauth_organization" "test" {
+  environment    = "Test"
+  name           = "E2E SAML Org"
+  can_setup_saml = true
+}
+
+resource "propelauth_org_saml_connection" "test" {
+  environment     = "Test"
+  org_id          = propelauth_organization.test.id
+  idp_entity_id   = "https://idp.example.com"
+  idp_sso_url     = "https://idp.example.com/sso"
+  idp_certificate = %[1]q
+  attribute_mappings = {
+    first_name = "firstName"
+  }
+  role_mappings = {
+    engineering = %[2]q
+  }
+}
+`, certificate, engineeringRole)
+}
@@ -127,7 +127,8 @@ func (r *organizationConfigurationResource) Schema(ctx context.Context, req reso
 					"The default setting is true.",
 			},
 			"orgs_can_require_2fa": schema.BoolAttribute{
-				Optional: true,
+				Optional:           true,
+				DeprecationMessage: "Use `orgs_can_require_mfa` of `propelauth_mfa_policy` instead.",
 				Description: "If true, organizations can require their users to use 2FA." +
 					"The default setting is false. " +
 					"Warning: This is only applied in prod for some billing plans",
@@ -0,0 +1,410 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"terraform-provider-propelauth/internal/propelauth"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &organizationResource{}
+var _ resource.ResourceWithConfigure = &organizationResource{}
+var _ resource.ResourceWithImportState = &organizationResource{}
+var _ resource.ResourceWithValidateConfig = &organizationResource{}
+
+func NewOrganizationResource() resource.Resource {
+	return &organizationResource{}
+}
+
+// organizationResource defines the resource implementation.
+type organizationResource struct {
+	client *propelauth.PropelAuthClient
+}
+
+// organizationResourceModel describes the resource data model.
+type organizationResourceModel struct {
+	Id                    types.String `tfsdk:"id"`
+	Environment           types.String `tfsdk:"environment"`
+	Name                  types.String `tfsdk:"name"`
+	Domain                types.String `tfsdk:"domain"`
+	AutojoinByDomain      types.Bool   `tfsdk:"autojoin_by_domain"`
+	RestrictToDomain      types.Bool   `tfsdk:"restrict_to_domain"`
+	MaxUsers              types.Int64  `tfsdk:"max_users"`
+	Metadata              types.Map    `tfsdk:"metadata"`
+	CustomRoleMappingName types.String `tfsdk:"custom_role_mapping_name"`
+	CanSetupSaml          types.Bool   `tfsdk:"can_setup_saml"`
+	UrlSafeOrgSlug        types.String `tfsdk:"url_safe_org_slug"`
+	IsSamlConfigured      types.Bool   `tfsdk:"is_saml_configured"`
+}
+
+func (r *organizationResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_organization"
+}
+
+func (r *organizationResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	domainRegex := regexp.MustCompile(`^([a-zA-Z0-9]([a-zA-Z0-9-]*[a-zA-Z0-9])?\.)+[a-zA-Z]{2,}$`)
+
+	resp.Schema = schema.Schema{
+		Description: "Organization resource. This is for provisioning an organization in one of your environments, " +
+			"such as an organization for your own staff or a demo tenant. It needs a BE API key of the environment in `backend_api_keys`.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Description: "The ID of the organization set by PropelAuth.",
+			},
+			"environment": schema.StringAttribute{
+				Required: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("Test", "Staging", "Prod"),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Description: "The environment the organization is in. Accepted values are `Test`, `Staging`, and `Prod`.",
+			},
+			"name": schema.StringAttribute{
+				Required: true,
+				Validators: []validator.String{
+					stringvalidator.LengthBetween(1, 100),
+				},
+				Description: "The name of the organization.",
+			},
+			"domain": schema.StringAttribute{
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(domainRegex, "must be a domain, e.g. `example.com`"),
+				},
+				Description: "The email domain of the organization, e.g. `example.com`.",
+			},
+			"autojoin_by_domain": schema.BoolAttribute{
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+				Description: "If true, users with an email address on the organization's `domain` can join it without an invitation. " +
+					"The default is false.",
+			},
+			"restrict_to_domain": schema.BoolAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+				Description: "If true, only users with an email address on the organization's `domain` can be members. The default is false.",
+			},
+			"max_users": schema.Int64Attribute{
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+				Description: "The maximum number of members of the organization. If unset, the number of members isn't limited.",
+			},
+			"metadata": schema.MapAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Description: "Metadata of the organization, which is returned with it by PropelAuth's APIs.",
+			},
+			"custom_role_mapping_name": schema.StringAttribute{
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Description: "The name of the role mapping the organization's members get their roles from. " +
+					"If unset, the organization uses the project's default role mapping.",
+			},
+			"can_setup_saml": schema.BoolAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+				Description: "If true, the organization's admins can set up an enterprise SSO connection for it. The default is false.",
+			},
+			"url_safe_org_slug": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Description: "A URL safe version of the organization's name set by PropelAuth.",
+			},
+			"is_saml_configured": schema.BoolAttribute{
+				Computed:    true,
+				Description: "True if the organization has a SAML connection.",
+			},
+		},
+	}
+}
+
+func (r *organizationResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*propelauth.PropelAuthClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *propelauth.PropelAuthClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *organizationResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config organizationResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !config.Domain.IsNull() {
+		return
+	}
+	if config.AutojoinByDomain.ValueBool() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("autojoin_by_domain"),
+			"Missing domain",
+			"`autojoin_by_domain` can only be enabled for an organization with a `domain`.",
+		)
+	}
+	if config.RestrictToDomain.ValueBool() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("restrict_to_domain"),
+			"Missing domain",
+			"`restrict_to_domain` can only be enabled for an organization with a `domain`.",
+		)
+	}
+}
+
+func (r *organizationResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan organizationResourceModel
+
+	// Read Terraform plan data into the model
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	backendClient, err := r.client.BackendClient(plan.Environment.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Error creating organization", err.Error())
+		return
+	}
+
+	orgId, err := backendClient.CreateOrganization(propelauth.OrganizationCreateRequest{
+		Name:                          plan.Name.ValueString(),
+		Domain:                        plan.Domain.ValueStringPointer(),
+		EnableAutoJoiningByDomain:     plan.AutojoinByDomain.ValueBool(),
+		MembersMustHaveMatchingDomain: plan.RestrictToDomain.ValueBool(),
+		MaxUsers:                      plan.MaxUsers.ValueInt64Pointer(),
+		CustomRoleMappingName:         plan.CustomRoleMappingName.ValueStringPointer(),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating organization",
+			"Could not create organization, unexpected error: "+err.Error(),
+		)
+		return
+	}
+	plan.Id = types.StringValue(orgId)
+
+	// metadata and SAML can't be set on creation, so save the organization first in case setting them fails
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), orgId)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("environment"), plan.Environment)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.updateOrganization(ctx, backendClient, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Write logs using the tflog package
+	// Documentation: https://terraform.io/plugin/log
+	tflog.Trace(ctx, "created a propelauth_organization resource")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *organizationResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// Get current state and read it into the model
+	var state organizationResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	backendClient, err := r.client.BackendClient(state.Environment.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Error Reading PropelAuth Organization", err.Error())
+		return
+	}
+
+	organization, err := backendClient.GetOrganization(state.Id.ValueString())
+	if err != nil {
+		// If error is "not_found", it indicates that the resource should be deleted.
+		if propelauth.IsPropelAuthNotFoundError(err) {
+			tflog.Trace(ctx, "deleting a propelauth_organization resource because it was not found in PropelAuth")
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Error Reading PropelAuth Organization",
+			"Could not read PropelAuth Organization: "+err.Error(),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(organizationToModel(ctx, organization, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save updated state into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *organizationResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Read Terraform plan data into the model
+	var plan organizationResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	backendClient, err := r.client.BackendClient(plan.Environment.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Error updating organization", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(r.updateOrganization(ctx, backendClient, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "updated a propelauth_organization resource")
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *organizationResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state organizationResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	backendClient, err := r.client.BackendClient(state.Environment.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Error deleting organization", err.Error())
+		return
+	}
+
+	// Delete existing organization
+	err = backendClient.DeleteOrganization(state.Id.ValueString())
+	if err != nil && !propelauth.IsPropelAuthNotFoundError(err) {
+		resp.Diagnostics.AddError(
+			"Error deleting organization",
+			"Could not delete organization, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	tflog.Trace(ctx, "deleted a propelauth_organization resource")
+}
+
+func (r *organizationResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	environment, orgId, found := strings.Cut(req.ID, "/")
+	if !found || (environment != "Test" && environment != "Staging" && environment != "Prod") || orgId == "" {
+		resp.Diagnostics.AddError(
+			"Invalid import ID",
+			fmt.Sprintf("Expected an import ID of the form `<environment>/<org_id>`, e.g. `Prod/1189c444-8a2d-4c41-8b4b-ae43ce79a492`, got: %s", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("environment"), environment)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), orgId)...)
+}
+
+// updateOrganization saves the plan to the organization and reads back the fields PropelAuth sets.
+func (r *organizationResource) updateOrganization(ctx context.Context, backendClient *propelauth.PropelAuthBackendClient, plan *organizationResourceModel) diag.Diagnostics {
+	metadata, diags := jsonObjectFromStringMap(ctx, plan.Metadata)
+	if diags.HasError() {
+		return diags
+	}
+
+	err := backendClient.UpdateOrganization(plan.Id.ValueString(), propelauth.OrganizationUpdateRequest{
+		Name:                  plan.Name.ValueString(),
+		Domain:                plan.Domain.ValueStringPointer(),
+		AutojoinByDomain:      plan.AutojoinByDomain.ValueBool(),
+		RestrictToDomain:      plan.RestrictToDomain.ValueBool(),
+		CanSetupSaml:          plan.CanSetupSaml.ValueBool(),
+		MaxUsers:              plan.MaxUsers.ValueInt64Pointer(),
+		Metadata:              metadata,
+		CustomRoleMappingName: plan.CustomRoleMappingName.ValueStringPointer(),
+	})
+	if err != nil {
+		diags.AddError(
+			"Error updating organization",
+			"Could not update organization, unexpected error: "+err.Error(),
+		)
+		return diags
+	}
+
+	organization, err := backendClient.GetOrganization(plan.Id.ValueString())
+	if err != nil {
+		diags.AddError(
+			"Error reading organization",
+			"Could not read the organization after saving it: "+err.Error(),
+		)
+		return diags
+	}
+	plan.CustomRoleMappingName = types.StringPointerValue(organization.CustomRoleMappingName)
+	plan.UrlSafeOrgSlug = types.StringValue(organization.UrlSafeOrgSlug)
+	plan.IsSamlConfigured = types.BoolValue(organization.IsSamlConfigured)
+
+	return diags
+}
+
+// organizationToModel sets the model from an organization returned by PropelAuth.
+func organizationToModel(ctx context.Context, organization *propelauth.OrganizationInfo, model *organizationResourceModel) diag.Diagnostics {
+	model.Id = types.StringValue(organization.OrgId)
+	model.Name = types.StringValue(organization.Name)
+	model.Domain = types.StringPointerValue(organization.Domain)
+	model.AutojoinByDomain = types.BoolValue(organization.DomainAutojoin)
+	model.RestrictToDomain = types.BoolValue(organization.DomainRestrict)
+	model.MaxUsers = types.Int64PointerValue(organization.MaxUsers)
+	model.CustomRoleMappingName = types.StringPointerValue(organization.CustomRoleMappingName)
+	model.CanSetupSaml = types.BoolValue(organization.CanSetupSaml)
+	model.UrlSafeOrgSlug = types.StringValue(organization.UrlSafeOrgSlug)
+	model.IsSamlConfigured = types.BoolValue(organization.IsSamlConfigured)
+
+	var diags diag.Diagnostics
+	model.Metadata, diags = stringMapFromJsonObject(ctx, organization.Metadata, model.Metadata)
+	return diags
+}
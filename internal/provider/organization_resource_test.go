@@ -0,0 +1,60 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccOrganizationResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccOrganizationResourceConfig("Acme Staff", 10),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("propelauth_organization.test", "name", "Acme Staff"),
+					resource.TestCheckResourceAttr("propelauth_organization.test", "domain", "acme.com"),
+					resource.TestCheckResourceAttr("propelauth_organization.test", "autojoin_by_domain", "true"),
+					resource.TestCheckResourceAttr("propelauth_organization.test", "max_users", "10"),
+					resource.TestCheckResourceAttr("propelauth_organization.test", "metadata.team", "platform"),
+					resource.TestCheckResourceAttrSet("propelauth_organization.test", "id"),
+					resource.TestCheckResourceAttrSet("propelauth_organization.test", "url_safe_org_slug"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:        "propelauth_organization.test",
+				ImportState:         true,
+				ImportStateIdPrefix: "Test/",
+				ImportStateVerify:   true,
+			},
+			// Update and Read testing
+			{
+				Config: testAccOrganizationResourceConfig("Acme Employees", 20),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("propelauth_organization.test", "name", "Acme Employees"),
+					resource.TestCheckResourceAttr("propelauth_organization.test", "max_users", "20"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccOrganizationResourceConfig(name string, maxUsers int) string {
+	return providerConfig + fmt.Sprintf(`
+resource "propelauth_organization" "test" {
+  environment        = "Test"
+  name               = %[1]q
+  domain             = "acme.com"
+  autojoin_by_domain = true
+  max_users          = %[2]d
+  metadata = {
+    team = "platform"
+  }
+}
+`, name, maxUsers)
+}
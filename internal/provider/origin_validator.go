@@ -0,0 +1,32 @@
+package provider
+
+import (
+	"context"
+
+	"terraform-provider-propelauth/internal/propelauth"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+var _ validator.String = originValidator{}
+
+// originValidator checks that a URL is only made of a scheme, host and optional port, such as `https://example.com`.
+type originValidator struct{}
+
+func (v originValidator) Description(ctx context.Context) string {
+	return "value must be a URL with an http or https scheme, a host and an optional port, without a path"
+}
+
+func (v originValidator) MarkdownDescription(ctx context.Context) string {
+	return "value must be a URL with an `http` or `https` scheme, a host and an optional port, without a path"
+}
+
+func (v originValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if _, err := propelauth.ParseApplicationUrl(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid URL", err.Error())
+	}
+}
@@ -30,9 +30,17 @@ type propelauthProvider struct {
 
 // propelauthProviderModel describes the provider data model.
 type propelauthProviderModel struct {
-	TenantId  types.String `tfsdk:"tenant_id"`
-	ProjectId types.String `tfsdk:"project_id"`
-	ApiKey    types.String `tfsdk:"api_key"`
+	TenantId       types.String                   `tfsdk:"tenant_id"`
+	ProjectId      types.String                   `tfsdk:"project_id"`
+	ApiKey         types.String                   `tfsdk:"api_key"`
+	BackendApiKeys *propelauthBackendApiKeysModel `tfsdk:"backend_api_keys"`
+}
+
+// propelauthBackendApiKeysModel describes the BE API keys used to manage each environment's data.
+type propelauthBackendApiKeysModel struct {
+	Test    types.String `tfsdk:"test"`
+	Staging types.String `tfsdk:"staging"`
+	Prod    types.String `tfsdk:"prod"`
 }
 
 func (p *propelauthProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
@@ -61,6 +69,32 @@ func (p *propelauthProvider) Schema(ctx context.Context, req provider.SchemaRequ
 					"You can generate one on the Infrastructure Integration page of the PropelAuth Dashboard. " +
 					"If not provided, the provider will attempt to use the PROPELAUTH_API_KEY environment variable.",
 			},
+			"backend_api_keys": schema.SingleNestedAttribute{
+				Optional: true,
+				Attributes: map[string]schema.Attribute{
+					"test": schema.StringAttribute{
+						Optional:  true,
+						Sensitive: true,
+						Description: "A BE API key of the `Test` environment. If not provided, the provider will attempt to use " +
+							"the PROPELAUTH_TEST_BE_API_KEY environment variable.",
+					},
+					"staging": schema.StringAttribute{
+						Optional:  true,
+						Sensitive: true,
+						Description: "A BE API key of the `Staging` environment. If not provided, the provider will attempt to use " +
+							"the PROPELAUTH_STAGING_BE_API_KEY environment variable.",
+					},
+					"prod": schema.StringAttribute{
+						Optional:  true,
+						Sensitive: true,
+						Description: "A BE API key of the `Prod` environment. If not provided, the provider will attempt to use " +
+							"the PROPELAUTH_PROD_BE_API_KEY environment variable.",
+					},
+				},
+				Description: "BE API keys for managing the data of your environments, such as organizations and their SAML connections. " +
+					"These are only needed for the resources that manage an environment's data, and only for the environments they manage. " +
+					"You can create them with `propelauth_be_api_key` in a separate configuration.",
+			},
 		},
 	}
 }
@@ -185,6 +219,30 @@ func (p *propelauthProvider) Configure(ctx context.Context, req provider.Configu
 		return
 	}
 
+	// BE API keys are optional, and only checked by the resources that need them
+	backendApiKeys := map[string]string{
+		"Test":    os.Getenv("PROPELAUTH_TEST_BE_API_KEY"),
+		"Staging": os.Getenv("PROPELAUTH_STAGING_BE_API_KEY"),
+		"Prod":    os.Getenv("PROPELAUTH_PROD_BE_API_KEY"),
+	}
+	if config.BackendApiKeys != nil {
+		configuredBackendApiKeys := map[string]types.String{
+			"Test":    config.BackendApiKeys.Test,
+			"Staging": config.BackendApiKeys.Staging,
+			"Prod":    config.BackendApiKeys.Prod,
+		}
+		for environment, apiKey := range configuredBackendApiKeys {
+			if !apiKey.IsNull() && !apiKey.IsUnknown() {
+				backendApiKeys[environment] = apiKey.ValueString()
+			}
+		}
+	}
+	for environment, apiKey := range backendApiKeys {
+		if apiKey != "" {
+			client.SetBackendApiKey(environment, apiKey)
+		}
+	}
+
 	// Make the PropelAuth client available during DataSource and Resource
 	// type Configure methods.
 	resp.DataSourceData = client
@@ -210,13 +268,30 @@ func (p *propelauthProvider) Resources(ctx context.Context) []func() resource.Re
 		NewOauthClientResource,
 		NewApiKeyAlertResource,
 		NewDarkmodeThemeResource,
+		NewOidcProviderResource,
+		NewOrgSamlConnectionResource,
+		NewOrganizationResource,
+		NewUserResource,
+		NewOrgMembershipResource,
+		NewOrgPropertySettingsResource,
+		NewWebhookEndpointResource,
+		NewEmailTemplateResource,
+		NewEmailSenderDomainResource,
+		NewEmailSenderDomainVerificationResource,
+		NewSessionSettingsResource,
+		NewMfaPolicyResource,
 	}
 }
 
 func (p *propelauthProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
 		NewBeIntegrationDataSource,
+		NewCustomDomainDataSource,
+		NewFeIntegrationDataSource,
+		NewFeIntegrationAllEnvironmentsDataSource,
 		NewSocialLoginRedirectDataSource,
+		NewOidcProviderRedirectDataSource,
+		NewWebhookEventTypesDataSource,
 	}
 }
 
@@ -0,0 +1,340 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"terraform-provider-propelauth/internal/propelauth"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &sessionSettingsResource{}
+var _ resource.ResourceWithConfigure = &sessionSettingsResource{}
+var _ resource.ResourceWithImportState = &sessionSettingsResource{}
+var _ resource.ResourceWithValidateConfig = &sessionSettingsResource{}
+
+func NewSessionSettingsResource() resource.Resource {
+	return &sessionSettingsResource{}
+}
+
+// sessionSettingsResource defines the resource implementation.
+type sessionSettingsResource struct {
+	client *propelauth.PropelAuthClient
+}
+
+// sessionSettingsResourceModel describes the resource data model.
+type sessionSettingsResourceModel struct {
+	Environment             types.String  `tfsdk:"environment"`
+	AccessTokenTtl          durationValue `tfsdk:"access_token_ttl"`
+	RefreshTokenLifetime    durationValue `tfsdk:"refresh_token_lifetime"`
+	SessionIdleTimeout      durationValue `tfsdk:"session_idle_timeout"`
+	SessionAbsoluteLifetime durationValue `tfsdk:"session_absolute_lifetime"`
+	MaxConcurrentSessions   types.Int64   `tfsdk:"max_concurrent_sessions"`
+}
+
+func (r *sessionSettingsResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_session_settings"
+}
+
+func (r *sessionSettingsResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Session Settings resource. This is for configuring how long the tokens and sessions of an environment's " +
+			"users last, and how many sessions a user can have at once. Durations are written like `15m`, `12h`, `30d` or `1h30m`. " +
+			"Unset values are left to be managed in the dashboard.",
+		Attributes: map[string]schema.Attribute{
+			"environment": schema.StringAttribute{
+				Required: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("Test", "Staging", "Prod"),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Description: "The environment for which you are configuring sessions. Accepted values are `Test`, `Staging`, and `Prod`.",
+			},
+			"access_token_ttl": schema.StringAttribute{
+				CustomType: durationType{},
+				Optional:   true,
+				Validators: []validator.String{
+					durationBetweenValidator{min: time.Minute, max: 24 * time.Hour},
+				},
+				Description: "How long an access token is valid for, between `1m` and `24h`. The default setting is `30m`.",
+			},
+			"refresh_token_lifetime": schema.StringAttribute{
+				CustomType: durationType{},
+				Optional:   true,
+				Validators: []validator.String{
+					durationBetweenValidator{min: time.Hour, max: 365 * 24 * time.Hour},
+				},
+				Description: "How long a refresh token can be used to get new access tokens, between `1h` and `365d`. " +
+					"It must be longer than `access_token_ttl`. The default setting is `14d`.",
+			},
+			"session_idle_timeout": schema.StringAttribute{
+				CustomType: durationType{},
+				Optional:   true,
+				Validators: []validator.String{
+					durationBetweenValidator{min: 5 * time.Minute, max: 365 * 24 * time.Hour},
+				},
+				Description: "How long a session lasts without any activity before the user is logged out, between `5m` and `365d`. " +
+					"It must not be longer than `session_absolute_lifetime`. The default setting is `14d`.",
+			},
+			"session_absolute_lifetime": schema.StringAttribute{
+				CustomType: durationType{},
+				Optional:   true,
+				Validators: []validator.String{
+					durationBetweenValidator{min: time.Hour, max: 365 * 24 * time.Hour},
+				},
+				Description: "How long a session lasts after the user logs in, however active they are, between `1h` and `365d`. " +
+					"The default setting is `365d`.",
+			},
+			"max_concurrent_sessions": schema.Int64Attribute{
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.Between(0, 1000),
+				},
+				Description: "The number of sessions a user can have at once. Logging in beyond it logs out the user's oldest session. " +
+					"0 is unlimited, which is the default setting.",
+			},
+		},
+	}
+}
+
+func (r *sessionSettingsResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*propelauth.PropelAuthClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *propelauth.PropelAuthClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *sessionSettingsResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config sessionSettingsResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	accessTokenTtl := config.AccessTokenTtl.Seconds()
+	refreshTokenLifetime := config.RefreshTokenLifetime.Seconds()
+	sessionIdleTimeout := config.SessionIdleTimeout.Seconds()
+	sessionAbsoluteLifetime := config.SessionAbsoluteLifetime.Seconds()
+
+	if accessTokenTtl != nil && refreshTokenLifetime != nil && *accessTokenTtl >= *refreshTokenLifetime {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("refresh_token_lifetime"),
+			"Invalid session settings",
+			fmt.Sprintf("`refresh_token_lifetime` (%s) must be longer than `access_token_ttl` (%s).",
+				config.RefreshTokenLifetime.ValueString(), config.AccessTokenTtl.ValueString()),
+		)
+	}
+	if sessionIdleTimeout != nil && sessionAbsoluteLifetime != nil && *sessionIdleTimeout > *sessionAbsoluteLifetime {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("session_idle_timeout"),
+			"Invalid session settings",
+			fmt.Sprintf("`session_idle_timeout` (%s) must not be longer than `session_absolute_lifetime` (%s).",
+				config.SessionIdleTimeout.ValueString(), config.SessionAbsoluteLifetime.ValueString()),
+		)
+	}
+	if refreshTokenLifetime != nil && sessionAbsoluteLifetime != nil && *refreshTokenLifetime > *sessionAbsoluteLifetime {
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("refresh_token_lifetime"),
+			"Refresh tokens outlive sessions",
+			fmt.Sprintf("`refresh_token_lifetime` (%s) is longer than `session_absolute_lifetime` (%s), so refresh tokens "+
+				"stop working when the session ends rather than when they expire.",
+				config.RefreshTokenLifetime.ValueString(), config.SessionAbsoluteLifetime.ValueString()),
+		)
+	}
+}
+
+func (r *sessionSettingsResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan sessionSettingsResourceModel
+
+	// Read Terraform plan data into the model
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.updateSessionSettings(plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Write logs using the tflog package
+	// Documentation: https://terraform.io/plugin/log
+	tflog.Trace(ctx, "created a propelauth_session_settings resource")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *sessionSettingsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// Get current state and read it into the model
+	var state sessionSettingsResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	realmConfigResponse, err := r.client.GetRealmConfig(state.Environment.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading PropelAuth session settings",
+			"Could not read PropelAuth session settings: "+err.Error(),
+		)
+		return
+	}
+
+	// Save into the Terraform state only if the value is not null in Terraform.
+	// Null, or unset values, in Terraform are left to be manually managed in the dashboard.
+	// Durations are read back in their own spelling, which semantic equality reconciles with the configured one.
+	if !state.AccessTokenTtl.IsNull() {
+		state.AccessTokenTtl = newDurationValue(realmConfigResponse.AccessTokenTtlSeconds)
+	}
+	if !state.RefreshTokenLifetime.IsNull() {
+		state.RefreshTokenLifetime = newDurationValue(realmConfigResponse.RefreshTokenLifetimeSeconds)
+	}
+	if !state.SessionIdleTimeout.IsNull() {
+		state.SessionIdleTimeout = newDurationValue(realmConfigResponse.SessionIdleTimeoutSeconds)
+	}
+	if !state.SessionAbsoluteLifetime.IsNull() {
+		state.SessionAbsoluteLifetime = newDurationValue(realmConfigResponse.SessionAbsoluteLifetimeSeconds)
+	}
+	if !state.MaxConcurrentSessions.IsNull() {
+		state.MaxConcurrentSessions = types.Int64Value(realmConfigResponse.MaxConcurrentSessions)
+	}
+
+	// Save updated state into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *sessionSettingsResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan sessionSettingsResourceModel
+
+	// Read Terraform plan data into the model
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.updateSessionSettings(plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Write logs using the tflog package
+	// Documentation: https://terraform.io/plugin/log
+	tflog.Trace(ctx, "updated a propelauth_session_settings resource")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *sessionSettingsResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	tflog.Trace(ctx, "deleted a propelauth_session_settings resource")
+}
+
+func (r *sessionSettingsResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	var state sessionSettingsResourceModel
+	environment := req.ID
+
+	if environment != "Test" && environment != "Staging" && environment != "Prod" {
+		resp.Diagnostics.AddError(
+			"Invalid Environment",
+			"Invalid environment value for import. Accepted values are `Test`, `Staging`, and `Prod`.",
+		)
+		return
+	}
+
+	realmConfigResponse, err := r.client.GetRealmConfig(environment)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Importing PropelAuth session settings",
+			"Could not read PropelAuth session settings: "+err.Error(),
+		)
+		return
+	}
+
+	// Save into the Terraform state all values from the dashboard
+	state.Environment = types.StringValue(environment)
+	state.AccessTokenTtl = newDurationValue(realmConfigResponse.AccessTokenTtlSeconds)
+	state.RefreshTokenLifetime = newDurationValue(realmConfigResponse.RefreshTokenLifetimeSeconds)
+	state.SessionIdleTimeout = newDurationValue(realmConfigResponse.SessionIdleTimeoutSeconds)
+	state.SessionAbsoluteLifetime = newDurationValue(realmConfigResponse.SessionAbsoluteLifetimeSeconds)
+	state.MaxConcurrentSessions = types.Int64Value(realmConfigResponse.MaxConcurrentSessions)
+
+	// Save updated state into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// updateSessionSettings saves the planned session settings and checks that each set value was updated.
+func (r *sessionSettingsResource) updateSessionSettings(plan sessionSettingsResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	realmConfigUpdate := propelauth.RealmConfigUpdate{
+		AccessTokenTtlSeconds:          plan.AccessTokenTtl.Seconds(),
+		RefreshTokenLifetimeSeconds:    plan.RefreshTokenLifetime.Seconds(),
+		SessionIdleTimeoutSeconds:      plan.SessionIdleTimeout.Seconds(),
+		SessionAbsoluteLifetimeSeconds: plan.SessionAbsoluteLifetime.Seconds(),
+		MaxConcurrentSessions:          plan.MaxConcurrentSessions.ValueInt64Pointer(),
+	}
+
+	realmConfigResponse, err := r.client.UpdateRealmConfig(plan.Environment.ValueString(), realmConfigUpdate)
+	if err != nil {
+		diags.AddError(
+			"Error setting session settings",
+			"Could not set session settings, unexpected error: "+err.Error(),
+		)
+		return diags
+	}
+
+	// Check that all fields were updated to the new value if not empty
+	updatedValues := []struct {
+		attribute string
+		planned   *int64
+		actual    int64
+	}{
+		{"access_token_ttl", realmConfigUpdate.AccessTokenTtlSeconds, realmConfigResponse.AccessTokenTtlSeconds},
+		{"refresh_token_lifetime", realmConfigUpdate.RefreshTokenLifetimeSeconds, realmConfigResponse.RefreshTokenLifetimeSeconds},
+		{"session_idle_timeout", realmConfigUpdate.SessionIdleTimeoutSeconds, realmConfigResponse.SessionIdleTimeoutSeconds},
+		{"session_absolute_lifetime", realmConfigUpdate.SessionAbsoluteLifetimeSeconds, realmConfigResponse.SessionAbsoluteLifetimeSeconds},
+		{"max_concurrent_sessions", realmConfigUpdate.MaxConcurrentSessions, realmConfigResponse.MaxConcurrentSessions},
+	}
+	for _, updatedValue := range updatedValues {
+		if updatedValue.planned != nil && *updatedValue.planned != updatedValue.actual {
+			diags.AddError(
+				"Error updating session settings",
+				fmt.Sprintf("The `%s` failed to update. It is instead %d (in seconds for durations).", updatedValue.attribute, updatedValue.actual),
+			)
+		}
+	}
+
+	return diags
+}
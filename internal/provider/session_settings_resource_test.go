@@ -0,0 +1,53 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccSessionSettingsResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Idle timeouts longer than the session are rejected when planning
+			{
+				Config:      testAccSessionSettingsResourceConfig("15m", "30d", "60d"),
+				ExpectError: regexp.MustCompile("must not be longer than `session_absolute_lifetime`"),
+			},
+			// Create and Read testing
+			{
+				Config: testAccSessionSettingsResourceConfig("15m", "8h", "30d"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("propelauth_session_settings.test", "access_token_ttl", "15m"),
+					resource.TestCheckResourceAttr("propelauth_session_settings.test", "session_idle_timeout", "8h"),
+					resource.TestCheckResourceAttr("propelauth_session_settings.test", "session_absolute_lifetime", "30d"),
+					resource.TestCheckResourceAttr("propelauth_session_settings.test", "max_concurrent_sessions", "5"),
+				),
+			},
+			// Update and Read testing
+			{
+				Config: testAccSessionSettingsResourceConfig("5m", "1h", "7d"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("propelauth_session_settings.test", "access_token_ttl", "5m"),
+					resource.TestCheckResourceAttr("propelauth_session_settings.test", "session_absolute_lifetime", "7d"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccSessionSettingsResourceConfig(accessTokenTtl string, sessionIdleTimeout string, sessionAbsoluteLifetime string) string {
+	return providerConfig + fmt.Sprintf(`
+resource "propelauth_session_settings" "test" {
+  environment               = "Test"
+  access_token_ttl          = %[1]q
+  session_idle_timeout      = %[2]q
+  session_absolute_lifetime = %[3]q
+  max_concurrent_sessions   = 5
+}
+`, accessTokenTtl, sessionIdleTimeout, sessionAbsoluteLifetime)
+}
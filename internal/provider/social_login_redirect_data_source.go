@@ -52,10 +52,10 @@ func (d *SocialLoginRedirectDataSource) Schema(_ context.Context, _ datasource.S
 			"social_provider": schema.StringAttribute{
 				Required: true,
 				Validators: []validator.String{
-					stringvalidator.OneOf("Google", "Microsoft", "GitHub", "Slack", "LinkedIn", "Atlassian", "Apple", "Salesforce", "QuickBooks", "Xero", "Salesloft", "Outreach"),
+					socialProviderValidator{},
 				},
-				Description: "The social login provider for which you are configuring and need the redirect URL. Accepted values are " +
-					"`Google`, `Microsoft`, `GitHub`, `Slack`, `LinkedIn`, `Atlassian`, `Apple`, `Salesforce`, `QuickBooks`, `Xero`, `Salesloft`, and `Outreach`.",
+				Description: "The social login provider for which you are configuring and need the redirect URL. " +
+					socialProviderDescription(),
 			},
 			"redirect_url": schema.StringAttribute{
 				Computed:    true,
@@ -3,16 +3,24 @@ package provider
 import (
 	"context"
 	"fmt"
+	"regexp"
+	"time"
 
 	"terraform-provider-propelauth/internal/propelauth"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
@@ -21,6 +29,11 @@ import (
 var _ resource.Resource = &socialLoginResource{}
 var _ resource.ResourceWithConfigure = &socialLoginResource{}
 var _ resource.ResourceWithImportState = &socialLoginResource{}
+var _ resource.ResourceWithValidateConfig = &socialLoginResource{}
+var _ resource.ResourceWithModifyPlan = &socialLoginResource{}
+
+// appleClientSecretRenewalWindow is how long before it expires a generated Apple client secret is renewed.
+const appleClientSecretRenewalWindow = 30 * 24 * time.Hour
 
 func NewSocialLoginResource() resource.Resource {
 	return &socialLoginResource{}
@@ -33,9 +46,35 @@ type socialLoginResource struct {
 
 // socialLoginResourceModel describes the resource data model.
 type socialLoginResourceModel struct {
-	SocialProvider types.String `tfsdk:"social_provider"`
-	ClientId       types.String `tfsdk:"client_id"`
-	ClientSecret   types.String `tfsdk:"client_secret"`
+	SocialProvider types.String               `tfsdk:"social_provider"`
+	ClientId       types.String               `tfsdk:"client_id"`
+	ClientSecret   types.String               `tfsdk:"client_secret"`
+	Apple          *appleSocialLoginModel     `tfsdk:"apple"`
+	Microsoft      *microsoftSocialLoginModel `tfsdk:"microsoft"`
+	Google         *googleSocialLoginModel    `tfsdk:"google"`
+	GitHub         *gitHubSocialLoginModel    `tfsdk:"github"`
+	ExtraScopes    types.Set                  `tfsdk:"extra_scopes"`
+}
+
+type appleSocialLoginModel struct {
+	TeamId                   types.String `tfsdk:"team_id"`
+	KeyId                    types.String `tfsdk:"key_id"`
+	PrivateKey               types.String `tfsdk:"private_key"`
+	ClientSecretValidityDays types.Int64  `tfsdk:"client_secret_validity_days"`
+	ClientSecretExpiresAt    types.String `tfsdk:"client_secret_expires_at"`
+}
+
+type microsoftSocialLoginModel struct {
+	TenantId          types.String `tfsdk:"tenant_id"`
+	OrganizationsOnly types.Bool   `tfsdk:"organizations_only"`
+}
+
+type googleSocialLoginModel struct {
+	HostedDomains types.Set `tfsdk:"hosted_domains"`
+}
+
+type gitHubSocialLoginModel struct {
+	AllowedOrganizations types.Set `tfsdk:"allowed_organizations"`
 }
 
 func (r *socialLoginResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -43,6 +82,10 @@ func (r *socialLoginResource) Metadata(ctx context.Context, req resource.Metadat
 }
 
 func (r *socialLoginResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	domainNameRegex := regexp.MustCompile(`^[a-z0-9][a-z0-9\-\.]*\.[a-z0-9]{2,}$`)
+	tenantIdRegex := regexp.MustCompile(`^([0-9a-fA-F]{8}-([0-9a-fA-F]{4}-){3}[0-9a-fA-F]{12}|[a-z0-9][a-z0-9\-\.]*\.[a-z0-9]{2,})$`)
+	gitHubOrganizationRegex := regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9\-]{0,38}$`)
+
 	resp.Schema = schema.Schema{
 		// This description is used by the documentation generator and the language server.
 		Description: "Backend API Key resource. This is for configuring the basic BE API key information in PropelAuth.",
@@ -50,14 +93,13 @@ func (r *socialLoginResource) Schema(ctx context.Context, req resource.SchemaReq
 			"social_provider": schema.StringAttribute{
 				Required: true,
 				Validators: []validator.String{
-					stringvalidator.OneOf("Google", "Microsoft", "GitHub", "Slack", "LinkedIn", "Atlassian", "Apple", "Salesforce", "QuickBooks", "Xero", "Salesloft", "Outreach"),
+					socialProviderValidator{},
 				},
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.RequiresReplace(),
 				},
-				Description: "The OIDC provider for the Social Login you're configuring. This is only for internal dislay purposes." +
-					"Accepted values are `Google`, `Microsoft`, `GitHub`, `Slack`, `LinkedIn`, `Atlassian`, `Apple`, " +
-					"`Salesforce`, `QuickBooks`, `Xero`, `Salesloft`, and `Outreach`.",
+				Description: "The OIDC provider for the Social Login you're configuring. This is only for internal dislay purposes. " +
+					socialProviderDescription(),
 			},
 			"client_id": schema.StringAttribute{
 				Required: true,
@@ -65,9 +107,112 @@ func (r *socialLoginResource) Schema(ctx context.Context, req resource.SchemaReq
 					"OIDC provider.",
 			},
 			"client_secret": schema.StringAttribute{
-				Required:    true,
-				Sensitive:   true,
-				Description: "The client secret for the oauth client that can be retrieved from the OIDC provider.",
+				Optional:  true,
+				Computed:  true,
+				Sensitive: true,
+				Description: "The client secret for the oauth client that can be retrieved from the OIDC provider. " +
+					"This is required unless `apple` is set, in which case it is generated from Apple's key material.",
+			},
+			"apple": schema.SingleNestedAttribute{
+				Optional: true,
+				Attributes: map[string]schema.Attribute{
+					"team_id": schema.StringAttribute{
+						Required:    true,
+						Description: "The ID of your Apple Developer team.",
+					},
+					"key_id": schema.StringAttribute{
+						Required:    true,
+						Description: "The ID of the Sign in with Apple private key.",
+					},
+					"private_key": schema.StringAttribute{
+						Required:  true,
+						Sensitive: true,
+						Description: "The contents of the `.p8` private key file downloaded from Apple. " +
+							"It must be an ES256 key.",
+					},
+					"client_secret_validity_days": schema.Int64Attribute{
+						Optional: true,
+						Computed: true,
+						Default:  int64default.StaticInt64(180),
+						Validators: []validator.Int64{
+							int64validator.Between(1, 180),
+						},
+						Description: "How many days a generated client secret is valid for. Apple accepts at most six months. " +
+							"The default is 180.",
+					},
+					"client_secret_expires_at": schema.StringAttribute{
+						Computed: true,
+						Description: "When the generated client secret expires, in RFC 3339 format. A new client secret is " +
+							"generated by the first apply within 30 days of this time, or when any of the key material changes.",
+					},
+				},
+				Description: "Sign in with Apple key material. If set, the client secret is generated locally as the " +
+					"ES256 signed JWT Apple expects, and `client_id` is your Services ID. This can only be set when " +
+					"`social_provider` is `Apple`.",
+			},
+			"microsoft": schema.SingleNestedAttribute{
+				Optional: true,
+				Attributes: map[string]schema.Attribute{
+					"tenant_id": schema.StringAttribute{
+						Optional: true,
+						Validators: []validator.String{
+							stringvalidator.RegexMatches(tenantIdRegex, "must be an Azure AD tenant ID or one of its verified domains"),
+						},
+						Description: "Only allow users of this Azure AD tenant to log in. This is the tenant ID or one of " +
+							"the tenant's verified domains, e.g. `contoso.onmicrosoft.com`.",
+					},
+					"organizations_only": schema.BoolAttribute{
+						Optional: true,
+						Computed: true,
+						Default:  booldefault.StaticBool(false),
+						Description: "If true, only work and school accounts from any Azure AD tenant can log in, " +
+							"and personal Microsoft accounts can't. This can't be combined with `tenant_id`. The default is false.",
+					},
+				},
+				Description: "Restrictions for Microsoft login. This can only be set when `social_provider` is `Microsoft`.",
+			},
+			"google": schema.SingleNestedAttribute{
+				Optional: true,
+				Attributes: map[string]schema.Attribute{
+					"hosted_domains": schema.SetAttribute{
+						Required:    true,
+						ElementType: types.StringType,
+						Validators: []validator.Set{
+							setvalidator.SizeAtLeast(1),
+							setvalidator.ValueStringsAre(
+								stringvalidator.RegexMatches(domainNameRegex, "must be a lowercase domain name, e.g. `example.com`"),
+							),
+						},
+						Description: "Only allow Google Workspace users of these hosted domains (`hd`) to log in.",
+					},
+				},
+				Description: "Restrictions for Google login. This can only be set when `social_provider` is `Google`.",
+			},
+			"github": schema.SingleNestedAttribute{
+				Optional: true,
+				Attributes: map[string]schema.Attribute{
+					"allowed_organizations": schema.SetAttribute{
+						Required:    true,
+						ElementType: types.StringType,
+						Validators: []validator.Set{
+							setvalidator.SizeAtLeast(1),
+							setvalidator.ValueStringsAre(
+								stringvalidator.RegexMatches(gitHubOrganizationRegex, "must be a GitHub organization name"),
+							),
+						},
+						Description: "Only allow members of these GitHub organizations to log in.",
+					},
+				},
+				Description: "Restrictions for GitHub login. This can only be set when `social_provider` is `GitHub`.",
+			},
+			"extra_scopes": schema.SetAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Validators: []validator.Set{
+					setvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
+				},
+				Description: "OAuth scopes to request on top of the ones PropelAuth always requests. " +
+					"Scopes the provider doesn't support are rejected by PropelAuth when applying.",
 			},
 		},
 	}
@@ -93,6 +238,87 @@ func (r *socialLoginResource) Configure(ctx context.Context, req resource.Config
 	r.client = client
 }
 
+func (r *socialLoginResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var socialProvider types.String
+	var clientSecret types.String
+	var apple types.Object
+	var privateKey types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("social_provider"), &socialProvider)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("client_secret"), &clientSecret)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("apple"), &apple)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("apple").AtName("private_key"), &privateKey)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	validateSocialLoginOptions(ctx, req.Config, socialProvider, &resp.Diagnostics)
+
+	if apple.IsNull() {
+		if clientSecret.IsNull() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("client_secret"),
+				"Missing client secret",
+				"`client_secret` is required unless the client secret is generated from Apple's key material with `apple`.",
+			)
+		}
+		return
+	}
+
+	if !socialProvider.IsUnknown() && propelauth.SocialProviderKey(socialProvider.ValueString()) != "apple" {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("apple"),
+			"Apple configuration for another social login provider",
+			fmt.Sprintf("`apple` can only be set when `social_provider` is `Apple`, not `%s`.", socialProvider.ValueString()),
+		)
+	}
+	if !clientSecret.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("client_secret"),
+			"Conflicting client secret",
+			"`client_secret` is generated from the key material in `apple`, so it can't also be set. "+
+				"Remove one of them to either generate the client secret or pass it through.",
+		)
+	}
+	if !privateKey.IsNull() && !privateKey.IsUnknown() {
+		if _, err := propelauth.ParseApplePrivateKey(privateKey.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("apple").AtName("private_key"), "Invalid Apple private key", err.Error())
+		}
+	}
+}
+
+func (r *socialLoginResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// nothing to plan when the resource is destroyed
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan socialLoginResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() || plan.Apple == nil {
+		return
+	}
+
+	var state *socialLoginResourceModel
+	if !req.State.Raw.IsNull() {
+		state = &socialLoginResourceModel{}
+		resp.Diagnostics.Append(req.State.Get(ctx, state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	// keep the generated client secret until it's about to expire or the key material changes
+	if state != nil && !appleClientSecretNeedsRenewal(state, &plan, time.Now()) {
+		plan.ClientSecret = state.ClientSecret
+		plan.Apple.ClientSecretExpiresAt = state.Apple.ClientSecretExpiresAt
+	} else {
+		plan.ClientSecret = types.StringUnknown()
+		plan.Apple.ClientSecretExpiresAt = types.StringUnknown()
+	}
+
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+}
+
 func (r *socialLoginResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var plan socialLoginResourceModel
 
@@ -103,8 +329,15 @@ func (r *socialLoginResource) Create(ctx context.Context, req resource.CreateReq
 		return
 	}
 
+	resp.Diagnostics.Append(generateAppleClientSecret(&plan, time.Now())...)
+	options, diags := socialLoginOptionsFromPlan(ctx, &plan, nil)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// upsert the client credentials for the social login
-	err := r.client.UpsertSocialLoginInfo(plan.SocialProvider.ValueString(), plan.ClientId.ValueString(), plan.ClientSecret.ValueString())
+	err := r.client.UpsertSocialLoginInfo(plan.SocialProvider.ValueString(), plan.ClientId.ValueString(), plan.ClientSecret.ValueString(), options)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error creating a Social Login in PropelAuth",
@@ -148,15 +381,24 @@ func (r *socialLoginResource) Read(ctx context.Context, req resource.ReadRequest
 }
 
 func (r *socialLoginResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	// Read Terraform plan data into the model
+	// Read Terraform plan and state data into the models
 	var plan socialLoginResourceModel
+	var state socialLoginResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(generateAppleClientSecret(&plan, time.Now())...)
+	options, diags := socialLoginOptionsFromPlan(ctx, &plan, &state)
+	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
 	// upsert the client credentials for the social login
-	err := r.client.UpsertSocialLoginInfo(plan.SocialProvider.ValueString(), plan.ClientId.ValueString(), plan.ClientSecret.ValueString())
+	err := r.client.UpsertSocialLoginInfo(plan.SocialProvider.ValueString(), plan.ClientId.ValueString(), plan.ClientSecret.ValueString(), options)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error updating social login",
@@ -193,3 +435,152 @@ func (r *socialLoginResource) Delete(ctx context.Context, req resource.DeleteReq
 func (r *socialLoginResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	resource.ImportStatePassthroughID(ctx, path.Root("social_provider"), req, resp)
 }
+
+// appleClientSecretNeedsRenewal reports whether the Apple client secret in the state can't be kept for the plan,
+// either because it was generated from different key material or because it's about to expire.
+func appleClientSecretNeedsRenewal(state *socialLoginResourceModel, plan *socialLoginResourceModel, now time.Time) bool {
+	if state.Apple == nil || state.ClientSecret.IsNull() {
+		return true
+	}
+	if !plan.ClientId.Equal(state.ClientId) ||
+		!plan.Apple.TeamId.Equal(state.Apple.TeamId) ||
+		!plan.Apple.KeyId.Equal(state.Apple.KeyId) ||
+		!plan.Apple.PrivateKey.Equal(state.Apple.PrivateKey) ||
+		!plan.Apple.ClientSecretValidityDays.Equal(state.Apple.ClientSecretValidityDays) {
+		return true
+	}
+
+	expiresAt, err := time.Parse(time.RFC3339, state.Apple.ClientSecretExpiresAt.ValueString())
+	if err != nil {
+		return true
+	}
+	renewalWindow := appleClientSecretRenewalWindow
+	if validity := time.Duration(state.Apple.ClientSecretValidityDays.ValueInt64()) * 24 * time.Hour; validity/2 < renewalWindow {
+		renewalWindow = validity / 2
+	}
+	return now.Add(renewalWindow).After(expiresAt)
+}
+
+// generateAppleClientSecret generates the Apple client secret for the plan if it was planned to be renewed.
+func generateAppleClientSecret(plan *socialLoginResourceModel, now time.Time) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if plan.Apple == nil || !plan.ClientSecret.IsUnknown() {
+		return diags
+	}
+
+	validity := time.Duration(plan.Apple.ClientSecretValidityDays.ValueInt64()) * 24 * time.Hour
+	clientSecret, err := propelauth.GenerateAppleClientSecret(
+		plan.Apple.TeamId.ValueString(),
+		plan.Apple.KeyId.ValueString(),
+		plan.ClientId.ValueString(),
+		plan.Apple.PrivateKey.ValueString(),
+		now,
+		validity,
+	)
+	if err != nil {
+		diags.AddAttributeError(
+			path.Root("apple"),
+			"Error generating the Apple client secret",
+			"Could not generate the client secret from the Apple key material: "+err.Error(),
+		)
+		return diags
+	}
+
+	plan.ClientSecret = types.StringValue(clientSecret)
+	plan.Apple.ClientSecretExpiresAt = types.StringValue(now.Add(validity).UTC().Format(time.RFC3339))
+	return diags
+}
+
+// validateSocialLoginOptions checks that the provider-specific options in the config match the social provider.
+func validateSocialLoginOptions(ctx context.Context, config tfsdk.Config, socialProvider types.String, diags *diag.Diagnostics) {
+	var microsoft types.Object
+	var tenantId types.String
+	var organizationsOnly types.Bool
+	var google types.Object
+	var gitHub types.Object
+	diags.Append(config.GetAttribute(ctx, path.Root("microsoft"), &microsoft)...)
+	diags.Append(config.GetAttribute(ctx, path.Root("microsoft").AtName("tenant_id"), &tenantId)...)
+	diags.Append(config.GetAttribute(ctx, path.Root("microsoft").AtName("organizations_only"), &organizationsOnly)...)
+	diags.Append(config.GetAttribute(ctx, path.Root("google"), &google)...)
+	diags.Append(config.GetAttribute(ctx, path.Root("github"), &gitHub)...)
+	if diags.HasError() {
+		return
+	}
+
+	if !tenantId.IsNull() && organizationsOnly.ValueBool() {
+		diags.AddAttributeError(
+			path.Root("microsoft").AtName("organizations_only"),
+			"Conflicting Microsoft restrictions",
+			"`organizations_only` allows work and school accounts from any tenant, so it can't be combined with `tenant_id`, "+
+				"which already only allows the accounts of one tenant.",
+		)
+	}
+
+	if socialProvider.IsUnknown() {
+		return
+	}
+	socialProviderKey := propelauth.SocialProviderKey(socialProvider.ValueString())
+	providerOptions := map[string]types.Object{
+		"microsoft": microsoft,
+		"google":    google,
+		"github":    gitHub,
+	}
+	for key, options := range providerOptions {
+		if !options.IsNull() && key != socialProviderKey {
+			knownProvider, _ := propelauth.LookupSocialProvider(key)
+			diags.AddAttributeError(
+				path.Root(key),
+				"Social login options for another provider",
+				fmt.Sprintf("`%s` can only be set when `social_provider` is `%s`, not `%s`.", key, knownProvider.Name, socialProvider.ValueString()),
+			)
+		}
+	}
+}
+
+// socialLoginOptionsFromPlan converts the provider-specific options of the plan into the request's options.
+// Options that were in the state but are no longer in the plan are sent empty, so their restriction is lifted.
+func socialLoginOptionsFromPlan(ctx context.Context, plan *socialLoginResourceModel, state *socialLoginResourceModel) (propelauth.SocialLoginOptions, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	options := propelauth.SocialLoginOptions{}
+
+	if plan.Microsoft != nil {
+		options.Microsoft = &propelauth.MicrosoftSocialLoginOptions{
+			TenantId:          plan.Microsoft.TenantId.ValueString(),
+			OrganizationsOnly: plan.Microsoft.OrganizationsOnly.ValueBool(),
+		}
+	} else if state != nil && state.Microsoft != nil {
+		options.Microsoft = &propelauth.MicrosoftSocialLoginOptions{}
+	}
+
+	if plan.Google != nil {
+		hostedDomains, setDiags := setToStrings(ctx, plan.Google.HostedDomains)
+		diags.Append(setDiags...)
+		options.Google = &propelauth.GoogleSocialLoginOptions{HostedDomains: hostedDomains}
+	} else if state != nil && state.Google != nil {
+		options.Google = &propelauth.GoogleSocialLoginOptions{HostedDomains: []string{}}
+	}
+
+	if plan.GitHub != nil {
+		allowedOrganizations, setDiags := setToStrings(ctx, plan.GitHub.AllowedOrganizations)
+		diags.Append(setDiags...)
+		options.GitHub = &propelauth.GitHubSocialLoginOptions{AllowedOrganizations: allowedOrganizations}
+	} else if state != nil && state.GitHub != nil {
+		options.GitHub = &propelauth.GitHubSocialLoginOptions{AllowedOrganizations: []string{}}
+	}
+
+	if !plan.ExtraScopes.IsNull() {
+		extraScopes, setDiags := setToStrings(ctx, plan.ExtraScopes)
+		diags.Append(setDiags...)
+		options.ExtraScopes = &extraScopes
+	} else if state != nil && !state.ExtraScopes.IsNull() {
+		options.ExtraScopes = &[]string{}
+	}
+
+	return options, diags
+}
+
+func setToStrings(ctx context.Context, set types.Set) ([]string, diag.Diagnostics) {
+	values := []string{}
+	diags := set.ElementsAs(ctx, &values, false)
+	return values, diags
+}
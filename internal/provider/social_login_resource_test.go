@@ -1,8 +1,14 @@
 package provider
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
 	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 )
@@ -48,3 +54,109 @@ resource "propelauth_social_login" "test" {
 }	  
 `, clientId, clientSecret)
 }
+
+func TestAppleClientSecretNeedsRenewal(t *testing.T) {
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	appleLogin := func(keyId string, expiresAt time.Time) *socialLoginResourceModel {
+		return &socialLoginResourceModel{
+			SocialProvider: types.StringValue("Apple"),
+			ClientId:       types.StringValue("com.example.signin"),
+			ClientSecret:   types.StringValue("generated-secret"),
+			Apple: &appleSocialLoginModel{
+				TeamId:                   types.StringValue("TEAM123456"),
+				KeyId:                    types.StringValue(keyId),
+				PrivateKey:               types.StringValue("private-key"),
+				ClientSecretValidityDays: types.Int64Value(180),
+				ClientSecretExpiresAt:    types.StringValue(expiresAt.Format(time.RFC3339)),
+			},
+		}
+	}
+
+	tests := []struct {
+		name  string
+		state *socialLoginResourceModel
+		plan  *socialLoginResourceModel
+		want  bool
+	}{
+		{
+			name:  "Test unchanged and far from expiring",
+			state: appleLogin("KEY1234567", now.Add(90*24*time.Hour)),
+			plan:  appleLogin("KEY1234567", now.Add(90*24*time.Hour)),
+			want:  false,
+		},
+		{
+			name:  "Test unchanged and about to expire",
+			state: appleLogin("KEY1234567", now.Add(10*24*time.Hour)),
+			plan:  appleLogin("KEY1234567", now.Add(10*24*time.Hour)),
+			want:  true,
+		},
+		{
+			name:  "Test changed key",
+			state: appleLogin("KEY1234567", now.Add(90*24*time.Hour)),
+			plan:  appleLogin("KEY7654321", now.Add(90*24*time.Hour)),
+			want:  true,
+		},
+		{
+			name:  "Test client secret passed through before",
+			state: &socialLoginResourceModel{ClientId: types.StringValue("com.example.signin"), ClientSecret: types.StringValue("SECRET")},
+			plan:  appleLogin("KEY1234567", now.Add(90*24*time.Hour)),
+			want:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := appleClientSecretNeedsRenewal(tt.state, tt.plan, now); got != tt.want {
+				t.Errorf("appleClientSecretNeedsRenewal() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSocialLoginOptionsFromPlan(t *testing.T) {
+	ctx := context.Background()
+	state := &socialLoginResourceModel{
+		Google: &googleSocialLoginModel{
+			HostedDomains: types.SetValueMust(types.StringType, []attr.Value{types.StringValue("example.com")}),
+		},
+		ExtraScopes: types.SetValueMust(types.StringType, []attr.Value{types.StringValue("openid")}),
+	}
+
+	tests := []struct {
+		name  string
+		plan  *socialLoginResourceModel
+		state *socialLoginResourceModel
+		want  string
+	}{
+		{
+			name: "Test no options",
+			plan: &socialLoginResourceModel{ExtraScopes: types.SetNull(types.StringType)},
+			want: `{}`,
+		},
+		{
+			name: "Test options set",
+			plan: &socialLoginResourceModel{
+				Microsoft:   &microsoftSocialLoginModel{TenantId: types.StringValue("contoso.onmicrosoft.com"), OrganizationsOnly: types.BoolValue(false)},
+				ExtraScopes: types.SetValueMust(types.StringType, []attr.Value{types.StringValue("User.Read")}),
+			},
+			want: `{"microsoft":{"tenant_id":"contoso.onmicrosoft.com","organizations_only":false},"extra_scopes":["User.Read"]}`,
+		},
+		{
+			name:  "Test removed options are cleared",
+			plan:  &socialLoginResourceModel{ExtraScopes: types.SetNull(types.StringType)},
+			state: state,
+			want:  `{"google":{"hosted_domains":[]},"extra_scopes":[]}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			options, diags := socialLoginOptionsFromPlan(ctx, tt.plan, tt.state)
+			if diags.HasError() {
+				t.Fatalf("socialLoginOptionsFromPlan() diagnostics = %v", diags)
+			}
+			got, _ := json.Marshal(options)
+			if string(got) != tt.want {
+				t.Errorf("socialLoginOptionsFromPlan() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
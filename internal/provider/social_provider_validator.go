@@ -0,0 +1,64 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"terraform-provider-propelauth/internal/propelauth"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+var _ validator.String = socialProviderValidator{}
+
+var socialProviderNameRegex = regexp.MustCompile(`^[A-Za-z0-9]+$`)
+
+// socialProviderValidator checks a social login provider against the providers known to this version of the provider.
+// Unknown providers are only warned about, as PropelAuth may support providers added after this release.
+type socialProviderValidator struct{}
+
+func (v socialProviderValidator) Description(ctx context.Context) string {
+	return "value should be one of: " + strings.Join(propelauth.SocialProviderNames(), ", ")
+}
+
+func (v socialProviderValidator) MarkdownDescription(ctx context.Context) string {
+	return "value should be one of: `" + strings.Join(propelauth.SocialProviderNames(), "`, `") + "`"
+}
+
+func (v socialProviderValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	socialProvider := req.ConfigValue.ValueString()
+	if !socialProviderNameRegex.MatchString(socialProvider) {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid social login provider",
+			fmt.Sprintf("`%s` is not a valid social login provider name, it must only contain letters and digits.", socialProvider),
+		)
+		return
+	}
+	if _, ok := propelauth.LookupSocialProvider(socialProvider); ok {
+		return
+	}
+
+	detail := fmt.Sprintf("`%s` is not a social login provider known to this version of the provider. "+
+		"It will be sent to PropelAuth as `%s`, which fails if PropelAuth doesn't support it.",
+		socialProvider, propelauth.SocialProviderKey(socialProvider))
+	if suggestion, ok := propelauth.ClosestMatch(propelauth.SocialProviderNames(), socialProvider, 2); ok {
+		detail = fmt.Sprintf("`%s` is not a social login provider known to this version of the provider. Did you mean `%s`?",
+			socialProvider, suggestion)
+	}
+
+	resp.Diagnostics.AddAttributeWarning(req.Path, "Unknown social login provider", detail)
+}
+
+// socialProviderDescription lists the known social login providers for an attribute description.
+func socialProviderDescription() string {
+	names := propelauth.SocialProviderNames()
+	return "Known values are `" + strings.Join(names[:len(names)-1], "`, `") + "`, and `" + names[len(names)-1] + "`. " +
+		"Providers PropelAuth adds later can also be used by name."
+}
@@ -0,0 +1,384 @@
+package provider
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"io"
+	"net/http"
+	"os"
+	"regexp"
+	"strings"
+
+	"terraform-provider-propelauth/internal/propelauth"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// terraformJsonOutput is the subset of `terraform show -json` output needed for a theme preview.
+// Plans carry their values under `planned_values`, while state files use `values`.
+type terraformJsonOutput struct {
+	PlannedValues *terraformJsonValues `json:"planned_values"`
+	Values        *terraformJsonValues `json:"values"`
+}
+
+type terraformJsonValues struct {
+	RootModule terraformJsonModule `json:"root_module"`
+}
+
+type terraformJsonModule struct {
+	Resources    []terraformJsonResource `json:"resources"`
+	ChildModules []terraformJsonModule   `json:"child_modules"`
+}
+
+type terraformJsonResource struct {
+	Address string          `json:"address"`
+	Mode    string          `json:"mode"`
+	Type    string          `json:"type"`
+	Values  json.RawMessage `json:"values"`
+}
+
+type themePreviewPage struct {
+	Label              string
+	Theme              *propelauth.Theme
+	ProjectName        string
+	LogoUrl            template.URL
+	Variables          template.CSS
+	LoginBackground    template.CSS
+	SplitDirection     string
+	SplitImageUrl      template.URL
+	DisplayProjectName bool
+}
+
+// RenderThemePreview writes a static HTML approximation of the hosted login and management pages
+// for the `propelauth_theme` and `propelauth_darkmode_theme` resources found in the output of
+// `terraform show -json`. Images from `propelauth_image` resources are embedded from their local `source`.
+func RenderThemePreview(ctx context.Context, terraformJson []byte, w io.Writer) error {
+	var output terraformJsonOutput
+	if err := json.Unmarshal(terraformJson, &output); err != nil {
+		return fmt.Errorf("error parsing terraform JSON output: %w", err)
+	}
+
+	values := output.PlannedValues
+	if values == nil {
+		values = output.Values
+	}
+	if values == nil {
+		return fmt.Errorf("terraform JSON output has neither `planned_values` nor `values`, use `terraform show -json` on a plan or state")
+	}
+
+	resources := collectManagedResources(values.RootModule)
+
+	projectName := "My Project"
+	images := map[string]template.URL{}
+	for _, r := range resources {
+		switch r.Type {
+		case "propelauth_project_info":
+			var projectInfo struct {
+				Name string `json:"name"`
+			}
+			if err := json.Unmarshal(r.Values, &projectInfo); err == nil && projectInfo.Name != "" {
+				projectName = projectInfo.Name
+			}
+		case "propelauth_image":
+			var image struct {
+				Source    string `json:"source"`
+				ImageType string `json:"image_type"`
+				ImageUrl  string `json:"image_url"`
+			}
+			if err := json.Unmarshal(r.Values, &image); err != nil {
+				return fmt.Errorf("error parsing %s: %w", r.Address, err)
+			}
+			images[image.ImageType] = previewImageUrl(image.Source, image.ImageUrl)
+		}
+	}
+
+	var pages []themePreviewPage
+	for _, r := range resources {
+		var label, logoType, backgroundType string
+		var themeResource resource.Resource
+		switch r.Type {
+		case "propelauth_theme":
+			label, logoType, backgroundType = "Light theme", "logo", "background"
+			themeResource = NewThemeResource()
+		case "propelauth_darkmode_theme":
+			label, logoType, backgroundType = "Dark theme", "darkmode_logo", "darkmode_background"
+			themeResource = NewDarkmodeThemeResource()
+		default:
+			continue
+		}
+
+		theme, err := decodeThemeValues(ctx, themeResource, r.Values)
+		if err != nil {
+			return fmt.Errorf("error reading %s: %w", r.Address, err)
+		}
+
+		pages = append(pages, newThemePreviewPage(label+" ("+r.Address+")", theme, projectName, images[logoType], images[backgroundType]))
+	}
+
+	if len(pages) == 0 {
+		return fmt.Errorf("no `propelauth_theme` or `propelauth_darkmode_theme` resources were found")
+	}
+
+	return themePreviewTemplate.Execute(w, struct {
+		Fonts []string
+		Pages []themePreviewPage
+	}{
+		Fonts: previewFonts(pages),
+		Pages: pages,
+	})
+}
+
+func collectManagedResources(module terraformJsonModule) []terraformJsonResource {
+	var resources []terraformJsonResource
+	for _, r := range module.Resources {
+		if r.Mode == "" || r.Mode == "managed" {
+			resources = append(resources, r)
+		}
+	}
+	for _, child := range module.ChildModules {
+		resources = append(resources, collectManagedResources(child)...)
+	}
+
+	return resources
+}
+
+// decodeThemeValues converts the JSON values of a theme resource into the API model through the
+// resource's own schema, so the preview uses exactly the same conversion as an apply.
+func decodeThemeValues(ctx context.Context, themeResource resource.Resource, values json.RawMessage) (*propelauth.Theme, error) {
+	var schemaResp resource.SchemaResponse
+	themeResource.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	raw, err := tftypes.ValueFromJSONWithOpts(
+		values,
+		schemaResp.Schema.Type().TerraformType(ctx),
+		tftypes.ValueFromJSONOpts{IgnoreUndefinedAttributes: true},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	var model themeResourceModel
+	diags := tfsdk.Plan{Schema: schemaResp.Schema, Raw: raw}.Get(ctx, &model)
+	if diags.HasError() {
+		return nil, fmt.Errorf("%s: %s", diags.Errors()[0].Summary(), diags.Errors()[0].Detail())
+	}
+
+	login := model.LoginPageTheme
+	missingParameters := (login.BackgroundType.ValueString() == "Solid" && login.SolidBackgroundParameters == nil) ||
+		(login.BackgroundType.ValueString() == "Gradient" && login.GradientBackgroundParameters == nil) ||
+		(login.BackgroundType.ValueString() == "Image" && login.ImageBackgroundParameters == nil) ||
+		(login.Layout.ValueString() == "SplitScreen" && login.SplitLoginPageParameters == nil)
+	if missingParameters {
+		return nil, fmt.Errorf("the login page theme is missing the parameters for its `layout` or `background_type`")
+	}
+
+	return convertPlanToTheme(&model), nil
+}
+
+// previewImageUrl embeds a local image as a data URI, falling back to the uploaded image's URL.
+func previewImageUrl(source string, imageUrl string) template.URL {
+	data, err := os.ReadFile(source)
+	if err != nil {
+		return template.URL(imageUrl)
+	}
+
+	return template.URL("data:" + http.DetectContentType(data) + ";base64," + base64.StdEncoding.EncodeToString(data))
+}
+
+func newThemePreviewPage(label string, theme *propelauth.Theme, projectName string, logoUrl template.URL, backgroundUrl template.URL) themePreviewPage {
+	variables := []struct {
+		name  string
+		color propelauth.RgbColor
+	}{
+		{"background", theme.BackgroundColor},
+		{"background-text", theme.BackgroundTextColor},
+		{"secondary-text", theme.SecondaryBackgroundTextColor},
+		{"border", theme.BorderColor},
+		{"frame", theme.FrameBackgroundColor},
+		{"frame-text", theme.FrameTextColor},
+		{"primary", theme.PrimaryColor},
+		{"primary-text", theme.PrimaryTextColor},
+		{"error", theme.ErrorButtonColor},
+		{"error-text", theme.ErrorButtonTextColor},
+		{"main", theme.ManagementPagesTheme.MainBackgroundColor},
+		{"main-text", theme.ManagementPagesTheme.MainTextColor},
+		{"navbar", theme.ManagementPagesTheme.NavbarBackgroundColor},
+		{"navbar-text", theme.ManagementPagesTheme.NavbarTextColor},
+		{"management-border", theme.ManagementPagesTheme.BorderColor},
+		{"action-button", theme.ManagementPagesTheme.ActionButtonColor},
+		{"action-button-text", theme.ManagementPagesTheme.ActionButtonTextColor},
+	}
+
+	var css strings.Builder
+	for _, variable := range variables {
+		fmt.Fprintf(&css, "--%s: %s; ", variable.name, previewCssColor(variable.color))
+	}
+	fmt.Fprintf(&css, "--header-font: '%s', sans-serif; ", previewFontFamily(theme.HeaderFont))
+	fmt.Fprintf(&css, "--body-font: '%s', sans-serif;", previewFontFamily(theme.BodyFont))
+
+	background := "background: " + previewCssColor(theme.BackgroundColor) + ";"
+	switch theme.BackgroundType {
+	case "Gradient":
+		background = fmt.Sprintf(
+			"background: linear-gradient(%ddeg, %s, %s);",
+			theme.GradientAngle, previewCssColor(theme.BackgroundColor), previewCssColor(theme.SecondaryBackgroundColor),
+		)
+	case "Image":
+		if backgroundUrl != "" {
+			background = fmt.Sprintf(
+				"background: %s url('%s') center / cover no-repeat;",
+				previewCssColor(theme.BackgroundColor), backgroundUrl,
+			)
+		}
+	}
+
+	splitDirection := ""
+	var splitImageUrl template.URL
+	if theme.LoginLayout == "SplitScreen" && theme.Splitscreen != nil {
+		splitDirection = theme.Splitscreen.Direction
+		if theme.Splitscreen.ContentType == "Image" {
+			splitImageUrl = backgroundUrl
+		}
+	}
+
+	return themePreviewPage{
+		Label:              label,
+		Theme:              theme,
+		ProjectName:        projectName,
+		LogoUrl:            logoUrl,
+		Variables:          template.CSS(css.String()),
+		LoginBackground:    template.CSS(background),
+		SplitDirection:     splitDirection,
+		SplitImageUrl:      splitImageUrl,
+		DisplayProjectName: theme.DisplayProjectName,
+	}
+}
+
+func previewCssColor(color propelauth.RgbColor) string {
+	return fmt.Sprintf("rgb(%d, %d, %d)", color.Red, color.Green, color.Blue)
+}
+
+var fontNameBoundary = regexp.MustCompile(`([a-z])([A-Z])`)
+var fontNameUnsafe = regexp.MustCompile(`[^A-Za-z0-9 ]`)
+
+// previewFontFamily turns a PascalCase font name such as `PlusJakartaSans` into its family name `Plus Jakarta Sans`.
+func previewFontFamily(font string) string {
+	return fontNameBoundary.ReplaceAllString(fontNameUnsafe.ReplaceAllString(font, ""), "$1 $2")
+}
+
+func previewFonts(pages []themePreviewPage) []string {
+	var fonts []string
+	for _, page := range pages {
+		for _, font := range []string{page.Theme.HeaderFont, page.Theme.BodyFont} {
+			family := previewFontFamily(font)
+			if family != "" && !propelauth.Contains(fonts, family) {
+				fonts = append(fonts, family)
+			}
+		}
+	}
+
+	return fonts
+}
+
+var themePreviewTemplate = template.Must(template.New("theme_preview").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>PropelAuth theme preview</title>
+{{- if .Fonts}}
+<link rel="stylesheet" href="https://fonts.googleapis.com/css2?{{range .Fonts}}family={{.}}:wght@400;600&{{end}}display=swap">
+{{- end}}
+<style>
+  body { margin: 0; padding: 24px; background: #e9e9e9; font-family: sans-serif; }
+  .preview-label { margin: 32px 0 8px; font: 600 14px sans-serif; color: #444; }
+  .screen { border: 1px solid #bbb; border-radius: 6px; overflow: hidden; min-height: 560px; display: flex; font-family: var(--body-font); }
+  .screen h1, .screen h2 { font-family: var(--header-font); margin: 0 0 12px; }
+  .login { align-items: center; justify-content: center; color: var(--background-text); }
+  .login.split { align-items: stretch; }
+  .login.split.Right { flex-direction: row-reverse; }
+  .login-side { display: flex; flex: 1; align-items: center; justify-content: center; padding: 32px; }
+  .login.split .login-side { background: var(--frame); }
+  .split-content { flex: 1; display: flex; flex-direction: column; justify-content: center; padding: 48px; }
+  .split-content p { color: var(--secondary-text); }
+  .split-content.image { padding: 0; background: center / cover no-repeat; }
+  .frame { width: 340px; padding: 32px; color: var(--frame-text); }
+  .Frame .frame { background: var(--frame); border: 1px solid var(--border); border-radius: 8px; box-shadow: 0 2px 8px rgba(0, 0, 0, 0.08); }
+  .Frameless .frame { color: var(--background-text); }
+  .logo { display: block; max-height: 48px; margin: 0 auto 16px; }
+  .project-name { text-align: center; }
+  label { display: block; font-size: 14px; margin: 12px 0 4px; }
+  input { box-sizing: border-box; width: 100%; padding: 8px 10px; font: inherit; background: var(--frame); color: var(--frame-text); border: 1px solid var(--border); border-radius: 4px; }
+  button { width: 100%; margin-top: 16px; padding: 10px; font: 600 14px var(--body-font); border: 0; border-radius: 4px; cursor: pointer; }
+  .primary { background: var(--primary); color: var(--primary-text); }
+  .error-button { background: var(--error); color: var(--error-text); }
+  .error-message { margin-top: 12px; font-size: 14px; color: var(--error); }
+  .link { display: block; margin-top: 12px; font-size: 14px; color: var(--primary); }
+  .management { background: var(--main); color: var(--main-text); }
+  .management nav { width: 200px; padding: 24px 16px; background: var(--navbar); color: var(--navbar-text); border-right: 1px solid var(--management-border); }
+  .management nav div { padding: 8px 0; }
+  .management main { flex: 1; padding: 32px; }
+  .management .card { max-width: 480px; padding: 24px; border: 1px solid var(--management-border); border-radius: 8px; }
+  .management button { width: auto; padding: 8px 20px; background: var(--action-button); color: var(--action-button-text); }
+</style>
+</head>
+<body>
+{{- range .Pages}}
+<section style="{{.Variables}}">
+  <h2 class="preview-label">{{.Label}} &mdash; login page</h2>
+  <div class="screen login {{.Theme.LoginLayout}}{{if .SplitDirection}} split {{.SplitDirection}}{{end}}" style="{{.LoginBackground}}">
+    <div class="login-side">
+      <div class="frame">
+        {{- if .LogoUrl}}
+        <img class="logo" src="{{.LogoUrl}}" alt="Logo">
+        {{- end}}
+        {{- if .DisplayProjectName}}
+        <h1 class="project-name">{{.ProjectName}}</h1>
+        {{- end}}
+        <label>Email</label>
+        <input type="email" placeholder="you@example.com">
+        <label>Password</label>
+        <input type="password" value="password">
+        <button class="primary">Log in</button>
+        <div class="error-message">Incorrect email or password.</div>
+        <a class="link">Forgot password?</a>
+        <button class="error-button">Cancel</button>
+      </div>
+    </div>
+    {{- if .SplitDirection}}
+    <div class="split-content{{if .SplitImageUrl}} image{{end}}"{{if .SplitImageUrl}} style="background-image: url('{{.SplitImageUrl}}');"{{end}}>
+      {{- if eq .Theme.Splitscreen.ContentType "Text"}}
+      <h1>{{.Theme.Splitscreen.Header}}</h1>
+      <p>{{.Theme.Splitscreen.Subheader}}</p>
+      {{- end}}
+    </div>
+    {{- end}}
+  </div>
+  <h2 class="preview-label">{{.Label}} &mdash; account page</h2>
+  <div class="screen management">
+    {{- if .Theme.ManagementPagesTheme.DisplayNavbar}}
+    <nav>
+      <h2>{{.ProjectName}}</h2>
+      <div>Account</div>
+      <div>Organizations</div>
+      <div>API Keys</div>
+    </nav>
+    {{- end}}
+    <main>
+      <h1>Account</h1>
+      <div class="card">
+        <label>Name</label>
+        <input type="text" value="Jane Doe">
+        <button>Save</button>
+      </div>
+    </main>
+  </div>
+</section>
+{{- end}}
+</body>
+</html>
+`))
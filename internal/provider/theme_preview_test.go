@@ -0,0 +1,102 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+)
+
+const testThemePreviewValues = `{"root_module":{"resources":[
+  {"address":"propelauth_project_info.example","mode":"managed","type":"propelauth_project_info","values":{"name":"Acme"}},
+  {"address":"propelauth_theme.example","mode":"managed","type":"propelauth_theme","values":{
+    "header_font":"PlusJakartaSans","body_font":"Inter","display_project_name":true,"strict_accessibility":false,
+    "login_page_theme":{
+      "layout":"SplitScreen","background_type":"Gradient",
+      "gradient_background_parameters":{"background_gradient_start_color":"#0c1cf7","background_gradient_end_color":"black","background_gradient_angle":45,"background_text_color":"#ffffff"},
+      "solid_background_parameters":null,"image_background_parameters":null,
+      "frame_background_color":"#fff","frame_text_color":"#700278","primary_color":"#02927d","primary_text_color":"#ffffff",
+      "error_color":"#cf222e","error_button_text_color":"#ffffff","border_color":"#000000",
+      "split_login_page_parameters":{"direction":"Right","content_type":"Text","header":"Welcome <back>","subheader":"Log in to Acme","secondary_background_text_color":"#eeeeee"}
+    },
+    "management_pages_theme":{
+      "main_background_color":"#c1a0eb","main_text_color":"#2d4036","navbar_background_color":"#e4c7f2","navbar_text_color":"#2d4036",
+      "action_button_color":"#629c75","action_button_text_color":"#f7f7f7","border_color":"#fcfcfc","display_navbar":true
+    }
+  }}
+]}}`
+
+func TestRenderThemePreview(t *testing.T) {
+	tests := []struct {
+		name          string
+		terraformJson string
+		wantContains  []string
+		wantErr       string
+	}{
+		{
+			name:          "Test plan",
+			terraformJson: `{"planned_values":` + testThemePreviewValues + `}`,
+			wantContains: []string{
+				"family=Plus%20Jakarta%20Sans:wght@400;600&family=Inter:wght@400;600&display=swap",
+				"--background: rgb(12, 28, 247);",
+				"--frame: rgb(255, 255, 255);",
+				"Light theme (propelauth_theme.example) &mdash; login page",
+				`<h1 class="project-name">Acme</h1>`,
+				"<h1>Welcome &lt;back&gt;</h1>",
+			},
+		},
+		{
+			name:          "Test state",
+			terraformJson: `{"values":` + testThemePreviewValues + `}`,
+			wantContains:  []string{"Light theme (propelauth_theme.example) &mdash; account page"},
+		},
+		{
+			name: "Test split-screen image content",
+			terraformJson: `{"planned_values":` + strings.NewReplacer(
+				`"resources":[`, `"resources":[{"address":"propelauth_image.background","mode":"managed","type":"propelauth_image","values":{"image_type":"background","image_url":"https://example.com/background.png"}},`,
+				`"content_type":"Text"`, `"content_type":"Image","background_image_id":"background"`,
+			).Replace(testThemePreviewValues) + `}`,
+			wantContains: []string{`<div class="split-content image" style="background-image: url('https://example.com/background.png');">`},
+		},
+		{
+			name:          "Test invalid JSON",
+			terraformJson: `{"planned_values":`,
+			wantErr:       "error parsing terraform JSON output",
+		},
+		{
+			name:          "Test neither plan nor state",
+			terraformJson: `{"format_version":"1.2"}`,
+			wantErr:       "neither `planned_values` nor `values`",
+		},
+		{
+			name:          "Test no themes",
+			terraformJson: `{"planned_values":{"root_module":{"resources":[]}}}`,
+			wantErr:       "no `propelauth_theme` or `propelauth_darkmode_theme` resources were found",
+		},
+		{
+			name:          "Test invalid theme values",
+			terraformJson: `{"planned_values":{"root_module":{"resources":[{"address":"propelauth_theme.example","mode":"managed","type":"propelauth_theme","values":{"header_font":1}}]}}}`,
+			wantErr:       "error reading propelauth_theme.example",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var html bytes.Buffer
+			err := RenderThemePreview(context.Background(), []byte(tt.terraformJson), &html)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("RenderThemePreview() error = %v, want it to contain %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("RenderThemePreview() error = %v", err)
+			}
+			for _, want := range tt.wantContains {
+				if !strings.Contains(html.String(), want) {
+					t.Errorf("RenderThemePreview() output doesn't contain %q", want)
+				}
+			}
+		})
+	}
+}
@@ -3,7 +3,6 @@ package provider
 import (
 	"context"
 	"fmt"
-	"regexp"
 	"strings"
 
 	"github.com/reiver/go-hexcolor"
@@ -11,6 +10,7 @@ import (
 	"terraform-provider-propelauth/internal/propelauth"
 
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -26,6 +26,7 @@ import (
 var _ resource.Resource = &themeResource{}
 var _ resource.ResourceWithConfigure = &themeResource{}
 var _ resource.ResourceWithValidateConfig = &themeResource{}
+var _ resource.ResourceWithModifyPlan = &themeResource{}
 var _ resource.ResourceWithImportState = &themeResource{}
 
 func NewThemeResource() resource.Resource {
@@ -44,6 +45,7 @@ type themeResourceModel struct {
 	LoginPageTheme       loginPageTheme       `tfsdk:"login_page_theme"`
 	ManagementPagesTheme managementPagesTheme `tfsdk:"management_pages_theme"`
 	DisplayProjectName   types.Bool           `tfsdk:"display_project_name"`
+	StrictAccessibility  types.Bool           `tfsdk:"strict_accessibility"`
 }
 
 type loginPageTheme struct {
@@ -52,13 +54,13 @@ type loginPageTheme struct {
 	SolidBackgroundParameters    *solidBackgroundParameters    `tfsdk:"solid_background_parameters"`
 	GradientBackgroundParameters *gradientBackgroundParameters `tfsdk:"gradient_background_parameters"`
 	ImageBackgroundParameters    *imageBackgroundParameters    `tfsdk:"image_background_parameters"`
-	FrameBackgroundColor         types.String                  `tfsdk:"frame_background_color"`
-	FrameTextColor               types.String                  `tfsdk:"frame_text_color"`
-	PrimaryColor                 types.String                  `tfsdk:"primary_color"`
-	PrimaryTextColor             types.String                  `tfsdk:"primary_text_color"`
-	ErrorColor                   types.String                  `tfsdk:"error_color"`
-	ErrorButtonTextColor         types.String                  `tfsdk:"error_button_text_color"`
-	BorderColor                  types.String                  `tfsdk:"border_color"`
+	FrameBackgroundColor         colorValue                    `tfsdk:"frame_background_color"`
+	FrameTextColor               colorValue                    `tfsdk:"frame_text_color"`
+	PrimaryColor                 colorValue                    `tfsdk:"primary_color"`
+	PrimaryTextColor             colorValue                    `tfsdk:"primary_text_color"`
+	ErrorColor                   colorValue                    `tfsdk:"error_color"`
+	ErrorButtonTextColor         colorValue                    `tfsdk:"error_button_text_color"`
+	BorderColor                  colorValue                    `tfsdk:"border_color"`
 	SplitLoginPageParameters     *splitLoginPageParameters     `tfsdk:"split_login_page_parameters"`
 }
 
@@ -67,35 +69,37 @@ type splitLoginPageParameters struct {
 	ContentType                  types.String `tfsdk:"content_type"`
 	Header                       types.String `tfsdk:"header"`
 	Subheader                    types.String `tfsdk:"subheader"`
-	SecondaryBackgroundTextColor types.String `tfsdk:"secondary_background_text_color"`
+	SecondaryBackgroundTextColor colorValue   `tfsdk:"secondary_background_text_color"`
+	BackgroundImageId            types.String `tfsdk:"background_image_id"`
 }
 
 type solidBackgroundParameters struct {
-	BackgroundColor     types.String `tfsdk:"background_color"`
-	BackgroundTextColor types.String `tfsdk:"background_text_color"`
+	BackgroundColor     colorValue `tfsdk:"background_color"`
+	BackgroundTextColor colorValue `tfsdk:"background_text_color"`
 }
 
 type gradientBackgroundParameters struct {
-	BackgroundGradientStartColor types.String `tfsdk:"background_gradient_start_color"`
-	BackgroundGradientEndColor   types.String `tfsdk:"background_gradient_end_color"`
-	BackgroundGradientAngle      types.Int32  `tfsdk:"background_gradient_angle"`
-	BackgroundTextColor          types.String `tfsdk:"background_text_color"`
+	BackgroundGradientStartColor colorValue  `tfsdk:"background_gradient_start_color"`
+	BackgroundGradientEndColor   colorValue  `tfsdk:"background_gradient_end_color"`
+	BackgroundGradientAngle      types.Int32 `tfsdk:"background_gradient_angle"`
+	BackgroundTextColor          colorValue  `tfsdk:"background_text_color"`
 }
 
 type imageBackgroundParameters struct {
-	DefaultBackgroundColor types.String `tfsdk:"default_background_color"`
-	BackgroundTextColor    types.String `tfsdk:"background_text_color"`
+	DefaultBackgroundColor colorValue   `tfsdk:"default_background_color"`
+	BackgroundTextColor    colorValue   `tfsdk:"background_text_color"`
+	BackgroundImageId      types.String `tfsdk:"background_image_id"`
 }
 
 type managementPagesTheme struct {
-	MainBackgroundColor   types.String `tfsdk:"main_background_color"`
-	MainTextColor         types.String `tfsdk:"main_text_color"`
-	NavbarBackgroundColor types.String `tfsdk:"navbar_background_color"`
-	NavbarTextColor       types.String `tfsdk:"navbar_text_color"`
-	ActionButtonColor     types.String `tfsdk:"action_button_color"`
-	ActionButtonTextColor types.String `tfsdk:"action_button_text_color"`
-	BorderColor           types.String `tfsdk:"border_color"`
-	DisplayNavbar         types.Bool   `tfsdk:"display_navbar"`
+	MainBackgroundColor   colorValue `tfsdk:"main_background_color"`
+	MainTextColor         colorValue `tfsdk:"main_text_color"`
+	NavbarBackgroundColor colorValue `tfsdk:"navbar_background_color"`
+	NavbarTextColor       colorValue `tfsdk:"navbar_text_color"`
+	ActionButtonColor     colorValue `tfsdk:"action_button_color"`
+	ActionButtonTextColor colorValue `tfsdk:"action_button_text_color"`
+	BorderColor           colorValue `tfsdk:"border_color"`
+	DisplayNavbar         types.Bool `tfsdk:"display_navbar"`
 }
 
 func (r *themeResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -103,30 +107,29 @@ func (r *themeResource) Metadata(ctx context.Context, req resource.MetadataReque
 }
 
 func (r *themeResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
-	hexcode_regex := regexp.MustCompile(`^#(?:[0-9a-f]{3}){1,2}$`)
-
 	resp.Schema = schema.Schema{
-		Description: "Hosted Pages Look & Feel. This is for configuring the look and feel of your PropelAuth hosted pages",
+		Description: "Hosted Pages Look & Feel. This is for configuring the look and feel of your PropelAuth hosted pages. " +
+			"Colors can be written as hex codes (`#fff`, `#ffffff` or `#ffffffff`), `rgb()`, `hsl()` or CSS named colors. " +
+			"The configured spelling is kept as long as PropelAuth returns the same color, and any alpha channel is ignored.",
 		Attributes: map[string]schema.Attribute{
 			"header_font": schema.StringAttribute{
 				Optional: true,
 				Computed: true,
 				Default:  stringdefault.StaticString("Inter"),
 				Validators: []validator.String{
-					stringvalidator.OneOf(
-						"Roboto", "Inter", "OpenSans", "Montserrat", "Lato", "Poppins", "Raleway", "Jost",
-						"Fraunces", "Caveat", "PlusJakartaSans",
-					),
+					fontValidator{},
 				},
 				Description: "The font used for all headings in your hosted pages written in PascalCase. This includes both login and management pages. " +
-					"Options include `Roboto`, `Inter`, `OpenSans`, `Montserrat`, `Lato`, `Poppins`, `Raleway`, `Jost`, " +
-					"`Fraunces`, `Caveat`, `PlusJakartaSans`, etc" +
-					"The default value is `Inter`",
+					"Options are `Roboto`, `Inter`, `OpenSans`, `Montserrat`, `Lato`, `Poppins`, `Raleway`, `Jost`, " +
+					"`Fraunces`, `Caveat` and `PlusJakartaSans`. The default value is `Inter`",
 			},
 			"body_font": schema.StringAttribute{
 				Optional: true,
 				Computed: true,
 				Default:  stringdefault.StaticString("Inter"),
+				Validators: []validator.String{
+					fontValidator{},
+				},
 				Description: "The font used for all body text in your hosted pages. This includes both login and management pages. " +
 					"The available options are the same as for `header_font`. The default value is `Inter`",
 			},
@@ -137,6 +140,14 @@ func (r *themeResource) Schema(ctx context.Context, req resource.SchemaRequest,
 				Description: "If true, the project name is displayed in the header of the login page. " +
 					"The default value is `true`",
 			},
+			"strict_accessibility": schema.BoolAttribute{
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+				Description: "If true, text and background color pairs that fall below the WCAG AA contrast ratio of 4.5:1 " +
+					"are reported as errors instead of warnings. Pairs where neither color is set are PropelAuth's defaults and aren't checked. " +
+					"The default value is `false`",
+			},
 			"login_page_theme": schema.SingleNestedAttribute{
 				Description: "The theme for the login page",
 				Required:    true,
@@ -166,21 +177,17 @@ func (r *themeResource) Schema(ctx context.Context, req resource.SchemaRequest,
 						Description: "The parameters required for a solid background in the login page",
 						Attributes: map[string]schema.Attribute{
 							"background_color": schema.StringAttribute{
-								Optional: true,
-								Computed: true,
-								Default:  stringdefault.StaticString("#f7f7f7"),
-								Validators: []validator.String{
-									stringvalidator.RegexMatches(hexcode_regex, "background_color must be a valid hex color code with lowercase characters"),
-								},
+								Optional:    true,
+								Computed:    true,
+								Default:     stringdefault.StaticString("#f7f7f7"),
+								CustomType:  colorType{},
 								Description: "The color of a solid background in the login page. The default value is `#f7f7f7`",
 							},
 							"background_text_color": schema.StringAttribute{
-								Optional: true,
-								Computed: true,
-								Default:  stringdefault.StaticString("#363636"),
-								Validators: []validator.String{
-									stringvalidator.RegexMatches(hexcode_regex, "background_text_color must be a valid hex color code with lowercase characters"),
-								},
+								Optional:    true,
+								Computed:    true,
+								Default:     stringdefault.StaticString("#363636"),
+								CustomType:  colorType{},
 								Description: "The color of the text on a solid background in the login page. The default value is `#363636`",
 							},
 						},
@@ -190,21 +197,17 @@ func (r *themeResource) Schema(ctx context.Context, req resource.SchemaRequest,
 						Description: "The parameters required for a gradient background in the login page",
 						Attributes: map[string]schema.Attribute{
 							"background_gradient_start_color": schema.StringAttribute{
-								Optional: true,
-								Computed: true,
-								Default:  stringdefault.StaticString("#f7f7f7"),
-								Validators: []validator.String{
-									stringvalidator.RegexMatches(hexcode_regex, "background_gradient_start_color must be a valid hex color code with lowercase characters"),
-								},
+								Optional:    true,
+								Computed:    true,
+								Default:     stringdefault.StaticString("#f7f7f7"),
+								CustomType:  colorType{},
 								Description: "The start color of a gradient background in the login page. The default value is `#f7f7f7`",
 							},
 							"background_gradient_end_color": schema.StringAttribute{
-								Optional: true,
-								Computed: true,
-								Default:  stringdefault.StaticString("#f7f7f7"),
-								Validators: []validator.String{
-									stringvalidator.RegexMatches(hexcode_regex, "background_gradient_end_color must be a valid hex color code with lowercase characters"),
-								},
+								Optional:    true,
+								Computed:    true,
+								Default:     stringdefault.StaticString("#f7f7f7"),
+								CustomType:  colorType{},
 								Description: "The end color of a gradient background in the login page. The default value is `#f7f7f7`",
 							},
 							"background_gradient_angle": schema.Int32Attribute{
@@ -214,12 +217,10 @@ func (r *themeResource) Schema(ctx context.Context, req resource.SchemaRequest,
 								Description: "The angle of the gradient background in the login page. The default value is `135`",
 							},
 							"background_text_color": schema.StringAttribute{
-								Optional: true,
-								Computed: true,
-								Default:  stringdefault.StaticString("#363636"),
-								Validators: []validator.String{
-									stringvalidator.RegexMatches(hexcode_regex, "background_text_color must be a valid hex color code with lowercase characters"),
-								},
+								Optional:    true,
+								Computed:    true,
+								Default:     stringdefault.StaticString("#363636"),
+								CustomType:  colorType{},
 								Description: "The color of the text on a gradient background in the login page. The default value is `#363636`",
 							},
 						},
@@ -229,91 +230,84 @@ func (r *themeResource) Schema(ctx context.Context, req resource.SchemaRequest,
 						Description: "The parameters required for an image background in the login page",
 						Attributes: map[string]schema.Attribute{
 							"default_background_color": schema.StringAttribute{
-								Optional: true,
-								Computed: true,
-								Default:  stringdefault.StaticString("#f7f7f7"),
-								Validators: []validator.String{
-									stringvalidator.RegexMatches(hexcode_regex, "default_background_color must be a valid hex color code with lowercase characters"),
-								},
+								Optional:    true,
+								Computed:    true,
+								Default:     stringdefault.StaticString("#f7f7f7"),
+								CustomType:  colorType{},
 								Description: "The default color behind the background image in the login page. The default value is `#f7f7f7`",
 							},
 							"background_text_color": schema.StringAttribute{
+								Optional:    true,
+								Computed:    true,
+								Default:     stringdefault.StaticString("#363636"),
+								CustomType:  colorType{},
+								Description: "The color of the text on an image background in the login page. The default value is `#363636`",
+							},
+							"background_image_id": schema.StringAttribute{
 								Optional: true,
-								Computed: true,
-								Default:  stringdefault.StaticString("#363636"),
 								Validators: []validator.String{
-									stringvalidator.RegexMatches(hexcode_regex, "background_text_color must be a valid hex color code with lowercase characters"),
+									stringvalidator.LengthAtLeast(1),
 								},
-								Description: "The color of the text on an image background in the login page. The default value is `#363636`",
+								Description: "The `image_id` of a `propelauth_image` with `image_type` `background` to display as the background. " +
+									"Referencing the image here makes Terraform upload it before applying the theme. " +
+									"Once the image is uploaded, planning fails if PropelAuth has no background image at all. " +
+									"PropelAuth doesn't return which image it has, so a reference to another image isn't detected. " +
+									"If not set, the most recently uploaded background image is used",
 							},
 						},
 					},
 					"frame_background_color": schema.StringAttribute{
-						Optional: true,
-						Computed: true,
-						Default:  stringdefault.StaticString("#ffffff"),
-						Validators: []validator.String{
-							stringvalidator.RegexMatches(hexcode_regex, "frame_background_color must be a valid hex color code with lowercase characters"),
-						},
+						Optional:   true,
+						Computed:   true,
+						Default:    stringdefault.StaticString("#ffffff"),
+						CustomType: colorType{},
 						Description: "The background color within the frame in the login page. If the the `layout` is `Frameless`, " +
 							"this color is applied to the background of the input components on the page. The default value is `#ffffff`",
 					},
 					"frame_text_color": schema.StringAttribute{
-						Optional: true,
-						Computed: true,
-						Default:  stringdefault.StaticString("#0f0f0f"),
-						Validators: []validator.String{
-							stringvalidator.RegexMatches(hexcode_regex, "frame_text_color must be a valid hex color code with lowercase characters"),
-						},
+						Optional:   true,
+						Computed:   true,
+						Default:    stringdefault.StaticString("#0f0f0f"),
+						CustomType: colorType{},
 						Description: "The color of the text within the frame in the login page.  If the the `layout` is `Frameless`, " +
 							"this color is applied to text within input components on the page. The default value is `#0f0f0f`",
 					},
 					"primary_color": schema.StringAttribute{
-						Optional: true,
-						Computed: true,
-						Default:  stringdefault.StaticString("#50c878"),
-						Validators: []validator.String{
-							stringvalidator.RegexMatches(hexcode_regex, "primary_color must be a valid hex color code with lowercase characters"),
-						},
+						Optional:   true,
+						Computed:   true,
+						Default:    stringdefault.StaticString("#50c878"),
+						CustomType: colorType{},
 						Description: "The primary color of action buttons and links in the login page. " +
 							"The default value is `#50c878`",
 					},
 					"primary_text_color": schema.StringAttribute{
-						Optional: true,
-						Computed: true,
-						Default:  stringdefault.StaticString("#f7f7f7"),
-						Validators: []validator.String{
-							stringvalidator.RegexMatches(hexcode_regex, "primary_text_color must be a valid hex color code with lowercase characters"),
-						},
+						Optional:    true,
+						Computed:    true,
+						Default:     stringdefault.StaticString("#f7f7f7"),
+						CustomType:  colorType{},
 						Description: "The color of the text on action buttons in the login page. The default value is `#f7f7f7`",
 					},
 					"error_color": schema.StringAttribute{
-						Optional: true,
-						Computed: true,
-						Default:  stringdefault.StaticString("#cf222e"),
-						Validators: []validator.String{
-							stringvalidator.RegexMatches(hexcode_regex, "error_color must be a valid hex color code with lowercase characters"),
-						},
+						Optional:   true,
+						Computed:   true,
+						Default:    stringdefault.StaticString("#cf222e"),
+						CustomType: colorType{},
 						Description: "The color for error messages and cancel button in the login page. " +
 							"The default value is `#cf222e`",
 					},
 					"error_button_text_color": schema.StringAttribute{
-						Optional: true,
-						Computed: true,
-						Default:  stringdefault.StaticString("#ffffff"),
-						Validators: []validator.String{
-							stringvalidator.RegexMatches(hexcode_regex, "error_button_text_color must be a valid hex color code with lowercase characters"),
-						},
+						Optional:   true,
+						Computed:   true,
+						Default:    stringdefault.StaticString("#ffffff"),
+						CustomType: colorType{},
 						Description: "The color of the text on error messages and cancel button in the login page. " +
 							"The default value is `#ffffff`",
 					},
 					"border_color": schema.StringAttribute{
-						Optional: true,
-						Computed: true,
-						Default:  stringdefault.StaticString("#e4e4e4"),
-						Validators: []validator.String{
-							stringvalidator.RegexMatches(hexcode_regex, "border_color must be a valid hex color code with lowercase characters"),
-						},
+						Optional:    true,
+						Computed:    true,
+						Default:     stringdefault.StaticString("#e4e4e4"),
+						CustomType:  colorType{},
 						Description: "The color of the borders in the login page. The default value is `#e4e4e4`",
 					},
 					"split_login_page_parameters": schema.SingleNestedAttribute{
@@ -335,10 +329,21 @@ func (r *themeResource) Schema(ctx context.Context, req resource.SchemaRequest,
 								Computed: true,
 								Default:  stringdefault.StaticString("None"),
 								Validators: []validator.String{
-									stringvalidator.OneOf("None", "Text"),
+									stringvalidator.OneOf("None", "Text", "Image"),
 								},
 								Description: "The type of content displayed on the side of the screen opposite the login components. " +
-									"Currently, options include `None` and `Text`. The default value is `None`",
+									"Options include `None`, `Text` and `Image`. `Image` displays the image referenced by `background_image_id`. " +
+									"The default value is `None`",
+							},
+							"background_image_id": schema.StringAttribute{
+								Optional: true,
+								Validators: []validator.String{
+									stringvalidator.LengthAtLeast(1),
+								},
+								Description: "The `image_id` of a `propelauth_image` with `image_type` `background` to display on the side of the screen " +
+									"opposite the login components. This is required if `content_type` is `Image`, and can't be set otherwise. " +
+									"PropelAuth has one background image per theme, so if `image_background_parameters` also sets one, " +
+									"it must be the same image. The same plan-time check as for `image_background_parameters` applies",
 							},
 							"header": schema.StringAttribute{
 								Optional: true,
@@ -355,12 +360,10 @@ func (r *themeResource) Schema(ctx context.Context, req resource.SchemaRequest,
 									"This is only displayed if `content_type` is `Text`",
 							},
 							"secondary_background_text_color": schema.StringAttribute{
-								Optional: true,
-								Computed: true,
-								Default:  stringdefault.StaticString("#363636"),
-								Validators: []validator.String{
-									stringvalidator.RegexMatches(hexcode_regex, "secondary_background_text_color must be a valid hex color code with lowercase characters"),
-								},
+								Optional:   true,
+								Computed:   true,
+								Default:    stringdefault.StaticString("#363636"),
+								CustomType: colorType{},
 								Description: "The color of the subheader on the side of the screen opposite the login components. " +
 									"The header text in the same area uses the `background_text_color`. " +
 									"The default value is `#363636`",
@@ -374,66 +377,52 @@ func (r *themeResource) Schema(ctx context.Context, req resource.SchemaRequest,
 				Required:    true,
 				Attributes: map[string]schema.Attribute{
 					"main_background_color": schema.StringAttribute{
-						Optional: true,
-						Computed: true,
-						Default:  stringdefault.StaticString("#f7f7f7"),
-						Validators: []validator.String{
-							stringvalidator.RegexMatches(hexcode_regex, "main_background_color must be a valid hex color code with lowercase characters"),
-						},
+						Optional:    true,
+						Computed:    true,
+						Default:     stringdefault.StaticString("#f7f7f7"),
+						CustomType:  colorType{},
 						Description: "The background color of the main content area in the management pages. The default value is `#f7f7f7`",
 					},
 					"main_text_color": schema.StringAttribute{
-						Optional: true,
-						Computed: true,
-						Default:  stringdefault.StaticString("#363636"),
-						Validators: []validator.String{
-							stringvalidator.RegexMatches(hexcode_regex, "main_text_color must be a valid hex color code with lowercase characters"),
-						},
+						Optional:    true,
+						Computed:    true,
+						Default:     stringdefault.StaticString("#363636"),
+						CustomType:  colorType{},
 						Description: "The color of the text in the main content area of the management pages. The default value is `#363636`",
 					},
 					"navbar_background_color": schema.StringAttribute{
-						Optional: true,
-						Computed: true,
-						Default:  stringdefault.StaticString("#ffffff"),
-						Validators: []validator.String{
-							stringvalidator.RegexMatches(hexcode_regex, "navbar_background_color must be a valid hex color code with lowercase characters"),
-						},
+						Optional:    true,
+						Computed:    true,
+						Default:     stringdefault.StaticString("#ffffff"),
+						CustomType:  colorType{},
 						Description: "The background color of the navigation bar in the management pages. The default value is `#ffffff`",
 					},
 					"navbar_text_color": schema.StringAttribute{
-						Optional: true,
-						Computed: true,
-						Default:  stringdefault.StaticString("#0f0f0f"),
-						Validators: []validator.String{
-							stringvalidator.RegexMatches(hexcode_regex, "navbar_text_color must be a valid hex color code with lowercase characters"),
-						},
+						Optional:    true,
+						Computed:    true,
+						Default:     stringdefault.StaticString("#0f0f0f"),
+						CustomType:  colorType{},
 						Description: "The color of the text in the navigation bar in the management pages. The default value is `#0f0f0f`",
 					},
 					"action_button_color": schema.StringAttribute{
-						Optional: true,
-						Computed: true,
-						Default:  stringdefault.StaticString("#50c878"),
-						Validators: []validator.String{
-							stringvalidator.RegexMatches(hexcode_regex, "action_button_color must be a valid hex color code with lowercase characters"),
-						},
+						Optional:    true,
+						Computed:    true,
+						Default:     stringdefault.StaticString("#50c878"),
+						CustomType:  colorType{},
 						Description: "The color of action buttons in the management pages. The default value is `#50c878`",
 					},
 					"action_button_text_color": schema.StringAttribute{
-						Optional: true,
-						Computed: true,
-						Default:  stringdefault.StaticString("#f7f7f7"),
-						Validators: []validator.String{
-							stringvalidator.RegexMatches(hexcode_regex, "action_button_text_color must be a valid hex color code with lowercase characters"),
-						},
+						Optional:    true,
+						Computed:    true,
+						Default:     stringdefault.StaticString("#f7f7f7"),
+						CustomType:  colorType{},
 						Description: "The color of the text on action buttons in the management pages. The default value is `#f7f7f7`",
 					},
 					"border_color": schema.StringAttribute{
-						Optional: true,
-						Computed: true,
-						Default:  stringdefault.StaticString("#e4e4e4"),
-						Validators: []validator.String{
-							stringvalidator.RegexMatches(hexcode_regex, "border_color must be a valid hex color code with lowercase characters"),
-						},
+						Optional:   true,
+						Computed:   true,
+						Default:    stringdefault.StaticString("#e4e4e4"),
+						CustomType: colorType{},
 						Description: "The color of the border between the navbar and the main content area in the management pages. " +
 							"The default value is `#e4e4e4`",
 					},
@@ -549,6 +538,11 @@ func (r *themeResource) ValidateConfig(ctx context.Context, req resource.Validat
 		return
 	}
 
+	validateSplitContentImage(&plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	if plan.LoginPageTheme.SolidBackgroundParameters == nil && plan.LoginPageTheme.GradientBackgroundParameters == nil && plan.LoginPageTheme.ImageBackgroundParameters == nil {
 		resp.Diagnostics.AddAttributeError(
 			path.Root("login_page_theme"),
@@ -557,6 +551,32 @@ func (r *themeResource) ValidateConfig(ctx context.Context, req resource.Validat
 		)
 		return
 	}
+
+	validateThemeContrast(&plan, &resp.Diagnostics)
+}
+
+func (r *themeResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// nothing to check when destroying or before the provider is configured
+	if req.Plan.Raw.IsNull() || r.client == nil {
+		return
+	}
+
+	var plan themeResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() || !hasCheckableBackgroundImage(&plan) {
+		return
+	}
+
+	environmentConfig, err := r.client.GetEnvironmentConfig()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading propelauth theme",
+			"Could not read the theme to check its background image: "+err.Error(),
+		)
+		return
+	}
+
+	checkBackgroundImage(&plan, environmentConfig.BackgroundUrl, &resp.Diagnostics)
 }
 
 func (r *themeResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -571,7 +591,8 @@ func (r *themeResource) Create(ctx context.Context, req resource.CreateRequest,
 
 	// Update the configuration in PropelAuth
 	environmentConfigUpdate := propelauth.EnvironmentConfigUpdate{
-		Theme: convertPlanToTheme(&plan),
+		Theme:             convertPlanToTheme(&plan),
+		BackgroundImageId: backgroundImageIdFromPlan(&plan),
 	}
 	environmentConfig, err := r.client.UpdateEnvironmentConfig(&environmentConfigUpdate)
 	if err != nil {
@@ -630,7 +651,8 @@ func (r *themeResource) Update(ctx context.Context, req resource.UpdateRequest,
 
 	// Update the configuration in PropelAuth
 	environmentConfigUpdate := propelauth.EnvironmentConfigUpdate{
-		Theme: convertPlanToTheme(&plan),
+		Theme:             convertPlanToTheme(&plan),
+		BackgroundImageId: backgroundImageIdFromPlan(&plan),
 	}
 	environmentConfig, err := r.client.UpdateEnvironmentConfig(&environmentConfigUpdate)
 	if err != nil {
@@ -668,6 +690,7 @@ func (r *themeResource) ImportState(ctx context.Context, req resource.ImportStat
 	}
 
 	var state themeResourceModel
+	state.StrictAccessibility = types.BoolValue(false)
 	updateStateFromTheme(environmentConfig.Theme, &state)
 
 	// Save updated state into Terraform state
@@ -682,41 +705,41 @@ func convertPlanToTheme(plan *themeResourceModel) *propelauth.Theme {
 		DisplayProjectName:      plan.DisplayProjectName.ValueBool(),
 		LoginLayout:             plan.LoginPageTheme.Layout.ValueString(),
 		BackgroundType:          plan.LoginPageTheme.BackgroundType.ValueString(),
-		FrameBackgroundColor:    convertHexColorToRgb(plan.LoginPageTheme.FrameBackgroundColor.ValueString()),
-		FrameTextColor:          convertHexColorToRgb(plan.LoginPageTheme.FrameTextColor.ValueString()),
-		FrameSecondaryTextColor: convertHexColorToRgb(plan.LoginPageTheme.FrameTextColor.ValueString()),
-		PrimaryColor:            convertHexColorToRgb(plan.LoginPageTheme.PrimaryColor.ValueString()),
-		PrimaryTextColor:        convertHexColorToRgb(plan.LoginPageTheme.PrimaryTextColor.ValueString()),
-		ErrorButtonColor:        convertHexColorToRgb(plan.LoginPageTheme.ErrorColor.ValueString()),
-		ErrorButtonTextColor:    convertHexColorToRgb(plan.LoginPageTheme.ErrorButtonTextColor.ValueString()),
-		BorderColor:             convertHexColorToRgb(plan.LoginPageTheme.BorderColor.ValueString()),
+		FrameBackgroundColor:    plan.LoginPageTheme.FrameBackgroundColor.RgbColor(),
+		FrameTextColor:          plan.LoginPageTheme.FrameTextColor.RgbColor(),
+		FrameSecondaryTextColor: plan.LoginPageTheme.FrameTextColor.RgbColor(),
+		PrimaryColor:            plan.LoginPageTheme.PrimaryColor.RgbColor(),
+		PrimaryTextColor:        plan.LoginPageTheme.PrimaryTextColor.RgbColor(),
+		ErrorButtonColor:        plan.LoginPageTheme.ErrorColor.RgbColor(),
+		ErrorButtonTextColor:    plan.LoginPageTheme.ErrorButtonTextColor.RgbColor(),
+		BorderColor:             plan.LoginPageTheme.BorderColor.RgbColor(),
 		ManagementPagesTheme: propelauth.ManagementPagesTheme{
-			MainBackgroundColor:   convertHexColorToRgb(plan.ManagementPagesTheme.MainBackgroundColor.ValueString()),
-			MainTextColor:         convertHexColorToRgb(plan.ManagementPagesTheme.MainTextColor.ValueString()),
-			NavbarBackgroundColor: convertHexColorToRgb(plan.ManagementPagesTheme.NavbarBackgroundColor.ValueString()),
-			NavbarTextColor:       convertHexColorToRgb(plan.ManagementPagesTheme.NavbarTextColor.ValueString()),
-			ActionButtonColor:     convertHexColorToRgb(plan.ManagementPagesTheme.ActionButtonColor.ValueString()),
-			ActionButtonTextColor: convertHexColorToRgb(plan.ManagementPagesTheme.ActionButtonTextColor.ValueString()),
-			BorderColor:           convertHexColorToRgb(plan.ManagementPagesTheme.BorderColor.ValueString()),
+			MainBackgroundColor:   plan.ManagementPagesTheme.MainBackgroundColor.RgbColor(),
+			MainTextColor:         plan.ManagementPagesTheme.MainTextColor.RgbColor(),
+			NavbarBackgroundColor: plan.ManagementPagesTheme.NavbarBackgroundColor.RgbColor(),
+			NavbarTextColor:       plan.ManagementPagesTheme.NavbarTextColor.RgbColor(),
+			ActionButtonColor:     plan.ManagementPagesTheme.ActionButtonColor.RgbColor(),
+			ActionButtonTextColor: plan.ManagementPagesTheme.ActionButtonTextColor.RgbColor(),
+			BorderColor:           plan.ManagementPagesTheme.BorderColor.RgbColor(),
 			DisplayNavbar:         plan.ManagementPagesTheme.DisplayNavbar.ValueBool(),
 		},
 	}
 
 	if plan.LoginPageTheme.BackgroundType.ValueString() == "Solid" {
-		theme.BackgroundColor = convertHexColorToRgb(plan.LoginPageTheme.SolidBackgroundParameters.BackgroundColor.ValueString())
-		theme.BackgroundTextColor = convertHexColorToRgb(plan.LoginPageTheme.SolidBackgroundParameters.BackgroundTextColor.ValueString())
+		theme.BackgroundColor = plan.LoginPageTheme.SolidBackgroundParameters.BackgroundColor.RgbColor()
+		theme.BackgroundTextColor = plan.LoginPageTheme.SolidBackgroundParameters.BackgroundTextColor.RgbColor()
 	}
 
 	if plan.LoginPageTheme.BackgroundType.ValueString() == "Gradient" {
-		theme.BackgroundColor = convertHexColorToRgb(plan.LoginPageTheme.GradientBackgroundParameters.BackgroundGradientStartColor.ValueString())
-		theme.SecondaryBackgroundColor = convertHexColorToRgb(plan.LoginPageTheme.GradientBackgroundParameters.BackgroundGradientEndColor.ValueString())
-		theme.BackgroundTextColor = convertHexColorToRgb(plan.LoginPageTheme.GradientBackgroundParameters.BackgroundTextColor.ValueString())
+		theme.BackgroundColor = plan.LoginPageTheme.GradientBackgroundParameters.BackgroundGradientStartColor.RgbColor()
+		theme.SecondaryBackgroundColor = plan.LoginPageTheme.GradientBackgroundParameters.BackgroundGradientEndColor.RgbColor()
+		theme.BackgroundTextColor = plan.LoginPageTheme.GradientBackgroundParameters.BackgroundTextColor.RgbColor()
 		theme.GradientAngle = plan.LoginPageTheme.GradientBackgroundParameters.BackgroundGradientAngle.ValueInt32()
 	}
 
 	if plan.LoginPageTheme.BackgroundType.ValueString() == "Image" {
-		theme.BackgroundColor = convertHexColorToRgb(plan.LoginPageTheme.ImageBackgroundParameters.DefaultBackgroundColor.ValueString())
-		theme.BackgroundTextColor = convertHexColorToRgb(plan.LoginPageTheme.ImageBackgroundParameters.BackgroundTextColor.ValueString())
+		theme.BackgroundColor = plan.LoginPageTheme.ImageBackgroundParameters.DefaultBackgroundColor.RgbColor()
+		theme.BackgroundTextColor = plan.LoginPageTheme.ImageBackgroundParameters.BackgroundTextColor.RgbColor()
 	}
 
 	if plan.LoginPageTheme.Layout.ValueString() == "SplitScreen" {
@@ -726,59 +749,69 @@ func convertPlanToTheme(plan *themeResourceModel) *propelauth.Theme {
 		splitscreenParams.Header = plan.LoginPageTheme.SplitLoginPageParameters.Header.ValueString()
 		splitscreenParams.Subheader = plan.LoginPageTheme.SplitLoginPageParameters.Subheader.ValueString()
 		theme.Splitscreen = &splitscreenParams
-		theme.SecondaryBackgroundTextColor = convertHexColorToRgb(plan.LoginPageTheme.SplitLoginPageParameters.SecondaryBackgroundTextColor.ValueString())
+		theme.SecondaryBackgroundTextColor = plan.LoginPageTheme.SplitLoginPageParameters.SecondaryBackgroundTextColor.RgbColor()
 	}
 
 	return &theme
 }
 
 func updateStateFromTheme(theme propelauth.Theme, state *themeResourceModel) {
+	// the background image is attached to the environment rather than the theme, so keep the configured references
+	backgroundImageId := types.StringNull()
+	if state.LoginPageTheme.ImageBackgroundParameters != nil {
+		backgroundImageId = state.LoginPageTheme.ImageBackgroundParameters.BackgroundImageId
+	}
+	splitBackgroundImageId := types.StringNull()
+	if state.LoginPageTheme.SplitLoginPageParameters != nil {
+		splitBackgroundImageId = state.LoginPageTheme.SplitLoginPageParameters.BackgroundImageId
+	}
+
 	state.HeaderFont = types.StringValue(theme.HeaderFont)
 	state.BodyFont = types.StringValue(theme.BodyFont)
 	state.DisplayProjectName = types.BoolValue(theme.DisplayProjectName)
 	state.LoginPageTheme = loginPageTheme{
 		Layout:               types.StringValue(theme.LoginLayout),
 		BackgroundType:       types.StringValue(theme.BackgroundType),
-		FrameBackgroundColor: types.StringValue(convertRgbToHexColor(theme.FrameBackgroundColor)),
-		FrameTextColor:       types.StringValue(convertRgbToHexColor(theme.FrameTextColor)),
-		PrimaryColor:         types.StringValue(convertRgbToHexColor(theme.PrimaryColor)),
-		PrimaryTextColor:     types.StringValue(convertRgbToHexColor(theme.PrimaryTextColor)),
-		ErrorColor:           types.StringValue(convertRgbToHexColor(theme.ErrorButtonColor)),
-		ErrorButtonTextColor: types.StringValue(convertRgbToHexColor(theme.ErrorButtonTextColor)),
-		BorderColor:          types.StringValue(convertRgbToHexColor(theme.BorderColor)),
+		FrameBackgroundColor: newColorValue(theme.FrameBackgroundColor),
+		FrameTextColor:       newColorValue(theme.FrameTextColor),
+		PrimaryColor:         newColorValue(theme.PrimaryColor),
+		PrimaryTextColor:     newColorValue(theme.PrimaryTextColor),
+		ErrorColor:           newColorValue(theme.ErrorButtonColor),
+		ErrorButtonTextColor: newColorValue(theme.ErrorButtonTextColor),
+		BorderColor:          newColorValue(theme.BorderColor),
 	}
 	state.ManagementPagesTheme = managementPagesTheme{
-		MainBackgroundColor:   types.StringValue(convertRgbToHexColor(theme.ManagementPagesTheme.MainBackgroundColor)),
-		MainTextColor:         types.StringValue(convertRgbToHexColor(theme.ManagementPagesTheme.MainTextColor)),
-		NavbarBackgroundColor: types.StringValue(convertRgbToHexColor(theme.ManagementPagesTheme.NavbarBackgroundColor)),
-		NavbarTextColor:       types.StringValue(convertRgbToHexColor(theme.ManagementPagesTheme.NavbarTextColor)),
-		ActionButtonColor:     types.StringValue(convertRgbToHexColor(theme.ManagementPagesTheme.ActionButtonColor)),
-		ActionButtonTextColor: types.StringValue(convertRgbToHexColor(theme.ManagementPagesTheme.ActionButtonTextColor)),
-		BorderColor:           types.StringValue(convertRgbToHexColor(theme.ManagementPagesTheme.BorderColor)),
+		MainBackgroundColor:   newColorValue(theme.ManagementPagesTheme.MainBackgroundColor),
+		MainTextColor:         newColorValue(theme.ManagementPagesTheme.MainTextColor),
+		NavbarBackgroundColor: newColorValue(theme.ManagementPagesTheme.NavbarBackgroundColor),
+		NavbarTextColor:       newColorValue(theme.ManagementPagesTheme.NavbarTextColor),
+		ActionButtonColor:     newColorValue(theme.ManagementPagesTheme.ActionButtonColor),
+		ActionButtonTextColor: newColorValue(theme.ManagementPagesTheme.ActionButtonTextColor),
+		BorderColor:           newColorValue(theme.ManagementPagesTheme.BorderColor),
 		DisplayNavbar:         types.BoolValue(theme.ManagementPagesTheme.DisplayNavbar),
 	}
 
 	if theme.BackgroundType == "Solid" {
 		state.LoginPageTheme.SolidBackgroundParameters = &solidBackgroundParameters{
-			BackgroundColor:     types.StringValue(convertRgbToHexColor(theme.BackgroundColor)),
-			BackgroundTextColor: types.StringValue(convertRgbToHexColor(theme.BackgroundTextColor)),
+			BackgroundColor:     newColorValue(theme.BackgroundColor),
+			BackgroundTextColor: newColorValue(theme.BackgroundTextColor),
 		}
 	}
 
 	if theme.BackgroundType == "Gradient" {
 		state.LoginPageTheme.GradientBackgroundParameters = &gradientBackgroundParameters{
-			BackgroundGradientStartColor: types.StringValue(convertRgbToHexColor(theme.BackgroundColor)),
-			BackgroundGradientEndColor:   types.StringValue(convertRgbToHexColor(theme.SecondaryBackgroundColor)),
+			BackgroundGradientStartColor: newColorValue(theme.BackgroundColor),
+			BackgroundGradientEndColor:   newColorValue(theme.SecondaryBackgroundColor),
 			BackgroundGradientAngle:      types.Int32Value(theme.GradientAngle),
-			BackgroundTextColor:          types.StringValue(convertRgbToHexColor(theme.BackgroundTextColor)),
+			BackgroundTextColor:          newColorValue(theme.BackgroundTextColor),
 		}
 	}
 
 	if theme.BackgroundType == "Image" {
 		state.LoginPageTheme.ImageBackgroundParameters = &imageBackgroundParameters{
-			// TODO: once we support image content, impl populating BackgrondImage
-			DefaultBackgroundColor: types.StringValue(convertRgbToHexColor(theme.BackgroundColor)),
-			BackgroundTextColor:    types.StringValue(convertRgbToHexColor(theme.BackgroundTextColor)),
+			DefaultBackgroundColor: newColorValue(theme.BackgroundColor),
+			BackgroundTextColor:    newColorValue(theme.BackgroundTextColor),
+			BackgroundImageId:      backgroundImageId,
 		}
 	}
 
@@ -788,20 +821,197 @@ func updateStateFromTheme(theme propelauth.Theme, state *themeResourceModel) {
 			ContentType:                  types.StringValue(theme.Splitscreen.ContentType),
 			Header:                       types.StringValue(theme.Splitscreen.Header),
 			Subheader:                    types.StringValue(theme.Splitscreen.Subheader),
-			SecondaryBackgroundTextColor: types.StringValue(convertRgbToHexColor(theme.SecondaryBackgroundTextColor)),
+			SecondaryBackgroundTextColor: newColorValue(theme.SecondaryBackgroundTextColor),
+			BackgroundImageId:            splitBackgroundImageId,
 		}
 	}
 }
 
-func convertHexColorToRgb(hexColor string) propelauth.RgbColor {
-	red, green, blue, err := hexcolor.Parse(hexColor)
-	if err != nil {
-		return propelauth.RgbColor{} // shouldn't happen since we validate all hex color inputs
-	} else {
-		return propelauth.RgbColor{
-			Red:   red,
-			Green: green,
-			Blue:  blue,
+func backgroundImageIdFromPlan(plan *themeResourceModel) string {
+	_, backgroundImageId, ok := backgroundImageReference(plan)
+	if !ok {
+		return ""
+	}
+
+	return backgroundImageId.ValueString()
+}
+
+// backgroundImageReference returns the attribute referencing the background image the theme displays, either as
+// `Image` split-screen content or as an `Image` background, and false if the theme displays no image.
+func backgroundImageReference(plan *themeResourceModel) (path.Path, types.String, bool) {
+	loginPage := plan.LoginPageTheme
+	if loginPage.Layout.ValueString() == "SplitScreen" && loginPage.SplitLoginPageParameters != nil &&
+		loginPage.SplitLoginPageParameters.ContentType.ValueString() == "Image" {
+		return path.Root("login_page_theme").AtName("split_login_page_parameters").AtName("background_image_id"),
+			loginPage.SplitLoginPageParameters.BackgroundImageId, true
+	}
+	if loginPage.BackgroundType.ValueString() == "Image" && loginPage.ImageBackgroundParameters != nil {
+		return path.Root("login_page_theme").AtName("image_background_parameters").AtName("background_image_id"),
+			loginPage.ImageBackgroundParameters.BackgroundImageId, true
+	}
+
+	return path.Empty(), types.StringNull(), false
+}
+
+// validateSplitContentImage checks that split-screen content has a `background_image_id` exactly when it's an
+// `Image`, and that it doesn't reference another image than the `Image` background, as both are the one
+// background image of the theme.
+func validateSplitContentImage(plan *themeResourceModel, diags *diag.Diagnostics) {
+	splitParameters := plan.LoginPageTheme.SplitLoginPageParameters
+	if plan.LoginPageTheme.Layout.ValueString() != "SplitScreen" || splitParameters == nil || splitParameters.ContentType.IsUnknown() {
+		return
+	}
+
+	imageIdPath := path.Root("login_page_theme").AtName("split_login_page_parameters").AtName("background_image_id")
+	isImageContent := splitParameters.ContentType.ValueString() == "Image"
+	if isImageContent && splitParameters.BackgroundImageId.IsNull() {
+		diags.AddAttributeError(
+			imageIdPath,
+			"Missing `background_image_id`",
+			"`Image` `content_type` requires `background_image_id` to be set to the `image_id` of a `propelauth_image`",
+		)
+		return
+	}
+	if !isImageContent && !splitParameters.BackgroundImageId.IsNull() {
+		diags.AddAttributeError(
+			imageIdPath,
+			"Invalid `background_image_id`",
+			"`background_image_id` is only displayed if `content_type` is `Image`",
+		)
+		return
+	}
+
+	imageBackground := plan.LoginPageTheme.ImageBackgroundParameters
+	if isImageContent && plan.LoginPageTheme.BackgroundType.ValueString() == "Image" && imageBackground != nil &&
+		!imageBackground.BackgroundImageId.IsNull() && !imageBackground.BackgroundImageId.IsUnknown() &&
+		!splitParameters.BackgroundImageId.IsUnknown() && !imageBackground.BackgroundImageId.Equal(splitParameters.BackgroundImageId) {
+		diags.AddAttributeError(
+			imageIdPath,
+			"Conflicting background images",
+			"PropelAuth has one background image per theme, so `background_image_id` must be the same image as the "+
+				"`background_image_id` of `image_background_parameters`.",
+		)
+	}
+}
+
+// hasCheckableBackgroundImage reports whether the theme displays a background image that can be checked, which is
+// once its `background_image_id` is known. An unknown one is for an image that is uploaded when applying.
+func hasCheckableBackgroundImage(plan *themeResourceModel) bool {
+	_, backgroundImageId, ok := backgroundImageReference(plan)
+	return ok && !backgroundImageId.IsUnknown()
+}
+
+// checkBackgroundImage reports a background image the theme displays but PropelAuth doesn't have, given the URL of
+// the background image PropelAuth has when planning. PropelAuth doesn't return the ID of that image, so only whether
+// there is one at all can be checked.
+func checkBackgroundImage(plan *themeResourceModel, backgroundUrl string, diags *diag.Diagnostics) {
+	imageIdPath, backgroundImageId, ok := backgroundImageReference(plan)
+	if !ok || backgroundUrl != "" {
+		return
+	}
+
+	if backgroundImageId.ValueString() != "" {
+		diags.AddAttributeError(
+			imageIdPath,
+			"Missing background image",
+			"PropelAuth has no background image for this theme at all. "+
+				"Make sure `background_image_id` is the `image_id` of a `propelauth_image` with the background `image_type` for this theme.",
+		)
+		return
+	}
+
+	diags.AddAttributeWarning(
+		path.Root("login_page_theme").AtName("background_type"),
+		"Missing background image",
+		"The `Image` `background_type` is set but no background image has been uploaded, so only the `default_background_color` is shown. "+
+			"Set `background_image_id` to the `image_id` of a `propelauth_image` so the image is uploaded first.",
+	)
+}
+
+// minimumContrastRatio is the WCAG 2.x AA contrast ratio required for normal text.
+const minimumContrastRatio = 4.5
+
+// themeContrastPair is a text color and the background it is displayed on.
+// The defaults mirror the schema defaults, since unset attributes are still null during config validation.
+// They're only used when the other color of the pair is set.
+type themeContrastPair struct {
+	textPath          path.Path
+	textColor         colorValue
+	defaultText       string
+	backgroundPath    path.Path
+	backgroundColor   colorValue
+	defaultBackground string
+}
+
+func validateThemeContrast(plan *themeResourceModel, diags *diag.Diagnostics) {
+	loginPage := path.Root("login_page_theme")
+	managementPages := path.Root("management_pages_theme")
+	pairs := []themeContrastPair{
+		{
+			textPath: loginPage.AtName("frame_text_color"), textColor: plan.LoginPageTheme.FrameTextColor, defaultText: "#0f0f0f",
+			backgroundPath: loginPage.AtName("frame_background_color"), backgroundColor: plan.LoginPageTheme.FrameBackgroundColor, defaultBackground: "#ffffff",
+		},
+		{
+			textPath: loginPage.AtName("primary_text_color"), textColor: plan.LoginPageTheme.PrimaryTextColor, defaultText: "#f7f7f7",
+			backgroundPath: loginPage.AtName("primary_color"), backgroundColor: plan.LoginPageTheme.PrimaryColor, defaultBackground: "#50c878",
+		},
+		{
+			textPath: loginPage.AtName("error_button_text_color"), textColor: plan.LoginPageTheme.ErrorButtonTextColor, defaultText: "#ffffff",
+			backgroundPath: loginPage.AtName("error_color"), backgroundColor: plan.LoginPageTheme.ErrorColor, defaultBackground: "#cf222e",
+		},
+		{
+			textPath: managementPages.AtName("navbar_text_color"), textColor: plan.ManagementPagesTheme.NavbarTextColor, defaultText: "#0f0f0f",
+			backgroundPath: managementPages.AtName("navbar_background_color"), backgroundColor: plan.ManagementPagesTheme.NavbarBackgroundColor, defaultBackground: "#ffffff",
+		},
+		{
+			textPath: managementPages.AtName("main_text_color"), textColor: plan.ManagementPagesTheme.MainTextColor, defaultText: "#363636",
+			backgroundPath: managementPages.AtName("main_background_color"), backgroundColor: plan.ManagementPagesTheme.MainBackgroundColor, defaultBackground: "#f7f7f7",
+		},
+	}
+
+	strict := plan.StrictAccessibility.ValueBool()
+	for _, pair := range pairs {
+		// colors coming from other resources can't be checked until apply
+		if pair.textColor.IsUnknown() || pair.backgroundColor.IsUnknown() {
+			continue
+		}
+		// PropelAuth's own default pairs aren't the user's choice, and the primary button one is below the minimum
+		if pair.textColor.IsNull() && pair.backgroundColor.IsNull() {
+			continue
+		}
+
+		textColor := pair.defaultText
+		if !pair.textColor.IsNull() {
+			textColor = pair.textColor.ValueString()
+		}
+		backgroundColor := pair.defaultBackground
+		if !pair.backgroundColor.IsNull() {
+			backgroundColor = pair.backgroundColor.ValueString()
+		}
+
+		textRgb, err := propelauth.ParseColor(textColor)
+		if err != nil {
+			continue
+		}
+		backgroundRgb, err := propelauth.ParseColor(backgroundColor)
+		if err != nil {
+			continue
+		}
+
+		ratio := propelauth.ContrastRatio(textRgb, backgroundRgb)
+		if ratio >= minimumContrastRatio {
+			continue
+		}
+
+		summary := "Insufficient color contrast"
+		detail := fmt.Sprintf(
+			"`%s` (%s) on `%s` (%s) has a contrast ratio of %.2f:1, which is below the WCAG AA minimum of %.1f:1.",
+			pair.textPath, textColor, pair.backgroundPath, backgroundColor, ratio, minimumContrastRatio,
+		)
+		if strict {
+			diags.AddAttributeError(pair.textPath, summary, detail)
+		} else {
+			diags.AddAttributeWarning(pair.textPath, summary, detail+" Set `strict_accessibility` to `true` to treat this as an error.")
 		}
 	}
 }
@@ -1,21 +1,25 @@
 package provider
 
 import (
+	"context"
 	"fmt"
 	"testing"
 
-	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	testresource "github.com/hashicorp/terraform-plugin-testing/helper/resource"
 )
 
 func TestAccThemeResource(t *testing.T) {
-	resource.Test(t, resource.TestCase{
+	testresource.Test(t, testresource.TestCase{
 		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
-		Steps: []resource.TestStep{
+		Steps: []testresource.TestStep{
 			// Create and Read testing
 			{
 				Config: testAccThemeResourceConfig("#f70000"),
-				Check: resource.ComposeAggregateTestCheckFunc(
-					resource.TestCheckResourceAttr(
+				Check: testresource.ComposeAggregateTestCheckFunc(
+					testresource.TestCheckResourceAttr(
 						"propelauth_theme.test",
 						"login_page_theme.gradient_background_parameters.background_gradient_end_color",
 						"#f70000",
@@ -25,14 +29,25 @@ func TestAccThemeResource(t *testing.T) {
 			// Update and Read testing
 			{
 				Config: testAccThemeResourceConfig("#000000"),
-				Check: resource.ComposeAggregateTestCheckFunc(
-					resource.TestCheckResourceAttr(
+				Check: testresource.ComposeAggregateTestCheckFunc(
+					testresource.TestCheckResourceAttr(
 						"propelauth_theme.test",
 						"login_page_theme.gradient_background_parameters.background_gradient_end_color",
 						"#000000",
 					),
 				),
 			},
+			// The configured spelling of a color is kept when the API returns it as hex
+			{
+				Config: testAccThemeResourceConfig("black"),
+				Check: testresource.ComposeAggregateTestCheckFunc(
+					testresource.TestCheckResourceAttr(
+						"propelauth_theme.test",
+						"login_page_theme.gradient_background_parameters.background_gradient_end_color",
+						"black",
+					),
+				),
+			},
 			// Delete testing automatically occurs in TestCase
 		},
 	})
@@ -72,3 +87,50 @@ resource "propelauth_theme" "test" {
 }
 `, backgroundGradientEndColor)
 }
+
+func TestThemeResourceValidateConfigDefaults(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name          string
+		themeResource resource.ResourceWithValidateConfig
+	}{
+		{name: "Test light theme", themeResource: &themeResource{}},
+		{name: "Test dark theme", themeResource: &darkmodeThemeResource{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var schemaResp resource.SchemaResponse
+			tt.themeResource.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+			// only the required nested attributes and an empty solid background are set, and strict accessibility turns contrast warnings into errors
+			configType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+			config := nullObjectValues(configType)
+			config["strict_accessibility"] = tftypes.NewValue(tftypes.Bool, true)
+			managementPagesType := configType.AttributeTypes["management_pages_theme"].(tftypes.Object)
+			config["management_pages_theme"] = tftypes.NewValue(managementPagesType, nullObjectValues(managementPagesType))
+			loginPageType := configType.AttributeTypes["login_page_theme"].(tftypes.Object)
+			loginPage := nullObjectValues(loginPageType)
+			solidBackgroundType := loginPageType.AttributeTypes["solid_background_parameters"].(tftypes.Object)
+			loginPage["solid_background_parameters"] = tftypes.NewValue(solidBackgroundType, nullObjectValues(solidBackgroundType))
+			config["login_page_theme"] = tftypes.NewValue(loginPageType, loginPage)
+
+			var resp resource.ValidateConfigResponse
+			tt.themeResource.ValidateConfig(ctx, resource.ValidateConfigRequest{
+				Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: tftypes.NewValue(configType, config)},
+			}, &resp)
+			if len(resp.Diagnostics) != 0 {
+				t.Errorf("ValidateConfig() diagnostics = %v, want none", resp.Diagnostics)
+			}
+		})
+	}
+}
+
+func nullObjectValues(objectType tftypes.Object) map[string]tftypes.Value {
+	values := map[string]tftypes.Value{}
+	for name, attributeType := range objectType.AttributeTypes {
+		values[name] = tftypes.NewValue(attributeType, nil)
+	}
+
+	return values
+}
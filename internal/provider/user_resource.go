@@ -0,0 +1,408 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"terraform-provider-propelauth/internal/propelauth"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &userResource{}
+var _ resource.ResourceWithConfigure = &userResource{}
+var _ resource.ResourceWithImportState = &userResource{}
+var _ resource.ResourceWithValidateConfig = &userResource{}
+
+func NewUserResource() resource.Resource {
+	return &userResource{}
+}
+
+// userResource defines the resource implementation.
+type userResource struct {
+	client *propelauth.PropelAuthClient
+}
+
+// userResourceModel describes the resource data model.
+type userResourceModel struct {
+	Id              types.String `tfsdk:"id"`
+	Environment     types.String `tfsdk:"environment"`
+	AllowInProd     types.Bool   `tfsdk:"allow_in_prod"`
+	Email           types.String `tfsdk:"email"`
+	EmailConfirmed  types.Bool   `tfsdk:"email_confirmed"`
+	Username        types.String `tfsdk:"username"`
+	FirstName       types.String `tfsdk:"first_name"`
+	LastName        types.String `tfsdk:"last_name"`
+	Properties      types.Map    `tfsdk:"properties"`
+	Password        types.String `tfsdk:"password"`
+	PasswordVersion types.Int64  `tfsdk:"password_version"`
+}
+
+func (r *userResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_user"
+}
+
+func (r *userResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	emailRegex := regexp.MustCompile(`^[^\s@]+@[^\s@]+\.[^\s@]+$`)
+
+	resp.Schema = schema.Schema{
+		Description: "User resource. This is for seeding users with known credentials, such as the accounts of end-to-end tests. " +
+			"It is rejected in the `Prod` environment unless `allow_in_prod` is set, and needs a BE API key of the environment in `backend_api_keys`.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Description: "The ID of the user set by PropelAuth.",
+			},
+			"environment": schema.StringAttribute{
+				Required: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("Test", "Staging", "Prod"),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Description: "The environment the user is in. Accepted values are `Test`, `Staging`, and `Prod`.",
+			},
+			"allow_in_prod": schema.BoolAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+				Description: "Must be true for the user to be in the `Prod` environment. The default is false.",
+			},
+			"email": schema.StringAttribute{
+				Required: true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(emailRegex, "must be an email address"),
+				},
+				Description: "The email address of the user.",
+			},
+			"email_confirmed": schema.BoolAttribute{
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(true),
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
+				Description: "If true, the user's email address is considered confirmed, so they can log in without confirming it. " +
+					"Changing it recreates the user. The default is true.",
+			},
+			"username": schema.StringAttribute{
+				Optional:    true,
+				Description: "The username of the user, if usernames are enabled for the environment.",
+			},
+			"first_name": schema.StringAttribute{
+				Optional:    true,
+				Description: "The first name of the user.",
+			},
+			"last_name": schema.StringAttribute{
+				Optional:    true,
+				Description: "The last name of the user.",
+			},
+			"properties": schema.MapAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Description: "The user properties of the user, keyed by the name of the property in `propelauth_user_property_settings`.",
+			},
+			"password": schema.StringAttribute{
+				Optional:  true,
+				Sensitive: true,
+				WriteOnly: true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(8),
+				},
+				Description: "The password of the user. It is write-only, so it's neither stored in the Terraform state nor " +
+					"read back, and changes made outside of Terraform aren't detected. It is set when the user is created, and " +
+					"again whenever `password_version` changes. Write-only attributes require Terraform 1.11 or later.",
+			},
+			"password_version": schema.Int64Attribute{
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AlsoRequires(path.MatchRoot("password")),
+				},
+				Description: "A version of `password`, such as 1. Since `password` isn't stored in the state, changing it alone " +
+					"doesn't update the user, so change this along with it to set the new password.",
+			},
+		},
+	}
+}
+
+func (r *userResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*propelauth.PropelAuthClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *propelauth.PropelAuthClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *userResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config userResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	validateSeedDataEnvironment("propelauth_user", config.Environment, config.AllowInProd, &resp.Diagnostics)
+}
+
+func (r *userResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan userResourceModel
+
+	// Read Terraform plan data into the model
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	backendClient, err := r.client.BackendClient(plan.Environment.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Error creating user", err.Error())
+		return
+	}
+
+	properties, diags := jsonObjectFromStringMap(ctx, plan.Properties)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// the password is write-only, so it's only in the config
+	var password types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("password"), &password)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	userId, err := backendClient.CreateUser(propelauth.UserCreateRequest{
+		Email:                          plan.Email.ValueString(),
+		EmailConfirmed:                 plan.EmailConfirmed.ValueBool(),
+		SendEmailToConfirmEmailAddress: false,
+		Password:                       password.ValueStringPointer(),
+		Username:                       plan.Username.ValueStringPointer(),
+		FirstName:                      plan.FirstName.ValueStringPointer(),
+		LastName:                       plan.LastName.ValueStringPointer(),
+		Properties:                     properties,
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating user",
+			"Could not create user, unexpected error: "+err.Error(),
+		)
+		return
+	}
+	plan.Id = types.StringValue(userId)
+
+	// Write logs using the tflog package
+	// Documentation: https://terraform.io/plugin/log
+	tflog.Trace(ctx, "created a propelauth_user resource")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *userResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// Get current state and read it into the model
+	var state userResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	backendClient, err := r.client.BackendClient(state.Environment.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Error Reading PropelAuth User", err.Error())
+		return
+	}
+
+	user, err := backendClient.GetUser(state.Id.ValueString())
+	if err != nil {
+		// If error is "not_found", it indicates that the resource should be deleted.
+		if propelauth.IsPropelAuthNotFoundError(err) {
+			tflog.Trace(ctx, "deleting a propelauth_user resource because it was not found in PropelAuth")
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Error Reading PropelAuth User",
+			"Could not read PropelAuth User: "+err.Error(),
+		)
+		return
+	}
+
+	// the password is write-only and can't be read back, so it's never in the state
+	state.Email = types.StringValue(user.Email)
+	state.EmailConfirmed = types.BoolValue(user.EmailConfirmed)
+	state.Username = types.StringPointerValue(user.Username)
+	state.FirstName = types.StringPointerValue(user.FirstName)
+	state.LastName = types.StringPointerValue(user.LastName)
+	properties, diags := stringMapFromJsonObject(ctx, user.Properties, state.Properties)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state.Properties = properties
+
+	// Save updated state into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *userResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Read Terraform plan and state data into the models
+	var plan userResourceModel
+	var state userResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	backendClient, err := r.client.BackendClient(plan.Environment.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Error updating user", err.Error())
+		return
+	}
+
+	properties, diags := jsonObjectFromStringMap(ctx, plan.Properties)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err = backendClient.UpdateUser(plan.Id.ValueString(), propelauth.UserUpdateRequest{
+		Username:   plan.Username.ValueStringPointer(),
+		FirstName:  plan.FirstName.ValueStringPointer(),
+		LastName:   plan.LastName.ValueStringPointer(),
+		Properties: properties,
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating user",
+			"Could not update user, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	if !plan.Email.Equal(state.Email) {
+		err = backendClient.UpdateUserEmail(plan.Id.ValueString(), propelauth.UserEmailUpdateRequest{
+			NewEmail:                 plan.Email.ValueString(),
+			RequireEmailConfirmation: !plan.EmailConfirmed.ValueBool(),
+		})
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error updating user",
+				"Could not update the user's email address, unexpected error: "+err.Error(),
+			)
+			return
+		}
+	}
+
+	// the password is write-only, so it's only in the config, and is set again when its version changes
+	var password types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("password"), &password)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !password.IsNull() && !plan.PasswordVersion.Equal(state.PasswordVersion) {
+		err = backendClient.UpdateUserPassword(plan.Id.ValueString(), propelauth.UserPasswordUpdateRequest{
+			Password: password.ValueString(),
+		})
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error updating user",
+				"Could not update the user's password, unexpected error: "+err.Error(),
+			)
+			return
+		}
+	}
+
+	tflog.Trace(ctx, "updated a propelauth_user resource")
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *userResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state userResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	backendClient, err := r.client.BackendClient(state.Environment.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Error deleting user", err.Error())
+		return
+	}
+
+	// Delete existing user
+	err = backendClient.DeleteUser(state.Id.ValueString())
+	if err != nil && !propelauth.IsPropelAuthNotFoundError(err) {
+		resp.Diagnostics.AddError(
+			"Error deleting user",
+			"Could not delete user, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	tflog.Trace(ctx, "deleted a propelauth_user resource")
+}
+
+func (r *userResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	environment, userId, found := strings.Cut(req.ID, "/")
+	if !found || (environment != "Test" && environment != "Staging" && environment != "Prod") || userId == "" {
+		resp.Diagnostics.AddError(
+			"Invalid import ID",
+			fmt.Sprintf("Expected an import ID of the form `<environment>/<user_id>`, e.g. `Test/31c41c16-c281-44ae-9602-8a047e3bf33d`, got: %s", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("environment"), environment)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), userId)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("allow_in_prod"), environment == "Prod")...)
+}
+
+// validateSeedDataEnvironment rejects resources that seed an environment's data, such as test users, in the `Prod`
+// environment unless they explicitly allow it.
+func validateSeedDataEnvironment(resourceType string, environment types.String, allowInProd types.Bool, diags *diag.Diagnostics) {
+	if environment.ValueString() != "Prod" || allowInProd.IsUnknown() || allowInProd.ValueBool() {
+		return
+	}
+
+	diags.AddAttributeError(
+		path.Root("environment"),
+		"Seed data in Prod",
+		fmt.Sprintf("%s is meant for seeding the Test and Staging environments. Set `allow_in_prod = true` "+
+			"if it really should be in the Prod environment.", resourceType),
+	)
+}
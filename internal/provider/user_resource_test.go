@@ -0,0 +1,75 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccUserResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		// `password` is write-only
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_11_0),
+		},
+		Steps: []resource.TestStep{
+			// Seed data is rejected in Prod unless allowed
+			{
+				Config:      testAccUserResourceConfig("Prod", "Owner", 1),
+				ExpectError: regexp.MustCompile("Seed data in Prod"),
+			},
+			// Create and Read testing
+			{
+				Config: testAccUserResourceConfig("Test", "Owner", 1),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("propelauth_user.test", "email", "e2e-owner@example.com"),
+					resource.TestCheckResourceAttr("propelauth_user.test", "email_confirmed", "true"),
+					resource.TestCheckResourceAttr("propelauth_user.test", "first_name", "Owner"),
+					resource.TestCheckResourceAttrSet("propelauth_user.test", "id"),
+					resource.TestCheckNoResourceAttr("propelauth_user.test", "password"),
+					resource.TestCheckResourceAttr("propelauth_user.test", "password_version", "1"),
+					resource.TestCheckResourceAttr("propelauth_org_membership.test", "role", "Owner"),
+				),
+			},
+			// Update and Read testing
+			{
+				Config: testAccUserResourceConfig("Test", "Admin", 2),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("propelauth_user.test", "first_name", "Admin"),
+					resource.TestCheckNoResourceAttr("propelauth_user.test", "password"),
+					resource.TestCheckResourceAttr("propelauth_user.test", "password_version", "2"),
+					resource.TestCheckResourceAttr("propelauth_org_membership.test", "role", "Admin"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccUserResourceConfig(environment string, role string, passwordVersion int) string {
+	return providerConfig + fmt.Sprintf(`
+resource "propelauth_organization" "test" {
+  environment = %[1]q
+  name        = "E2E Org"
+}
+
+resource "propelauth_user" "test" {
+  environment      = %[1]q
+  email            = "e2e-owner@example.com"
+  first_name       = %[2]q
+  password         = "correct-horse-battery-staple-%[3]d"
+  password_version = %[3]d
+}
+
+resource "propelauth_org_membership" "test" {
+  environment = %[1]q
+  org_id      = propelauth_organization.test.id
+  user_id     = propelauth_user.test.id
+  role        = %[2]q
+}
+`, environment, role, passwordVersion)
+}
@@ -0,0 +1,348 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"terraform-provider-propelauth/internal/propelauth"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &webhookEndpointResource{}
+var _ resource.ResourceWithConfigure = &webhookEndpointResource{}
+var _ resource.ResourceWithImportState = &webhookEndpointResource{}
+var _ resource.ResourceWithModifyPlan = &webhookEndpointResource{}
+
+func NewWebhookEndpointResource() resource.Resource {
+	return &webhookEndpointResource{}
+}
+
+// webhookEndpointResource defines the resource implementation.
+type webhookEndpointResource struct {
+	client *propelauth.PropelAuthClient
+}
+
+// webhookEndpointResourceModel describes the resource data model.
+type webhookEndpointResourceModel struct {
+	Id            types.String `tfsdk:"id"`
+	Environment   types.String `tfsdk:"environment"`
+	Url           types.String `tfsdk:"url"`
+	EventTypes    types.Set    `tfsdk:"event_types"`
+	Enabled       types.Bool   `tfsdk:"enabled"`
+	Description   types.String `tfsdk:"description"`
+	SigningSecret types.String `tfsdk:"signing_secret"`
+}
+
+func (r *webhookEndpointResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_webhook_endpoint"
+}
+
+func (r *webhookEndpointResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Webhook Endpoint resource. This is for sending the user and organization events of an environment to one of your endpoints.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Description: "The ID of the webhook endpoint set by PropelAuth.",
+			},
+			"environment": schema.StringAttribute{
+				Required: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("Test", "Staging", "Prod"),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Description: "The environment whose events are sent. Accepted values are `Test`, `Staging`, and `Prod`.",
+			},
+			"url": schema.StringAttribute{
+				Required: true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(regexp.MustCompile(`^https://[^\s/]+(/\S*)?$`), "must be an https URL"),
+				},
+				Description: "The URL the events are sent to. It must be an https URL.",
+			},
+			"event_types": schema.SetAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+				},
+				Description: "The event types sent to the endpoint, e.g. `user.created`. They are checked against the " +
+					"`propelauth_webhook_event_types` data source when planning. If unset, every event is sent.",
+			},
+			"enabled": schema.BoolAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(true),
+				Description: "If false, no events are sent to the endpoint. The default is true.",
+			},
+			"description": schema.StringAttribute{
+				Optional:    true,
+				Description: "A description of the endpoint shown in the dashboard.",
+			},
+			"signing_secret": schema.StringAttribute{
+				Computed:  true,
+				Sensitive: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Description: "The secret the events sent to the endpoint are signed with, for verifying them.",
+			},
+		},
+	}
+}
+
+func (r *webhookEndpointResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*propelauth.PropelAuthClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *propelauth.PropelAuthClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *webhookEndpointResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// nothing to check when destroying or before the provider is configured
+	if req.Plan.Raw.IsNull() || r.client == nil {
+		return
+	}
+
+	var plan webhookEndpointResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() || plan.EventTypes.IsNull() || plan.EventTypes.IsUnknown() {
+		return
+	}
+
+	eventTypes := []types.String{}
+	resp.Diagnostics.Append(plan.EventTypes.ElementsAs(ctx, &eventTypes, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	availableEventTypes, err := r.client.GetWebhookEventTypes()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading webhook event types",
+			"Could not read the event types to check `event_types` against: "+err.Error(),
+		)
+		return
+	}
+	availableEventTypeNames := make([]string, len(availableEventTypes))
+	for i, eventType := range availableEventTypes {
+		availableEventTypeNames[i] = eventType.Name
+	}
+
+	for _, eventType := range eventTypes {
+		if eventType.IsUnknown() || propelauth.Contains(availableEventTypeNames, eventType.ValueString()) {
+			continue
+		}
+		detail := fmt.Sprintf("%s is not an event type. The event types are: %s.",
+			eventType.ValueString(), strings.Join(availableEventTypeNames, ", "))
+		if suggestion, ok := propelauth.ClosestMatch(availableEventTypeNames, eventType.ValueString(), 3); ok {
+			detail = fmt.Sprintf("%s is not an event type, did you mean %s?", eventType.ValueString(), suggestion)
+		}
+		resp.Diagnostics.AddAttributeError(path.Root("event_types"), "Unknown webhook event type", detail)
+	}
+}
+
+func (r *webhookEndpointResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan webhookEndpointResourceModel
+
+	// Read Terraform plan data into the model
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	request, diags := webhookEndpointRequestFromPlan(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	webhookEndpoint, err := r.client.CreateWebhookEndpoint(plan.Environment.ValueString(), request)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating webhook endpoint",
+			"Could not create webhook endpoint, unexpected error: "+err.Error(),
+		)
+		return
+	}
+	plan.Id = types.StringValue(webhookEndpoint.Id)
+	plan.SigningSecret = types.StringValue(webhookEndpoint.SigningSecret)
+
+	// Write logs using the tflog package
+	// Documentation: https://terraform.io/plugin/log
+	tflog.Trace(ctx, "created a propelauth_webhook_endpoint resource")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *webhookEndpointResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// Get current state and read it into the model
+	var state webhookEndpointResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	webhookEndpoint, err := r.client.GetWebhookEndpoint(state.Environment.ValueString(), state.Id.ValueString())
+	if err != nil {
+		// If error is "not_found", it indicates that the resource should be deleted.
+		if propelauth.IsPropelAuthNotFoundError(err) {
+			tflog.Trace(ctx, "deleting a propelauth_webhook_endpoint resource because it was not found in PropelAuth")
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Error Reading PropelAuth Webhook Endpoint",
+			"Could not read PropelAuth Webhook Endpoint: "+err.Error(),
+		)
+		return
+	}
+
+	state.Url = types.StringValue(webhookEndpoint.Url)
+	state.Enabled = types.BoolValue(webhookEndpoint.Enabled)
+	if webhookEndpoint.Description != "" || !state.Description.IsNull() {
+		state.Description = types.StringValue(webhookEndpoint.Description)
+	}
+	// an endpoint without event types is sent every event
+	if len(webhookEndpoint.EventTypes) == 0 {
+		state.EventTypes = types.SetNull(types.StringType)
+	} else {
+		eventTypes, diags := types.SetValueFrom(ctx, types.StringType, webhookEndpoint.EventTypes)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		state.EventTypes = eventTypes
+	}
+
+	// the signing secret is only read when it isn't known yet, e.g. after an import
+	if state.SigningSecret.IsNull() {
+		signingSecret, err := r.client.GetWebhookEndpointSecret(state.Environment.ValueString(), state.Id.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Reading PropelAuth Webhook Endpoint",
+				"Could not read the signing secret of the PropelAuth Webhook Endpoint: "+err.Error(),
+			)
+			return
+		}
+		state.SigningSecret = types.StringValue(signingSecret)
+	}
+
+	// Save updated state into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *webhookEndpointResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Read Terraform plan data into the model
+	var plan webhookEndpointResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	request, diags := webhookEndpointRequestFromPlan(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.UpdateWebhookEndpoint(plan.Environment.ValueString(), plan.Id.ValueString(), request)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating webhook endpoint",
+			"Could not update webhook endpoint, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	tflog.Trace(ctx, "updated a propelauth_webhook_endpoint resource")
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *webhookEndpointResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state webhookEndpointResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Delete existing webhook endpoint
+	err := r.client.DeleteWebhookEndpoint(state.Environment.ValueString(), state.Id.ValueString())
+	if err != nil && !propelauth.IsPropelAuthNotFoundError(err) {
+		resp.Diagnostics.AddError(
+			"Error deleting webhook endpoint",
+			"Could not delete webhook endpoint, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	tflog.Trace(ctx, "deleted a propelauth_webhook_endpoint resource")
+}
+
+func (r *webhookEndpointResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	environment, webhookEndpointId, found := strings.Cut(req.ID, "/")
+	if !found || (environment != "Test" && environment != "Staging" && environment != "Prod") || webhookEndpointId == "" {
+		resp.Diagnostics.AddError(
+			"Invalid import ID",
+			fmt.Sprintf("Expected an import ID of the form `<environment>/<webhook_endpoint_id>`, e.g. `Prod/ep_2b4c6d8e0f`, got: %s", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("environment"), environment)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), webhookEndpointId)...)
+}
+
+// webhookEndpointRequestFromPlan builds the request to save the planned webhook endpoint.
+func webhookEndpointRequestFromPlan(ctx context.Context, plan webhookEndpointResourceModel) (propelauth.WebhookEndpointRequest, diag.Diagnostics) {
+	eventTypes := []string{}
+	if !plan.EventTypes.IsNull() {
+		diags := plan.EventTypes.ElementsAs(ctx, &eventTypes, false)
+		if diags.HasError() {
+			return propelauth.WebhookEndpointRequest{}, diags
+		}
+	}
+
+	return propelauth.WebhookEndpointRequest{
+		Url:         plan.Url.ValueString(),
+		EventTypes:  eventTypes,
+		Enabled:     plan.Enabled.ValueBool(),
+		Description: plan.Description.ValueString(),
+	}, nil
+}
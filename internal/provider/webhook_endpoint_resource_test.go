@@ -0,0 +1,52 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccWebhookEndpointResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Unknown event types are rejected when planning
+			{
+				Config:      testAccWebhookEndpointResourceConfig("https://warehouse.example.com/propelauth", "user.creatd"),
+				ExpectError: regexp.MustCompile("Unknown webhook event type"),
+			},
+			// Create and Read testing
+			{
+				Config: testAccWebhookEndpointResourceConfig("https://warehouse.example.com/propelauth", "user.created"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("propelauth_webhook_endpoint.test", "url", "https://warehouse.example.com/propelauth"),
+					resource.TestCheckResourceAttr("propelauth_webhook_endpoint.test", "event_types.#", "1"),
+					resource.TestCheckResourceAttr("propelauth_webhook_endpoint.test", "enabled", "true"),
+					resource.TestCheckResourceAttrSet("propelauth_webhook_endpoint.test", "signing_secret"),
+				),
+			},
+			// Update and Read testing
+			{
+				Config: testAccWebhookEndpointResourceConfig("https://warehouse.example.com/propelauth/v2", "org.created"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("propelauth_webhook_endpoint.test", "url", "https://warehouse.example.com/propelauth/v2"),
+					resource.TestCheckTypeSetElemAttr("propelauth_webhook_endpoint.test", "event_types.*", "org.created"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccWebhookEndpointResourceConfig(url string, eventType string) string {
+	return providerConfig + fmt.Sprintf(`
+resource "propelauth_webhook_endpoint" "test" {
+  environment = "Test"
+  url         = %[1]q
+  event_types = [%[2]q]
+  description = "Data warehouse sync"
+}
+`, url, eventType)
+}
@@ -0,0 +1,114 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"terraform-provider-propelauth/internal/propelauth"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource = &webhookEventTypesDataSource{}
+)
+
+// NewWebhookEventTypesDataSource is a helper function to simplify the provider implementation.
+func NewWebhookEventTypesDataSource() datasource.DataSource {
+	return &webhookEventTypesDataSource{}
+}
+
+type webhookEventTypesDataSource struct {
+	client *propelauth.PropelAuthClient
+}
+
+type webhookEventTypesDataSourceModel struct {
+	EventTypes []webhookEventTypeModel `tfsdk:"event_types"`
+	Names      []types.String          `tfsdk:"names"`
+}
+
+type webhookEventTypeModel struct {
+	Name        types.String `tfsdk:"name"`
+	Description types.String `tfsdk:"description"`
+}
+
+// Metadata returns the data source type name.
+func (d *webhookEventTypesDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_webhook_event_types"
+}
+
+// Schema defines the schema for the data source.
+func (d *webhookEventTypesDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Retrieves the event types that a `propelauth_webhook_endpoint` can subscribe to.",
+		Attributes: map[string]schema.Attribute{
+			"event_types": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "The event types that webhook endpoints can subscribe to.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Computed:    true,
+							Description: "The name of the event type, e.g. `user.created`.",
+						},
+						"description": schema.StringAttribute{
+							Computed:    true,
+							Description: "When events of the type are sent.",
+						},
+					},
+				},
+			},
+			"names": schema.ListAttribute{
+				Computed:    true,
+				ElementType: types.StringType,
+				Description: "The names of the event types, for use in `event_types` of a `propelauth_webhook_endpoint`.",
+			},
+		},
+	}
+}
+
+func (d *webhookEventTypesDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*propelauth.PropelAuthClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data source Configure Type",
+			fmt.Sprintf("Expected *propelauth.PropelAuthClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *webhookEventTypesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state webhookEventTypesDataSourceModel
+
+	// Fetch the data from the PropelAuth API
+	eventTypes, err := d.client.GetWebhookEventTypes()
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to fetch webhook event types from PropelAuth API", err.Error())
+		return
+	}
+	state.EventTypes = make([]webhookEventTypeModel, len(eventTypes))
+	state.Names = make([]types.String, len(eventTypes))
+	for i, eventType := range eventTypes {
+		state.EventTypes[i] = webhookEventTypeModel{
+			Name:        types.StringValue(eventType.Name),
+			Description: types.StringValue(eventType.Description),
+		}
+		state.Names[i] = types.StringValue(eventType.Name)
+	}
+
+	// Write the data to the Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"flag"
 	"log"
+	"os"
 
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
 
@@ -31,10 +32,21 @@ var (
 
 func main() {
 	var debug bool
+	var previewTheme string
+	var previewOutput string
 
 	flag.BoolVar(&debug, "debug", false, "set to true to run the provider with support for debuggers like delve")
+	flag.StringVar(&previewTheme, "preview-theme", "", "path to the output of `terraform show -json` to render an HTML preview of its themes from, instead of running the provider")
+	flag.StringVar(&previewOutput, "preview-output", "theme-preview.html", "path the HTML preview is written to when using -preview-theme")
 	flag.Parse()
 
+	if previewTheme != "" {
+		if err := renderThemePreview(previewTheme, previewOutput); err != nil {
+			log.Fatal(err.Error())
+		}
+		return
+	}
+
 	opts := providerserver.ServeOpts{
 		// TODO: Update this string with the published name of your provider.
 		// Also update the tfplugindocs generate command to either remove the
@@ -49,3 +61,20 @@ func main() {
 		log.Fatal(err.Error())
 	}
 }
+
+// renderThemePreview writes a static HTML preview of the hosted pages for the themes in a plan or state,
+// so theme changes can be reviewed without applying them to a live project.
+func renderThemePreview(terraformJsonPath string, outputPath string) error {
+	terraformJson, err := os.ReadFile(terraformJsonPath)
+	if err != nil {
+		return err
+	}
+
+	output, err := os.Create(outputPath)
+	if err != nil {
+		return err
+	}
+	defer output.Close() //nolint:errcheck
+
+	return provider.RenderThemePreview(context.Background(), terraformJson, output)
+}